@@ -43,6 +43,11 @@ func (src *AWSCluster) ConvertTo(dstRaw conversion.Hub) error {
 	}
 
 	dst.Spec.S3Bucket = restored.Spec.S3Bucket
+	restoreSubnets(restored.Spec.NetworkSpec.Subnets, dst.Spec.NetworkSpec.Subnets)
+
+	if restored.Status.Bastion != nil && dst.Status.Bastion != nil {
+		restoreInstance(restored.Status.Bastion, dst.Status.Bastion)
+	}
 
 	return nil
 }
@@ -54,6 +59,34 @@ func restoreControlPlaneLoadBalancer(restored, dst *infrav1.AWSLoadBalancerSpec)
 	dst.HealthCheckProtocol = restored.HealthCheckProtocol
 }
 
+// restoreSubnets manually restores the subnets data, matching the subnets by position.
+func restoreSubnets(restored, dst infrav1.Subnets) {
+	if len(restored) != len(dst) {
+		return
+	}
+	for i := range dst {
+		dst[i].PrivateDNSNameOptions = restored[i].PrivateDNSNameOptions
+	}
+}
+
+// restoreInstance manually restores the instance data.
+// Assumes restored and dst are non-nil.
+func restoreInstance(restored, dst *infrav1.Instance) {
+	dst.InstanceMetadataOptions = restored.InstanceMetadataOptions
+	dst.CapacityReservationSpecification = restored.CapacityReservationSpecification
+	dst.CapacityReservationID = restored.CapacityReservationID
+	dst.HostID = restored.HostID
+	dst.HostResourceGroupArn = restored.HostResourceGroupArn
+	dst.HostAffinity = restored.HostAffinity
+	dst.AdditionalNetworkInterfaces = restored.AdditionalNetworkInterfaces
+	dst.CPUOptions = restored.CPUOptions
+	dst.CreditSpecification = restored.CreditSpecification
+	dst.LaunchTemplate = restored.LaunchTemplate
+	dst.PrivateDNSNameOptions = restored.PrivateDNSNameOptions
+	dst.InstanceStoreVolumes = restored.InstanceStoreVolumes
+	restoreVolumes(restored.RootVolume, dst.RootVolume, restored.NonRootVolumes, dst.NonRootVolumes)
+}
+
 // ConvertFrom converts the v1beta1 AWSCluster receiver to a v1beta1 AWSCluster.
 func (r *AWSCluster) ConvertFrom(srcRaw conversion.Hub) error {
 	src := srcRaw.(*infrav1.AWSCluster)
@@ -37,6 +37,11 @@ func (r *AWSClusterTemplate) ConvertTo(dstRaw conversion.Hub) error {
 	}
 
 	dst.Spec.Template.ObjectMeta = restored.Spec.Template.ObjectMeta
+	restoreSubnets(restored.Spec.Template.Spec.NetworkSpec.Subnets, dst.Spec.Template.Spec.NetworkSpec.Subnets)
+	if restored.Spec.Template.Spec.S3Bucket != nil && dst.Spec.Template.Spec.S3Bucket != nil {
+		dst.Spec.Template.Spec.S3Bucket.KMSKeyID = restored.Spec.Template.Spec.S3Bucket.KMSKeyID
+		dst.Spec.Template.Spec.S3Bucket.PresignedURLDuration = restored.Spec.Template.Spec.S3Bucket.PresignedURLDuration
+	}
 
 	return nil
 }
@@ -36,10 +36,63 @@ func (src *AWSMachine) ConvertTo(dstRaw conversion.Hub) error {
 	}
 
 	dst.Spec.Ignition = restored.Spec.Ignition
+	dst.Spec.InstanceMetadataOptions = restored.Spec.InstanceMetadataOptions
+	dst.Spec.CapacityReservationSpecification = restored.Spec.CapacityReservationSpecification
+	dst.Spec.HostID = restored.Spec.HostID
+	dst.Spec.HostResourceGroupArn = restored.Spec.HostResourceGroupArn
+	dst.Spec.HostAffinity = restored.Spec.HostAffinity
+	dst.Spec.AdditionalNetworkInterfaces = restored.Spec.AdditionalNetworkInterfaces
+	dst.Spec.ElasticIP = restored.Spec.ElasticIP
+	dst.Spec.CPUOptions = restored.Spec.CPUOptions
+	dst.Spec.CreditSpecification = restored.Spec.CreditSpecification
+	dst.Spec.InstanceTypeFallbacks = restored.Spec.InstanceTypeFallbacks
+	dst.Spec.FailureDomainFallback = restored.Spec.FailureDomainFallback
+	dst.Spec.AMI.SSMParameter = restored.Spec.AMI.SSMParameter
+	dst.Spec.LaunchTemplate = restored.Spec.LaunchTemplate
+	dst.Spec.PrivateDNSNameOptions = restored.Spec.PrivateDNSNameOptions
+	dst.Spec.CloudInit.KMSKeyID = restored.Spec.CloudInit.KMSKeyID
+	dst.Spec.CloudInit.SSMParameterTier = restored.Spec.CloudInit.SSMParameterTier
+	restoreIgnition(restored.Spec.Ignition, dst.Spec.Ignition)
+	restoreVolumes(restored.Spec.RootVolume, dst.Spec.RootVolume, restored.Spec.NonRootVolumes, dst.Spec.NonRootVolumes)
+	dst.Status.ElasticIPAllocationID = restored.Status.ElasticIPAllocationID
+	dst.Status.RetainedVolumeIDs = restored.Status.RetainedVolumeIDs
+	dst.Status.VolumeSnapshotIDs = restored.Status.VolumeSnapshotIDs
+	dst.Status.ScheduledEvents = restored.Status.ScheduledEvents
+	dst.Status.InstanceType = restored.Status.InstanceType
+	dst.Status.InstanceStoreVolumes = restored.Status.InstanceStoreVolumes
 
 	return nil
 }
 
+// restoreIgnition manually restores the ignition data.
+func restoreIgnition(restored, dst *infrav1.Ignition) {
+	if restored == nil || dst == nil {
+		return
+	}
+	dst.StorageType = restored.StorageType
+	dst.TLS = restored.TLS
+	dst.Proxy = restored.Proxy
+}
+
+// restoreVolumes manually restores the volume data.
+// Non root volumes are matched by position as the conversion doesn't reorder them.
+func restoreVolumes(restoredRoot, dstRoot *infrav1.Volume, restoredNonRoot, dstNonRoot []infrav1.Volume) {
+	if restoredRoot != nil && dstRoot != nil {
+		restoreVolume(restoredRoot, dstRoot)
+	}
+	if len(restoredNonRoot) == len(dstNonRoot) {
+		for i := range dstNonRoot {
+			restoreVolume(&restoredNonRoot[i], &dstNonRoot[i])
+		}
+	}
+}
+
+func restoreVolume(restored, dst *infrav1.Volume) {
+	dst.VolumeDeletionPolicy = restored.VolumeDeletionPolicy
+	dst.SnapshotID = restored.SnapshotID
+	dst.VirtualName = restored.VirtualName
+}
+
 // ConvertFrom converts the v1beta2 AWSMachine to a v1beta1 AWSMachine.
 func (dst *AWSMachine) ConvertFrom(srcRaw conversion.Hub) error {
 	src := srcRaw.(*infrav1.AWSMachine)
@@ -81,6 +134,26 @@ func (r *AWSMachineTemplate) ConvertTo(dstRaw conversion.Hub) error {
 
 	dst.Spec.Template.ObjectMeta = restored.Spec.Template.ObjectMeta
 	dst.Spec.Template.Spec.Ignition = restored.Spec.Template.Spec.Ignition
+	dst.Spec.Template.Spec.InstanceMetadataOptions = restored.Spec.Template.Spec.InstanceMetadataOptions
+	dst.Spec.Template.Spec.CapacityReservationSpecification = restored.Spec.Template.Spec.CapacityReservationSpecification
+	dst.Spec.Template.Spec.HostID = restored.Spec.Template.Spec.HostID
+	dst.Spec.Template.Spec.HostResourceGroupArn = restored.Spec.Template.Spec.HostResourceGroupArn
+	dst.Spec.Template.Spec.HostAffinity = restored.Spec.Template.Spec.HostAffinity
+	dst.Spec.Template.Spec.AdditionalNetworkInterfaces = restored.Spec.Template.Spec.AdditionalNetworkInterfaces
+	dst.Spec.Template.Spec.ElasticIP = restored.Spec.Template.Spec.ElasticIP
+	dst.Spec.Template.Spec.CPUOptions = restored.Spec.Template.Spec.CPUOptions
+	dst.Spec.Template.Spec.CreditSpecification = restored.Spec.Template.Spec.CreditSpecification
+	dst.Spec.Template.Spec.InstanceTypeFallbacks = restored.Spec.Template.Spec.InstanceTypeFallbacks
+	dst.Spec.Template.Spec.FailureDomainFallback = restored.Spec.Template.Spec.FailureDomainFallback
+	dst.Spec.Template.Spec.AMI.SSMParameter = restored.Spec.Template.Spec.AMI.SSMParameter
+	dst.Spec.Template.Spec.LaunchTemplate = restored.Spec.Template.Spec.LaunchTemplate
+	dst.Spec.Template.Spec.PrivateDNSNameOptions = restored.Spec.Template.Spec.PrivateDNSNameOptions
+	dst.Spec.Template.Spec.CloudInit.KMSKeyID = restored.Spec.Template.Spec.CloudInit.KMSKeyID
+	dst.Spec.Template.Spec.CloudInit.SSMParameterTier = restored.Spec.Template.Spec.CloudInit.SSMParameterTier
+	restoreIgnition(restored.Spec.Template.Spec.Ignition, dst.Spec.Template.Spec.Ignition)
+	restoreVolumes(restored.Spec.Template.Spec.RootVolume, dst.Spec.Template.Spec.RootVolume, restored.Spec.Template.Spec.NonRootVolumes, dst.Spec.Template.Spec.NonRootVolumes)
+	dst.Status.ResolvedAMI = restored.Status.ResolvedAMI
+	dst.Status.Conditions = restored.Status.Conditions
 
 	return nil
 }
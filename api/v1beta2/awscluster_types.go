@@ -208,11 +208,28 @@ type AWSClusterStatus struct {
 type S3Bucket struct {
 	// ControlPlaneIAMInstanceProfile is a name of the IAMInstanceProfile, which will be allowed
 	// to read control-plane node bootstrap data from S3 Bucket.
-	ControlPlaneIAMInstanceProfile string `json:"controlPlaneIAMInstanceProfile"`
+	// Required unless the bootstrap data is delivered through pre-signed URLs.
+	// +optional
+	ControlPlaneIAMInstanceProfile string `json:"controlPlaneIAMInstanceProfile,omitempty"`
 
 	// NodesIAMInstanceProfiles is a list of IAM instance profiles, which will be allowed to read
 	// worker nodes bootstrap data from S3 Bucket.
-	NodesIAMInstanceProfiles []string `json:"nodesIAMInstanceProfiles"`
+	// Required unless the bootstrap data is delivered through pre-signed URLs.
+	// +optional
+	NodesIAMInstanceProfiles []string `json:"nodesIAMInstanceProfiles,omitempty"`
+
+	// KMSKeyID is the ID or ARN of the customer managed KMS key the bootstrap data objects are
+	// encrypted with using SSE-KMS. Defaults to the AWS managed S3 key.
+	// The controller must be allowed to generate data keys with and decrypt using the key.
+	// +optional
+	KMSKeyID string `json:"kmsKeyID,omitempty"`
+
+	// PresignedURLDuration enables delivering the bootstrap data to instances through pre-signed URLs
+	// valid for the given duration, up to 7 days, instead of granting the instance profiles read access
+	// to the bucket in its bucket policy. The URLs are only valid while the credentials of the controller
+	// are, and the bootstrap data objects are deleted once their machine has joined the cluster.
+	// +optional
+	PresignedURLDuration *metav1.Duration `json:"presignedURLDuration,omitempty"`
 
 	// Name defines name of S3 Bucket to be created.
 	// +kubebuilder:validation:MinLength:=3
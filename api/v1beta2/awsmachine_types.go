@@ -43,6 +43,17 @@ var (
 	SecretBackendSecretsManager = SecretBackend("secrets-manager")
 )
 
+// SSMParameterTier defines the tier of the AWS Systems Manager Parameter Store parameters storing the userdata.
+type SSMParameterTier string
+
+var (
+	// SSMParameterTierStandard defines standard parameters, which hold up to 4KB of data at no additional charge.
+	SSMParameterTierStandard = SSMParameterTier("Standard")
+
+	// SSMParameterTierAdvanced defines advanced parameters, which hold up to 8KB of data but are charged for.
+	SSMParameterTierAdvanced = SSMParameterTier("Advanced")
+)
+
 // AWSMachineSpec defines the desired state of an Amazon EC2 instance.
 type AWSMachineSpec struct {
 	// ProviderID is the unique identifier as specified by the cloud provider.
@@ -80,6 +91,21 @@ type AWSMachineSpec struct {
 	// +kubebuilder:validation:MinLength:=2
 	InstanceType string `json:"instanceType"`
 
+	// InstanceTypeFallbacks is an ordered list of instance types to launch the instance with, one after
+	// the other, when AWS does not have sufficient capacity to launch an instance of InstanceType. Instance types
+	// that do not support the architecture of the AMI or the CPUOptions are skipped. The machine fails once there is
+	// not sufficient capacity for any of the instance types.
+	// +optional
+	InstanceTypeFallbacks []string `json:"instanceTypeFallbacks,omitempty"`
+
+	// FailureDomainFallback, when set to true, allows the instance to be launched in the subnets of the other
+	// failure domains of the cluster when AWS does not have sufficient capacity to launch an instance of any of
+	// the instance types in the subnet picked for the machine. FailureDomain, and with it the failure domain of the
+	// Machine, is then updated to the one the instance is launched in.
+	// It has no effect when Subnet, NetworkInterfaces or the subnet of any AdditionalNetworkInterfaces are set.
+	// +optional
+	FailureDomainFallback bool `json:"failureDomainFallback,omitempty"`
+
 	// AdditionalTags is an optional set of tags to add to an instance, in addition to the ones added by default by the
 	// AWS provider. If both the AWSCluster and the AWSMachine specify the same tag name with different values, the
 	// AWSMachine's value takes precedence.
@@ -90,6 +116,10 @@ type AWSMachineSpec struct {
 	// +optional
 	IAMInstanceProfile string `json:"iamInstanceProfile,omitempty"`
 
+	// InstanceMetadataOptions is the metadata options for the EC2 instance.
+	// +optional
+	InstanceMetadataOptions *InstanceMetadataOptions `json:"instanceMetadataOptions,omitempty"`
+
 	// PublicIP specifies whether the instance should get a public IP.
 	// Precedence for this setting is as follows:
 	// 1. This field if set
@@ -133,6 +163,11 @@ type AWSMachineSpec struct {
 	// +kubebuilder:validation:MaxItems=2
 	NetworkInterfaces []string `json:"networkInterfaces,omitempty"`
 
+	// AdditionalNetworkInterfaces is a list of ENIs to create and attach to the instance at launch,
+	// in addition to the primary network interface. They are deleted when the instance is terminated.
+	// +optional
+	AdditionalNetworkInterfaces []AdditionalNetworkInterface `json:"additionalNetworkInterfaces,omitempty"`
+
 	// UncompressedUserData specify whether the user data is gzip-compressed before it is sent to ec2 instance.
 	// cloud-init has built-in support for gzip-compressed user data
 	// user data stored in aws secret manager is always gzip-compressed.
@@ -157,6 +192,57 @@ type AWSMachineSpec struct {
 	// +optional
 	// +kubebuilder:validation:Enum:=default;dedicated;host
 	Tenancy string `json:"tenancy,omitempty"`
+
+	// HostID specifies the Dedicated Host on which the instance should be launched.
+	// Requires Tenancy to be set to host.
+	// +optional
+	HostID *string `json:"hostID,omitempty"`
+
+	// HostResourceGroupArn specifies the ARN of the host resource group in which to launch the instance.
+	// Requires Tenancy to be set to host and can not be used together with HostID.
+	// +optional
+	HostResourceGroupArn *string `json:"hostResourceGroupArn,omitempty"`
+
+	// HostAffinity specifies the affinity setting for the instance and the Dedicated Host.
+	// When set to host, an instance stopped and restarted will always restart on the same host.
+	// Requires Tenancy to be set to host.
+	// +optional
+	// +kubebuilder:validation:Enum:=default;host
+	HostAffinity *string `json:"hostAffinity,omitempty"`
+
+	// ElasticIP configures a static Elastic IP to associate with the instance after launch,
+	// so that the public IP of the machine is stable across replacements.
+	// +optional
+	ElasticIP *ElasticIPSpec `json:"elasticIP,omitempty"`
+
+	// CPUOptions configures the number of CPU cores and threads per core of the instance.
+	// +optional
+	CPUOptions *CPUOptions `json:"cpuOptions,omitempty"`
+
+	// CreditSpecification configures the credit option for CPU usage of burstable performance instances.
+	// +optional
+	CreditSpecification *CreditSpecification `json:"creditSpecification,omitempty"`
+
+	// CapacityReservationSpecification configures the On-Demand Capacity Reservation targeting of the instance.
+	// Can not be used together with SpotMarketOptions.
+	// +optional
+	CapacityReservationSpecification *CapacityReservationSpecification `json:"capacityReservationSpecification,omitempty"`
+
+	// LaunchTemplate is an EC2 launch template to launch the instance from, either one provided by the user or
+	// one managed by an AWSMachinePool. The fields set in this spec override the ones of the launch template,
+	// and the AMI of the launch template is used unless the AMI or an image lookup is set in this spec.
+	// The subnet and the security groups of the launch template are kept unless a subnet or network interfaces are
+	// set for the machine, or its failure domain is not the one of the subnet of the launch template, in which case
+	// CAPA picks them as for machines launched without a launch template. The SSH key of the launch template is kept
+	// unless an SSH key is set for the machine or the cluster. The core security groups of the cluster are attached
+	// once the instance is running, alongside the security groups of the launch template.
+	// +optional
+	LaunchTemplate *LaunchTemplateReference `json:"launchTemplate,omitempty"`
+
+	// PrivateDNSNameOptions configures the hostname of the instance, which becomes the name of its node, and the
+	// DNS records created for it. Defaults to the options of the subnet the instance is launched in.
+	// +optional
+	PrivateDNSNameOptions *PrivateDNSNameOptions `json:"privateDnsNameOptions,omitempty"`
 }
 
 // CloudInit defines options related to the bootstrapping systems where
@@ -184,16 +270,88 @@ type CloudInit struct {
 	// +optional
 	// +kubebuilder:validation:Enum=secrets-manager;ssm-parameter-store
 	SecureSecretsBackend SecretBackend `json:"secureSecretsBackend,omitempty"`
+
+	// KMSKeyID is the ID, ARN or alias of the customer managed AWS KMS key used to encrypt the userdata
+	// in the secret backend. Defaults to the AWS managed key of the secret backend.
+	// The controllers and the nodes need to be allowed to use the key.
+	// +optional
+	KMSKeyID string `json:"kmsKeyID,omitempty"`
+
+	// SSMParameterTier is the tier of the AWS Systems Manager Parameter Store parameters used to store
+	// the userdata, and can only be set when SecureSecretsBackend is ssm-parameter-store. Advanced
+	// parameters hold twice as much data as standard ones, so fewer of them are needed.
+	// Defaults to Standard.
+	// +optional
+	// +kubebuilder:validation:Enum=Standard;Advanced
+	SSMParameterTier SSMParameterTier `json:"ssmParameterTier,omitempty"`
 }
 
 // Ignition defines options related to the bootstrapping systems where Ignition is used.
 type Ignition struct {
 	// Version defines which version of Ignition will be used to generate bootstrap data.
+	// Version 3 is required by images such as Flatcar Container Linux 3185 and later and Fedora CoreOS.
 	//
 	// +optional
 	// +kubebuilder:default="2.3"
-	// +kubebuilder:validation:Enum="2.3"
+	// +kubebuilder:validation:Enum="2.3";"3.0";"3.1";"3.2";"3.3"
 	Version string `json:"version,omitempty"`
+
+	// StorageType defines where the bootstrap data is stored for Ignition to fetch it from.
+	//
+	// ClusterObjectStore, the default, stores it in the S3 bucket of the cluster (spec.s3Bucket of the AWSCluster)
+	// and passes a stub configuration referencing the object as user data.
+	//
+	// UnencryptedUserData passes the bootstrap data as EC2 user data, unencrypted. It is only suitable for small
+	// configurations, as user data is limited to 16KB, and exposes the bootstrap data, which contains secrets,
+	// to anyone allowed to describe the instance attributes or to access the instance metadata service.
+	//
+	// +optional
+	// +kubebuilder:validation:Enum=ClusterObjectStore;UnencryptedUserData
+	StorageType IgnitionStorageType `json:"storageType,omitempty"`
+
+	// TLS defines the TLS settings Ignition uses to fetch the bootstrap data referenced by the stub configuration.
+	// Only supported with the ClusterObjectStore storage type and Ignition version 3.
+	// +optional
+	TLS *IgnitionTLS `json:"tls,omitempty"`
+
+	// Proxy defines the proxy Ignition uses to fetch the bootstrap data referenced by the stub configuration.
+	// Only supported with the ClusterObjectStore storage type and Ignition version 3.1 and later.
+	// +optional
+	Proxy *IgnitionProxy `json:"proxy,omitempty"`
+}
+
+// IgnitionStorageType describes where the bootstrap data is stored for Ignition.
+type IgnitionStorageType string
+
+const (
+	// IgnitionStorageTypeClusterObjectStore stores the bootstrap data in the S3 bucket of the cluster.
+	IgnitionStorageTypeClusterObjectStore = IgnitionStorageType("ClusterObjectStore")
+
+	// IgnitionStorageTypeUnencryptedUserData passes the bootstrap data as unencrypted EC2 user data.
+	IgnitionStorageTypeUnencryptedUserData = IgnitionStorageType("UnencryptedUserData")
+)
+
+// IgnitionTLS defines the TLS settings of Ignition.
+type IgnitionTLS struct {
+	// CASources are the sources of additional certificate authorities Ignition trusts when fetching
+	// the bootstrap data, e.g. data URLs with the PEM encoded certificates.
+	// +optional
+	CASources []string `json:"caSources,omitempty"`
+}
+
+// IgnitionProxy defines the proxy settings of Ignition.
+type IgnitionProxy struct {
+	// HTTPProxy is the URL of the proxy for HTTP requests.
+	// +optional
+	HTTPProxy *string `json:"httpProxy,omitempty"`
+
+	// HTTPSProxy is the URL of the proxy for HTTPS requests.
+	// +optional
+	HTTPSProxy *string `json:"httpsProxy,omitempty"`
+
+	// NoProxy are the hosts, domains and IP ranges requests are not proxied for.
+	// +optional
+	NoProxy []string `json:"noProxy,omitempty"`
 }
 
 // AWSMachineStatus defines the observed state of AWSMachine.
@@ -214,6 +372,34 @@ type AWSMachineStatus struct {
 	// +optional
 	InstanceState *InstanceState `json:"instanceState,omitempty"`
 
+	// InstanceType is the type of the instance launched for this machine. It differs from spec.instanceType
+	// when the instance was launched with one of spec.instanceTypeFallbacks.
+	// +optional
+	InstanceType string `json:"instanceType,omitempty"`
+
+	// ElasticIPAllocationID is the allocation ID of the Elastic IP associated with the instance.
+	// +optional
+	ElasticIPAllocationID *string `json:"elasticIPAllocationID,omitempty"`
+
+	// RetainedVolumeIDs are the IDs of the volumes kept after the instance was terminated,
+	// as requested by their volumeDeletionPolicy.
+	// +optional
+	RetainedVolumeIDs []string `json:"retainedVolumeIDs,omitempty"`
+
+	// VolumeSnapshotIDs are the IDs of the snapshots taken of the volumes before the instance was terminated,
+	// as requested by their volumeDeletionPolicy.
+	// +optional
+	VolumeSnapshotIDs []string `json:"volumeSnapshotIDs,omitempty"`
+
+	// InstanceStoreVolumes are the instance store volumes mapped to the instance at launch.
+	// They are not reported by EC2 together with the EBS volumes of the instance.
+	// +optional
+	InstanceStoreVolumes []InstanceStoreVolume `json:"instanceStoreVolumes,omitempty"`
+
+	// ScheduledEvents are the maintenance events AWS scheduled for the instance, such as a reboot or its retirement.
+	// +optional
+	ScheduledEvents []InstanceScheduledEvent `json:"scheduledEvents,omitempty"`
+
 	// FailureReason will be set in the event that there is a terminal problem
 	// reconciling the Machine and will contain a succinct value suitable
 	// for machine interpretation.
@@ -257,6 +443,31 @@ type AWSMachineStatus struct {
 	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
 }
 
+// InstanceScheduledEvent describes a maintenance event scheduled by AWS for an instance.
+type InstanceScheduledEvent struct {
+	// ID of the event.
+	ID string `json:"id"`
+
+	// Code of the event, e.g. instance-reboot, system-maintenance or instance-retirement.
+	Code string `json:"code"`
+
+	// Description of the event.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// NotBefore is the earliest scheduled start time of the event.
+	// +optional
+	NotBefore *metav1.Time `json:"notBefore,omitempty"`
+
+	// NotAfter is the latest scheduled end time of the event.
+	// +optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+
+	// NotBeforeDeadline is the deadline for starting the event, up to which it can be rescheduled.
+	// +optional
+	NotBeforeDeadline *metav1.Time `json:"notBeforeDeadline,omitempty"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:path=awsmachines,scope=Namespaced,categories=cluster-api,shortName=awsm
 // +kubebuilder:storageversion
@@ -17,6 +17,9 @@ limitations under the License.
 package v1beta2
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -55,6 +58,8 @@ func (r *AWSMachine) ValidateCreate() error {
 	allErrs = append(allErrs, r.validateNonRootVolumes()...)
 	allErrs = append(allErrs, r.validateSSHKeyName()...)
 	allErrs = append(allErrs, r.validateAdditionalSecurityGroups()...)
+	allErrs = append(allErrs, r.Spec.validateInstanceOptions(field.NewPath("spec"))...)
+	allErrs = append(allErrs, r.Spec.LaunchTemplate.Validate(field.NewPath("spec", "launchTemplate"))...)
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
 
 	return aggregateObjErrors(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
@@ -79,6 +84,7 @@ func (r *AWSMachine) ValidateUpdate(old runtime.Object) error {
 
 	allErrs = append(allErrs, r.validateCloudInitSecret()...)
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
+	allErrs = append(allErrs, r.validateVolumeModifications(old.(*AWSMachine))...)
 
 	newAWSMachineSpec := newAWSMachine["spec"].(map[string]interface{})
 	oldAWSMachineSpec := oldAWSMachine["spec"].(map[string]interface{})
@@ -91,6 +97,12 @@ func (r *AWSMachine) ValidateUpdate(old runtime.Object) error {
 	delete(oldAWSMachineSpec, "instanceID")
 	delete(newAWSMachineSpec, "instanceID")
 
+	// allow changes to failureDomain when the instance may be launched in another failure domain
+	if r.Spec.FailureDomainFallback {
+		delete(oldAWSMachineSpec, "failureDomain")
+		delete(newAWSMachineSpec, "failureDomain")
+	}
+
 	// allow changes to additionalTags
 	delete(oldAWSMachineSpec, "additionalTags")
 	delete(newAWSMachineSpec, "additionalTags")
@@ -112,6 +124,22 @@ func (r *AWSMachine) ValidateUpdate(old runtime.Object) error {
 		delete(cloudInit, "secureSecretsBackend")
 	}
 
+	// allow changes to the size, type, IOPS and throughput of volumes, which are validated
+	// separately and applied to the attached volumes in place, and to their deletion policy,
+	// which is only applied when the machine is deleted
+	for _, spec := range []map[string]interface{}{oldAWSMachineSpec, newAWSMachineSpec} {
+		if rootVolume, ok := spec["rootVolume"].(map[string]interface{}); ok {
+			deleteModifiableVolumeFields(rootVolume)
+		}
+		if nonRootVolumes, ok := spec["nonRootVolumes"].([]interface{}); ok {
+			for _, volume := range nonRootVolumes {
+				if volume, ok := volume.(map[string]interface{}); ok {
+					deleteModifiableVolumeFields(volume)
+				}
+			}
+		}
+	}
+
 	if !cmp.Equal(oldAWSMachineSpec, newAWSMachineSpec) {
 		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec"), "cannot be modified"))
 	}
@@ -119,6 +147,62 @@ func (r *AWSMachine) ValidateUpdate(old runtime.Object) error {
 	return aggregateObjErrors(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
 }
 
+func deleteModifiableVolumeFields(volume map[string]interface{}) {
+	delete(volume, "size")
+	delete(volume, "type")
+	delete(volume, "iops")
+	delete(volume, "throughput")
+	delete(volume, "volumeDeletionPolicy")
+}
+
+// validateVolumeModifications makes sure that in place volume modifications only increase the size,
+// IOPS and throughput of the volumes. Any other change is rejected as a spec modification.
+func (r *AWSMachine) validateVolumeModifications(old *AWSMachine) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if r.Spec.RootVolume != nil && old.Spec.RootVolume != nil {
+		allErrs = append(allErrs, validateVolumeModification(r.Spec.RootVolume, old.Spec.RootVolume, field.NewPath("spec", "rootVolume"))...)
+	}
+
+	if len(r.Spec.NonRootVolumes) == len(old.Spec.NonRootVolumes) {
+		for i := range r.Spec.NonRootVolumes {
+			allErrs = append(allErrs, validateVolumeModification(&r.Spec.NonRootVolumes[i], &old.Spec.NonRootVolumes[i], field.NewPath("spec", "nonRootVolumes").Index(i))...)
+		}
+	}
+
+	return allErrs
+}
+
+func validateVolumeModification(newVolume, oldVolume *Volume, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if newVolume.Size < oldVolume.Size {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("size"), newVolume.Size, "volume size can only be increased"))
+	}
+	if newVolume.Type != oldVolume.Type {
+		if (newVolume.Type == VolumeTypeIO1 || newVolume.Type == VolumeTypeIO2) && newVolume.IOPS == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("iops"), "iops required if type is 'io1' or 'io2'"))
+		}
+		if newVolume.Type != VolumeTypeGP3 && newVolume.Throughput != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("throughput"), newVolume.Throughput, "throughput is valid only for type 'gp3'"))
+		}
+	}
+	// IOPS and throughput may only be dropped when changing to a volume type that does not support them.
+	if (newVolume.Type == oldVolume.Type || volumeTypeSupportsIOPS(newVolume.Type)) && newVolume.IOPS < oldVolume.IOPS {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("iops"), newVolume.IOPS, "volume IOPS can only be increased"))
+	}
+	if (newVolume.Type == oldVolume.Type || newVolume.Type == VolumeTypeGP3) &&
+		oldVolume.Throughput != nil && (newVolume.Throughput == nil || *newVolume.Throughput < *oldVolume.Throughput) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("throughput"), newVolume.Throughput, "volume throughput can only be increased"))
+	}
+
+	return allErrs
+}
+
+func volumeTypeSupportsIOPS(volumeType VolumeType) bool {
+	return volumeType == VolumeTypeIO1 || volumeType == VolumeTypeIO2 || volumeType == VolumeTypeGP3
+}
+
 func (r *AWSMachine) validateCloudInitSecret() field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -138,6 +222,8 @@ func (r *AWSMachine) validateCloudInitSecret() field.ErrorList {
 		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "cloudInit", "secretCount"), "must be set together with spec.CloudInit.SecretPrefix"))
 	}
 
+	allErrs = append(allErrs, r.Spec.CloudInit.Validate(field.NewPath("spec", "cloudInit"))...)
+
 	return allErrs
 }
 
@@ -148,6 +234,8 @@ func (r *AWSMachine) cloudInitConfigured() bool {
 	configured = configured || r.Spec.CloudInit.SecretCount != 0
 	configured = configured || r.Spec.CloudInit.SecureSecretsBackend != ""
 	configured = configured || r.Spec.CloudInit.InsecureSkipSecretsManager
+	configured = configured || r.Spec.CloudInit.KMSKeyID != ""
+	configured = configured || r.Spec.CloudInit.SSMParameterTier != ""
 
 	return configured
 }
@@ -156,6 +244,50 @@ func (r *AWSMachine) ignitionEnabled() bool {
 	return r.Spec.Ignition != nil
 }
 
+// Validate validates the KMS key and parameter tier settings against the secret backend.
+func (c *CloudInit) Validate(fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if c.InsecureSkipSecretsManager {
+		if c.KMSKeyID != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("kmsKeyID"), "cannot be set if insecureSkipSecretsManager is true"))
+		}
+		if c.SSMParameterTier != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("ssmParameterTier"), "cannot be set if insecureSkipSecretsManager is true"))
+		}
+		return allErrs
+	}
+
+	if c.SSMParameterTier != "" && c.SecureSecretsBackend != SecretBackendSSMParameterStore {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("ssmParameterTier"),
+			fmt.Sprintf("can only be set if secureSecretsBackend is %s", SecretBackendSSMParameterStore)))
+	}
+
+	return allErrs
+}
+
+// Validate validates the Ignition settings against the Ignition version.
+func (i *Ignition) Validate(fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if i == nil || (i.TLS == nil && i.Proxy == nil) {
+		return allErrs
+	}
+
+	if i.StorageType == IgnitionStorageTypeUnencryptedUserData {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("storageType"), "tls and proxy are only supported with the ClusterObjectStore storage type"))
+	}
+
+	switch {
+	case !strings.HasPrefix(i.Version, "3."):
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("version"), "tls and proxy are only supported with Ignition version 3"))
+	case i.Proxy != nil && i.Version == "3.0":
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("proxy"), "proxy is only supported with Ignition version 3.1 and later"))
+	}
+
+	return allErrs
+}
+
 func (r *AWSMachine) validateIgnitionAndCloudInit() field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -169,6 +301,8 @@ func (r *AWSMachine) validateIgnitionAndCloudInit() field.ErrorList {
 		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "cloudInit"), "cannot be set if spec.ignition is set"))
 	}
 
+	allErrs = append(allErrs, r.Spec.Ignition.Validate(field.NewPath("spec", "ignition"))...)
+
 	return allErrs
 }
 
@@ -196,13 +330,15 @@ func (r *AWSMachine) validateRootVolume() field.ErrorList {
 		log.Info("root volume shouldn't have a device name (this can be ignored if performing a `clusterctl move`)")
 	}
 
+	allErrs = append(allErrs, r.Spec.RootVolume.Validate(field.NewPath("spec", "rootVolume"), true)...)
+
 	return allErrs
 }
 
 func (r *AWSMachine) validateNonRootVolumes() field.ErrorList {
 	var allErrs field.ErrorList
 
-	for _, volume := range r.Spec.NonRootVolumes {
+	for i, volume := range r.Spec.NonRootVolumes {
 		if VolumeTypesProvisioned.Has(string(r.Spec.RootVolume.Type)) && volume.IOPS == 0 {
 			allErrs = append(allErrs, field.Required(field.NewPath("spec.nonRootVolumes.iops"), "iops required if type is 'io1' or 'io2'"))
 		}
@@ -219,6 +355,8 @@ func (r *AWSMachine) validateNonRootVolumes() field.ErrorList {
 		if volume.DeviceName == "" {
 			allErrs = append(allErrs, field.Required(field.NewPath("spec.nonRootVolumes.deviceName"), "non root volume should have device name"))
 		}
+
+		allErrs = append(allErrs, volume.Validate(field.NewPath("spec", "nonRootVolumes").Index(i), false)...)
 	}
 
 	return allErrs
@@ -259,6 +397,112 @@ func (r *AWSMachine) validateAdditionalSecurityGroups() field.ErrorList {
 	return allErrs
 }
 
+// validateInstanceOptions validates the fields of an AWSMachine spec that configure how and where its instance is
+// launched, at fldPath. It is shared by the AWSMachine and AWSMachineTemplate webhooks.
+func (s *AWSMachineSpec) validateInstanceOptions(fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	allErrs = append(allErrs, s.validateCapacityReservationSpecification(fldPath)...)
+	allErrs = append(allErrs, s.validateHostPlacement(fldPath)...)
+	allErrs = append(allErrs, s.validateAdditionalNetworkInterfaces(fldPath)...)
+	allErrs = append(allErrs, s.validateElasticIP(fldPath)...)
+	allErrs = append(allErrs, ValidateCPUOptionsForInstanceType(s.CPUOptions, s.CreditSpecification, s.InstanceType, fldPath)...)
+	allErrs = append(allErrs, s.validateInstanceTypeFallbacks(fldPath)...)
+	allErrs = append(allErrs, s.AMI.Validate(fldPath.Child("ami"))...)
+
+	return allErrs
+}
+
+func (s *AWSMachineSpec) validateCapacityReservationSpecification(fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if s.CapacityReservationSpecification == nil {
+		return allErrs
+	}
+
+	allErrs = append(allErrs, s.CapacityReservationSpecification.Validate(fldPath.Child("capacityReservationSpecification"))...)
+	if s.SpotMarketOptions != nil && s.CapacityReservationSpecification.CapacityReservationTarget != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("capacityReservationSpecification", "capacityReservationTarget"),
+			fmt.Sprintf("cannot be set if %s is set", fldPath.Child("spotMarketOptions"))))
+	}
+
+	return allErrs
+}
+
+func (s *AWSMachineSpec) validateHostPlacement(fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if s.Tenancy != "host" {
+		notHost := fmt.Sprintf("can only be set if %s is host", fldPath.Child("tenancy"))
+		if s.HostID != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("hostID"), notHost))
+		}
+		if s.HostResourceGroupArn != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("hostResourceGroupArn"), notHost))
+		}
+		if s.HostAffinity != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("hostAffinity"), notHost))
+		}
+	}
+
+	if s.HostID != nil && s.HostResourceGroupArn != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("hostResourceGroupArn"), fmt.Sprintf("cannot be set if %s is set", fldPath.Child("hostID"))))
+	}
+
+	return allErrs
+}
+
+func (s *AWSMachineSpec) validateAdditionalNetworkInterfaces(fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if len(s.AdditionalNetworkInterfaces) > 0 && s.PublicIP != nil && *s.PublicIP {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("additionalNetworkInterfaces"),
+			fmt.Sprintf("cannot be set if %s is true, public IPs can't be auto-assigned to instances with multiple network interfaces", fldPath.Child("publicIP"))))
+	}
+
+	for i, eni := range s.AdditionalNetworkInterfaces {
+		if eni.SecondaryPrivateIPAddressCount != nil && eni.IPv4PrefixCount != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("additionalNetworkInterfaces").Index(i).Child("ipv4PrefixCount"), "cannot be set together with secondaryPrivateIPAddressCount"))
+		}
+	}
+
+	return allErrs
+}
+
+func (s *AWSMachineSpec) validateElasticIP(fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if s.ElasticIP == nil {
+		return allErrs
+	}
+
+	if len(s.AdditionalNetworkInterfaces) > 0 || len(s.NetworkInterfaces) > 1 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("elasticIP"), "cannot be set for instances with multiple network interfaces"))
+	}
+
+	return allErrs
+}
+
+func (s *AWSMachineSpec) validateInstanceTypeFallbacks(fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	fallbacksPath := fldPath.Child("instanceTypeFallbacks")
+	seen := map[string]bool{s.InstanceType: true}
+	for i, instanceType := range s.InstanceTypeFallbacks {
+		switch {
+		case instanceType == "":
+			allErrs = append(allErrs, field.Required(fallbacksPath.Index(i), "instance type must not be empty"))
+		case seen[instanceType]:
+			allErrs = append(allErrs, field.Duplicate(fallbacksPath.Index(i), instanceType))
+		case s.CreditSpecification != nil && !IsBurstableInstanceType(instanceType):
+			allErrs = append(allErrs, field.Forbidden(fallbacksPath.Index(i), fmt.Sprintf("must be a burstable performance instance type when creditSpecification is set, %q is not one", instanceType)))
+		}
+		seen[instanceType] = true
+	}
+
+	return allErrs
+}
+
 func (r *AWSMachine) validateSSHKeyName() field.ErrorList {
 	return validateSSHKeyName(r.Spec.SSHKeyName)
 }
@@ -24,6 +24,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/pointer"
 
 	utildefaulting "sigs.k8s.io/cluster-api/util/defaulting"
@@ -149,6 +150,67 @@ func TestAWSMachine_Create(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "non root volumes created from a snapshot may omit the size",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					NonRootVolumes: []Volume{
+						{
+							DeviceName: "/dev/sdb",
+							SnapshotID: aws.String("snap-1"),
+						},
+						{
+							DeviceName:  "/dev/sdc",
+							VirtualName: "ephemeral0",
+						},
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ensure non root EBS volumes have a size or a snapshot",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					NonRootVolumes: []Volume{
+						{
+							DeviceName: "/dev/sdb",
+						},
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ensure instance store volumes only set the device and virtual names",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					NonRootVolumes: []Volume{
+						{
+							DeviceName:  "/dev/sdb",
+							VirtualName: "ephemeral0",
+							Size:        50,
+						},
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ensure the root volume is not an instance store volume",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					RootVolume: &Volume{
+						VirtualName: "ephemeral0",
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "additional security groups may have id",
 			machine: &AWSMachine{
@@ -248,6 +310,270 @@ func TestAWSMachine_Create(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "capacity reservation target with reservation ID is accepted",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					CapacityReservationSpecification: &CapacityReservationSpecification{
+						CapacityReservationTarget: &CapacityReservationTarget{
+							CapacityReservationID: aws.String("cr-1234567890abcdef0"),
+						},
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "capacity reservation preference and target are mutually exclusive",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					CapacityReservationSpecification: &CapacityReservationSpecification{
+						CapacityReservationPreference: CapacityReservationPreferenceOpen,
+						CapacityReservationTarget: &CapacityReservationTarget{
+							CapacityReservationID: aws.String("cr-1234567890abcdef0"),
+						},
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "capacity reservation target can't be used with spot market options",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					CapacityReservationSpecification: &CapacityReservationSpecification{
+						CapacityReservationTarget: &CapacityReservationTarget{
+							CapacityReservationID: aws.String("cr-1234567890abcdef0"),
+						},
+					},
+					SpotMarketOptions: &SpotMarketOptions{},
+					InstanceType:      "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "additional network interfaces with secondary IPs are accepted",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					AdditionalNetworkInterfaces: []AdditionalNetworkInterface{
+						{
+							SubnetID:                       "subnet-1",
+							SecondaryPrivateIPAddressCount: aws.Int64(2),
+						},
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "additional network interfaces can't have both secondary IPs and prefixes",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					AdditionalNetworkInterfaces: []AdditionalNetworkInterface{
+						{
+							SecondaryPrivateIPAddressCount: aws.Int64(2),
+							IPv4PrefixCount:                aws.Int64(1),
+						},
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "additional network interfaces can't be used with public IP",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					AdditionalNetworkInterfaces: []AdditionalNetworkInterface{
+						{
+							IPv4PrefixCount: aws.Int64(1),
+						},
+					},
+					PublicIP:     aws.Bool(true),
+					InstanceType: "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "elastic IP from a pool is accepted",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					ElasticIP: &ElasticIPSpec{
+						Pool: &ElasticIPPool{
+							Filters: []Filter{{Name: "tag:eip-pool", Values: []string{"ingress"}}},
+						},
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "elastic IP can't be used with additional network interfaces",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					ElasticIP: &ElasticIPSpec{},
+					AdditionalNetworkInterfaces: []AdditionalNetworkInterface{
+						{
+							SubnetID: "subnet-1",
+						},
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "credit specification is accepted for burstable instance types",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					CreditSpecification: &CreditSpecification{CPUCredits: CPUCreditsUnlimited},
+					InstanceType:        "t3.large",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "credit specification is rejected for non burstable instance types",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					CreditSpecification: &CreditSpecification{CPUCredits: CPUCreditsUnlimited},
+					InstanceType:        "m5.large",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ami ssm parameter is accepted",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					AMI:          AMIReference{SSMParameter: aws.String("/golden-ami/k8s/{{.K8sMajorMinorVersion}}")},
+					InstanceType: "test",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ami ssm parameter together with ami id is rejected",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					AMI:          AMIReference{ID: aws.String("ami-1"), SSMParameter: aws.String("/golden-ami/k8s/1.27")},
+					InstanceType: "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ami ssm parameter with an invalid template is rejected",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					AMI:          AMIReference{SSMParameter: aws.String("/golden-ami/k8s/{{.K8sVersion")},
+					InstanceType: "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "instance type fallbacks are accepted",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:          "m5.large",
+					InstanceTypeFallbacks: []string{"m5a.large", "m6i.large"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "instance type fallbacks repeating the instance type are rejected",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:          "m5.large",
+					InstanceTypeFallbacks: []string{"m5a.large", "m5.large"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "non burstable instance type fallbacks are rejected with a credit specification",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					CreditSpecification:   &CreditSpecification{CPUCredits: CPUCreditsUnlimited},
+					InstanceType:          "t3.large",
+					InstanceTypeFallbacks: []string{"t3a.large", "m5.large"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "launch template referenced by name and version is accepted",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:   "m5.large",
+					LaunchTemplate: &LaunchTemplateReference{Name: aws.String("hardened"), Version: aws.String("$Latest")},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "launch template referenced by both ID and name is rejected",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:   "m5.large",
+					LaunchTemplate: &LaunchTemplateReference{ID: aws.String("lt-1"), Name: aws.String("hardened")},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "launch template with an invalid version is rejected",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:   "m5.large",
+					LaunchTemplate: &LaunchTemplateReference{ID: aws.String("lt-1"), Version: aws.String("latest")},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "host ID is accepted with host tenancy",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					Tenancy:      "host",
+					HostID:       aws.String("h-0123456789abcdef0"),
+					HostAffinity: aws.String("host"),
+					InstanceType: "test",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "host ID requires host tenancy",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					Tenancy:      "dedicated",
+					HostID:       aws.String("h-0123456789abcdef0"),
+					InstanceType: "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "host ID and host resource group ARN are mutually exclusive",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					Tenancy:              "host",
+					HostID:               aws.String("h-0123456789abcdef0"),
+					HostResourceGroupArn: aws.String("arn:aws:resource-groups:us-east-1:123456789012:group/my-hosts"),
+					InstanceType:         "test",
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -265,6 +591,86 @@ func TestAWSMachine_Create(t *testing.T) {
 	}
 }
 
+func TestCloudInit_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		cloudInit CloudInit
+		wantErr   bool
+	}{
+		{
+			name:      "KMS key and advanced tier with SSM Parameter Store",
+			cloudInit: CloudInit{SecureSecretsBackend: SecretBackendSSMParameterStore, KMSKeyID: "alias/userdata", SSMParameterTier: SSMParameterTierAdvanced},
+			wantErr:   false,
+		},
+		{
+			name:      "KMS key with Secrets Manager",
+			cloudInit: CloudInit{SecureSecretsBackend: SecretBackendSecretsManager, KMSKeyID: "alias/userdata"},
+			wantErr:   false,
+		},
+		{
+			name:      "advanced tier with Secrets Manager",
+			cloudInit: CloudInit{SecureSecretsBackend: SecretBackendSecretsManager, SSMParameterTier: SSMParameterTierAdvanced},
+			wantErr:   true,
+		},
+		{
+			name:      "KMS key without a secret backend",
+			cloudInit: CloudInit{InsecureSkipSecretsManager: true, KMSKeyID: "alias/userdata"},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := tt.cloudInit.Validate(field.NewPath("spec", "cloudInit"))
+			if tt.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestIgnition_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		ignition *Ignition
+		wantErr  bool
+	}{
+		{
+			name:     "Ignition v3 with TLS and proxy settings",
+			ignition: &Ignition{Version: "3.3", TLS: &IgnitionTLS{CASources: []string{"data:,ca"}}, Proxy: &IgnitionProxy{HTTPSProxy: aws.String("http://proxy:3128")}},
+			wantErr:  false,
+		},
+		{
+			name:     "Ignition v2 with TLS settings",
+			ignition: &Ignition{Version: "2.3", TLS: &IgnitionTLS{CASources: []string{"data:,ca"}}},
+			wantErr:  true,
+		},
+		{
+			name:     "Ignition v3.0 with proxy settings",
+			ignition: &Ignition{Version: "3.0", Proxy: &IgnitionProxy{HTTPSProxy: aws.String("http://proxy:3128")}},
+			wantErr:  true,
+		},
+		{
+			name:     "unencrypted user data with TLS settings",
+			ignition: &Ignition{Version: "3.3", StorageType: IgnitionStorageTypeUnencryptedUserData, TLS: &IgnitionTLS{CASources: []string{"data:,ca"}}},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := tt.ignition.Validate(field.NewPath("spec", "ignition"))
+			if tt.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
 func TestAWSMachine_Update(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -356,6 +762,146 @@ func TestAWSMachine_Update(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "increase of volume size, iops and throughput",
+			oldMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "test",
+					RootVolume:   &Volume{Size: 20, Type: VolumeTypeGP3, IOPS: 3000, Throughput: aws.Int64(125)},
+					NonRootVolumes: []Volume{
+						{DeviceName: "/dev/sdb", Size: 50, Type: VolumeTypeGP2},
+					},
+				},
+			},
+			newMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "test",
+					RootVolume:   &Volume{Size: 40, Type: VolumeTypeGP3, IOPS: 4000, Throughput: aws.Int64(250)},
+					NonRootVolumes: []Volume{
+						{DeviceName: "/dev/sdb", Size: 100, Type: VolumeTypeGP3},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "change of volume deletion policy",
+			oldMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "test",
+					RootVolume:   &Volume{Size: 20},
+				},
+			},
+			newMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "test",
+					RootVolume:   &Volume{Size: 20, VolumeDeletionPolicy: VolumeDeletionPolicySnapshot},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "decrease of volume size",
+			oldMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "test",
+					RootVolume:   &Volume{Size: 40},
+				},
+			},
+			newMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "test",
+					RootVolume:   &Volume{Size: 20},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "decrease of volume iops when changing volume type",
+			oldMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "test",
+					RootVolume:   &Volume{Size: 20, Type: VolumeTypeIO1, IOPS: 4000},
+				},
+			},
+			newMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "test",
+					RootVolume:   &Volume{Size: 20, Type: VolumeTypeGP3, IOPS: 3000},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "drop of volume iops when changing to a volume type without provisioned iops",
+			oldMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "test",
+					RootVolume:   &Volume{Size: 20, Type: VolumeTypeIO1, IOPS: 4000},
+				},
+			},
+			newMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "test",
+					RootVolume:   &Volume{Size: 20, Type: VolumeTypeGP2},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "change of volume device name",
+			oldMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "test",
+					NonRootVolumes: []Volume{
+						{DeviceName: "/dev/sdb", Size: 50},
+					},
+				},
+			},
+			newMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "test",
+					NonRootVolumes: []Volume{
+						{DeviceName: "/dev/sdc", Size: 50},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "change of failure domain with failure domain fallback",
+			oldMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:          "test",
+					FailureDomain:         pointer.StringPtr("us-east-1a"),
+					FailureDomainFallback: true,
+				},
+			},
+			newMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:          "test",
+					FailureDomain:         pointer.StringPtr("us-east-1b"),
+					FailureDomainFallback: true,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "change of failure domain without failure domain fallback",
+			oldMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:  "test",
+					FailureDomain: pointer.StringPtr("us-east-1a"),
+				},
+			},
+			newMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:  "test",
+					FailureDomain: pointer.StringPtr("us-east-1b"),
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		ctx := context.TODO()
@@ -23,6 +23,10 @@ import (
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
+// RefreshAMIAnnotation is the annotation which, set on an AWSMachineTemplate or an AWSMachinePool,
+// requests the AMI pinned in its status to be resolved again. It is removed once the AMI is refreshed.
+const RefreshAMIAnnotation = "aws.cluster.x-k8s.io/refresh-ami"
+
 // AWSMachineTemplateStatus defines a status for an AWSMachineTemplate.
 type AWSMachineTemplateStatus struct {
 	// Capacity defines the resource capacity for this machine.
@@ -30,6 +34,16 @@ type AWSMachineTemplateStatus struct {
 	// https://github.com/kubernetes-sigs/cluster-api/blob/main/docs/proposals/20210310-opt-in-autoscaling-from-zero.md
 	// +optional
 	Capacity corev1.ResourceList `json:"capacity,omitempty"`
+
+	// ResolvedAMI is the AMI looked up for the machines created from this template. New machines
+	// use it until the template changes, their Kubernetes version changes or a refresh is requested
+	// with the aws.cluster.x-k8s.io/refresh-ami annotation.
+	// +optional
+	ResolvedAMI *ResolvedAMI `json:"resolvedAMI,omitempty"`
+
+	// Conditions defines current service state of the AWSMachineTemplate.
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
 }
 
 // AWSMachineTemplateSpec defines the desired state of AWSMachineTemplate.
@@ -51,6 +65,16 @@ type AWSMachineTemplate struct {
 	Status AWSMachineTemplateStatus `json:"status,omitempty"`
 }
 
+// GetConditions returns the observations of the operational state of the AWSMachineTemplate resource.
+func (r *AWSMachineTemplate) GetConditions() clusterv1.Conditions {
+	return r.Status.Conditions
+}
+
+// SetConditions sets the underlying service state of the AWSMachineTemplate to the predescribed clusterv1.Conditions.
+func (r *AWSMachineTemplate) SetConditions(conditions clusterv1.Conditions) {
+	r.Status.Conditions = conditions
+}
+
 // +kubebuilder:object:root=true
 
 // AWSMachineTemplateList contains a list of AWSMachineTemplate.
@@ -71,6 +71,8 @@ func (r *AWSMachineTemplate) validateRootVolume() field.ErrorList {
 		log.Info("root volume shouldn't have a device name (this can be ignored if performing a `clusterctl move`)")
 	}
 
+	allErrs = append(allErrs, spec.RootVolume.Validate(field.NewPath("spec", "template", "spec", "rootVolume"), true)...)
+
 	return allErrs
 }
 
@@ -79,7 +81,7 @@ func (r *AWSMachineTemplate) validateNonRootVolumes() field.ErrorList {
 
 	spec := r.Spec.Template.Spec
 
-	for _, volume := range spec.NonRootVolumes {
+	for i, volume := range spec.NonRootVolumes {
 		if VolumeTypesProvisioned.Has(string(volume.Type)) && volume.IOPS == 0 {
 			allErrs = append(allErrs, field.Required(field.NewPath("spec.template.spec.nonRootVolumes.iops"), "iops required if type is 'io1' or 'io2'"))
 		}
@@ -96,6 +98,8 @@ func (r *AWSMachineTemplate) validateNonRootVolumes() field.ErrorList {
 		if volume.DeviceName == "" {
 			allErrs = append(allErrs, field.Required(field.NewPath("spec.template.spec.nonRootVolumes.deviceName"), "non root volume should have device name"))
 		}
+
+		allErrs = append(allErrs, volume.Validate(field.NewPath("spec", "template", "spec", "nonRootVolumes").Index(i), false)...)
 	}
 
 	return allErrs
@@ -125,6 +129,8 @@ func (r *AWSMachineTemplateWebhook) ValidateCreate(_ context.Context, raw runtim
 
 	allErrs = append(allErrs, obj.validateRootVolume()...)
 	allErrs = append(allErrs, obj.validateNonRootVolumes()...)
+	allErrs = append(allErrs, spec.LaunchTemplate.Validate(field.NewPath("spec", "template", "spec", "launchTemplate"))...)
+	allErrs = append(allErrs, spec.validateInstanceOptions(field.NewPath("spec", "template", "spec"))...)
 
 	// Feature gate is not enabled but ignition is enabled then send a forbidden error.
 	if !feature.Gates.Enabled(feature.BootstrapFormatIgnition) && spec.Ignition != nil {
@@ -132,12 +138,16 @@ func (r *AWSMachineTemplateWebhook) ValidateCreate(_ context.Context, raw runtim
 			"can be set only if the BootstrapFormatIgnition feature gate is enabled"))
 	}
 
-	cloudInitConfigured := spec.CloudInit.SecureSecretsBackend != "" || spec.CloudInit.InsecureSkipSecretsManager
+	cloudInitConfigured := spec.CloudInit.SecureSecretsBackend != "" || spec.CloudInit.InsecureSkipSecretsManager ||
+		spec.CloudInit.KMSKeyID != "" || spec.CloudInit.SSMParameterTier != ""
 	if cloudInitConfigured && spec.Ignition != nil {
 		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "template", "spec", "cloudInit"),
 			"cannot be set if spec.template.spec.ignition is set"))
 	}
 
+	allErrs = append(allErrs, spec.CloudInit.Validate(field.NewPath("spec", "template", "spec", "cloudInit"))...)
+	allErrs = append(allErrs, spec.Ignition.Validate(field.NewPath("spec", "template", "spec", "ignition"))...)
+
 	return aggregateObjErrors(obj.GroupVersionKind().GroupKind(), obj.Name, allErrs)
 }
 
@@ -80,6 +80,199 @@ func TestAWSMachineTemplateValidateCreate(t *testing.T) {
 			},
 			wantError: false,
 		},
+		{
+			name: "don't allow a capacity reservation target for spot instances",
+			inputTemplate: &AWSMachineTemplate{
+				ObjectMeta: metav1.ObjectMeta{},
+				Spec: AWSMachineTemplateSpec{
+					Template: AWSMachineTemplateResource{
+						Spec: AWSMachineSpec{
+							InstanceType:      "m5.large",
+							SpotMarketOptions: &SpotMarketOptions{},
+							CapacityReservationSpecification: &CapacityReservationSpecification{
+								CapacityReservationTarget: &CapacityReservationTarget{CapacityReservationID: aws.String("cr-1")},
+							},
+						},
+					},
+				},
+			},
+			wantError: true,
+		},
+		{
+			name: "don't allow a host ID without host tenancy",
+			inputTemplate: &AWSMachineTemplate{
+				ObjectMeta: metav1.ObjectMeta{},
+				Spec: AWSMachineTemplateSpec{
+					Template: AWSMachineTemplateResource{
+						Spec: AWSMachineSpec{
+							InstanceType: "m5.large",
+							HostID:       aws.String("h-1"),
+						},
+					},
+				},
+			},
+			wantError: true,
+		},
+		{
+			name: "allow a host ID with host tenancy",
+			inputTemplate: &AWSMachineTemplate{
+				ObjectMeta: metav1.ObjectMeta{},
+				Spec: AWSMachineTemplateSpec{
+					Template: AWSMachineTemplateResource{
+						Spec: AWSMachineSpec{
+							InstanceType: "m5.large",
+							Tenancy:      "host",
+							HostID:       aws.String("h-1"),
+						},
+					},
+				},
+			},
+			wantError: false,
+		},
+		{
+			name: "don't allow additional network interfaces with a public IP",
+			inputTemplate: &AWSMachineTemplate{
+				ObjectMeta: metav1.ObjectMeta{},
+				Spec: AWSMachineTemplateSpec{
+					Template: AWSMachineTemplateResource{
+						Spec: AWSMachineSpec{
+							InstanceType:                "m5.large",
+							PublicIP:                    aws.Bool(true),
+							AdditionalNetworkInterfaces: []AdditionalNetworkInterface{{SubnetID: "subnet-1"}},
+						},
+					},
+				},
+			},
+			wantError: true,
+		},
+		{
+			name: "don't allow an Elastic IP with additional network interfaces",
+			inputTemplate: &AWSMachineTemplate{
+				ObjectMeta: metav1.ObjectMeta{},
+				Spec: AWSMachineTemplateSpec{
+					Template: AWSMachineTemplateResource{
+						Spec: AWSMachineSpec{
+							InstanceType:                "m5.large",
+							ElasticIP:                   &ElasticIPSpec{},
+							AdditionalNetworkInterfaces: []AdditionalNetworkInterface{{SubnetID: "subnet-1"}},
+						},
+					},
+				},
+			},
+			wantError: true,
+		},
+		{
+			name: "don't allow invalid CPU options",
+			inputTemplate: &AWSMachineTemplate{
+				ObjectMeta: metav1.ObjectMeta{},
+				Spec: AWSMachineTemplateSpec{
+					Template: AWSMachineTemplateResource{
+						Spec: AWSMachineSpec{
+							InstanceType: "m5.large",
+							CPUOptions:   &CPUOptions{CoreCount: 1, ThreadsPerCore: 3},
+						},
+					},
+				},
+			},
+			wantError: true,
+		},
+		{
+			name: "don't allow a credit specification for instance types that are not burstable",
+			inputTemplate: &AWSMachineTemplate{
+				ObjectMeta: metav1.ObjectMeta{},
+				Spec: AWSMachineTemplateSpec{
+					Template: AWSMachineTemplateResource{
+						Spec: AWSMachineSpec{
+							InstanceType:        "m5.large",
+							CreditSpecification: &CreditSpecification{CPUCredits: CPUCreditsUnlimited},
+						},
+					},
+				},
+			},
+			wantError: true,
+		},
+		{
+			name: "don't allow duplicate instance type fallbacks",
+			inputTemplate: &AWSMachineTemplate{
+				ObjectMeta: metav1.ObjectMeta{},
+				Spec: AWSMachineTemplateSpec{
+					Template: AWSMachineTemplateResource{
+						Spec: AWSMachineSpec{
+							InstanceType:          "m5.large",
+							InstanceTypeFallbacks: []string{"m5a.large", "m5.large"},
+						},
+					},
+				},
+			},
+			wantError: true,
+		},
+		{
+			name: "don't allow instance type fallbacks that are not burstable with a credit specification",
+			inputTemplate: &AWSMachineTemplate{
+				ObjectMeta: metav1.ObjectMeta{},
+				Spec: AWSMachineTemplateSpec{
+					Template: AWSMachineTemplateResource{
+						Spec: AWSMachineSpec{
+							InstanceType:          "t3.large",
+							CreditSpecification:   &CreditSpecification{CPUCredits: CPUCreditsUnlimited},
+							InstanceTypeFallbacks: []string{"m5.large"},
+						},
+					},
+				},
+			},
+			wantError: true,
+		},
+		{
+			name: "allow burstable instance type fallbacks with a credit specification",
+			inputTemplate: &AWSMachineTemplate{
+				ObjectMeta: metav1.ObjectMeta{},
+				Spec: AWSMachineTemplateSpec{
+					Template: AWSMachineTemplateResource{
+						Spec: AWSMachineSpec{
+							InstanceType:          "t3.large",
+							CreditSpecification:   &CreditSpecification{CPUCredits: CPUCreditsUnlimited},
+							InstanceTypeFallbacks: []string{"t3a.large"},
+						},
+					},
+				},
+			},
+			wantError: false,
+		},
+		{
+			name: "don't allow an AMI SSM parameter together with an AMI ID",
+			inputTemplate: &AWSMachineTemplate{
+				ObjectMeta: metav1.ObjectMeta{},
+				Spec: AWSMachineTemplateSpec{
+					Template: AWSMachineTemplateResource{
+						Spec: AWSMachineSpec{
+							InstanceType: "m5.large",
+							AMI: AMIReference{
+								ID:           aws.String("ami-1"),
+								SSMParameter: aws.String("/aws/service/ami"),
+							},
+						},
+					},
+				},
+			},
+			wantError: true,
+		},
+		{
+			name: "don't allow an invalid AMI SSM parameter template",
+			inputTemplate: &AWSMachineTemplate{
+				ObjectMeta: metav1.ObjectMeta{},
+				Spec: AWSMachineTemplateSpec{
+					Template: AWSMachineTemplateResource{
+						Spec: AWSMachineSpec{
+							InstanceType: "m5.large",
+							AMI: AMIReference{
+								SSMParameter: aws.String("/ami/{{.K8sVersion"),
+							},
+						},
+					},
+				},
+			},
+			wantError: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -153,6 +153,67 @@ const (
 	SecurityGroupsFailedReason = "SecurityGroupsSyncFailed"
 )
 
+const (
+	// VolumesUpToDateCondition reports whether the size, type, IOPS and throughput of the volumes attached to
+	// the instance of an AWSMachine match its spec. Changes are applied to the attached volumes in place.
+	VolumesUpToDateCondition clusterv1.ConditionType = "VolumesUpToDate"
+
+	// VolumeModificationInProgressReason used while the modification of volumes attached to the instance is in progress.
+	VolumeModificationInProgressReason = "VolumeModificationInProgress"
+	// VolumeModificationFailedReason used when the volumes attached to the instance could not be modified.
+	VolumeModificationFailedReason = "VolumeModificationFailed"
+)
+
+const (
+	// ElasticIPAssociatedCondition reports whether the Elastic IP requested for an AWSMachine is associated with its instance.
+	ElasticIPAssociatedCondition clusterv1.ConditionType = "ElasticIPAssociated"
+
+	// ElasticIPAssociationFailedReason used when the Elastic IP could not be obtained or associated with the instance.
+	ElasticIPAssociationFailedReason = "ElasticIPAssociationFailed"
+)
+
+// A failed status check turns the AWSMachine not ready and flags its Machine for remediation by its owner,
+// unless the Machine has the cluster.x-k8s.io/skip-remediation annotation.
+const (
+	// InstanceStatusCheckPassedCondition reports the result of the EC2 instance status check, which detects
+	// problems of the instance itself, e.g. an unreachable operating system.
+	InstanceStatusCheckPassedCondition clusterv1.ConditionType = "InstanceStatusCheckPassed"
+	// SystemStatusCheckPassedCondition reports the result of the EC2 system status check, which detects
+	// problems of the AWS systems the instance runs on, e.g. a loss of network connectivity or power.
+	SystemStatusCheckPassedCondition clusterv1.ConditionType = "SystemStatusCheckPassed"
+
+	// InstanceStatusCheckFailedReason used when the instance status check of an instance is impaired.
+	InstanceStatusCheckFailedReason = "InstanceStatusCheckFailed"
+	// SystemStatusCheckFailedReason used when the system status check of an instance is impaired.
+	SystemStatusCheckFailedReason = "SystemStatusCheckFailed"
+)
+
+const (
+	// SpotInstanceHealthyCondition reports whether AWS signalled that the spot instance of an AWSMachine is about
+	// to be interrupted or is at an elevated risk of interruption. It is only set once such a signal is received.
+	SpotInstanceHealthyCondition clusterv1.ConditionType = "SpotInstanceHealthy"
+
+	// SpotInterruptionWarningReason used when AWS warned that the spot instance will be interrupted in two minutes.
+	SpotInterruptionWarningReason = "SpotInterruptionWarning"
+	// SpotRebalanceRecommendationReason used when AWS recommended to rebalance the spot instance as it is at an
+	// elevated risk of interruption.
+	SpotRebalanceRecommendationReason = "SpotRebalanceRecommendation"
+)
+
+const (
+	// AMIDeprecatedCondition reports that the AMI used by an AWSMachine, AWSMachineTemplate or AWSMachinePool is
+	// deprecated or no longer available, so new instances may soon fail to launch from it. It is only set while
+	// that is the case, with a warning severity, or while the AMI can't be checked, with an unknown status.
+	AMIDeprecatedCondition clusterv1.ConditionType = "AMIDeprecated"
+
+	// AMIDeprecatedReason used when the AMI has passed its deprecation time.
+	AMIDeprecatedReason = "AMIDeprecated"
+	// AMIUnavailableReason used when the AMI has been deregistered, has failed or can no longer be found.
+	AMIUnavailableReason = "AMIUnavailable"
+	// AMIDeprecationCheckFailedReason used when the AMI could not be described to check its deprecation.
+	AMIDeprecationCheckFailedReason = "AMIDeprecationCheckFailed"
+)
+
 const (
 	// ELBAttachedCondition will report true when a control plane is successfully registered with an ELB.
 	// When set to false, severity can be an Error if the subnet is not found or unavailable in the instance's AZ.
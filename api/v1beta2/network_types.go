@@ -276,6 +276,11 @@ type SubnetSpec struct {
 
 	// Tags is a collection of tags describing the resource.
 	Tags Tags `json:"tags,omitempty"`
+
+	// PrivateDNSNameOptions configures the hostname of the instances launched in the subnet and the DNS
+	// records created for them. It is only applied to the subnets of a VPC managed by the provider.
+	// +optional
+	PrivateDNSNameOptions *PrivateDNSNameOptions `json:"privateDnsNameOptions,omitempty"`
 }
 
 // String returns a string representation of the subnet.
@@ -19,12 +19,16 @@ package v1beta2
 import (
 	"fmt"
 	"net"
+	"time"
 
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"sigs.k8s.io/cluster-api-provider-aws/v2/feature"
 )
 
+// maxPresignedURLDuration is the longest validity of pre-signed URLs supported by S3.
+const maxPresignedURLDuration = 7 * 24 * time.Hour
+
 // Validate validates S3Bucket fields.
 func (b *S3Bucket) Validate() []*field.Error {
 	var errs field.ErrorList
@@ -43,14 +47,21 @@ func (b *S3Bucket) Validate() []*field.Error {
 			"can be set only if the BootstrapFormatIgnition feature gate is enabled"))
 	}
 
-	if b.ControlPlaneIAMInstanceProfile == "" {
-		errs = append(errs,
-			field.Required(field.NewPath("spec", "s3Bucket", "controlPlaneIAMInstanceProfiles"), "can't be empty"))
-	}
+	if b.PresignedURLDuration != nil {
+		if b.PresignedURLDuration.Duration <= 0 || b.PresignedURLDuration.Duration > maxPresignedURLDuration {
+			errs = append(errs,
+				field.Invalid(field.NewPath("spec", "s3Bucket", "presignedURLDuration"), b.PresignedURLDuration.Duration.String(), "must be greater than 0 and at most 7 days"))
+		}
+	} else {
+		if b.ControlPlaneIAMInstanceProfile == "" {
+			errs = append(errs,
+				field.Required(field.NewPath("spec", "s3Bucket", "controlPlaneIAMInstanceProfiles"), "can't be empty"))
+		}
 
-	if len(b.NodesIAMInstanceProfiles) == 0 {
-		errs = append(errs,
-			field.Required(field.NewPath("spec", "s3Bucket", "nodesIAMInstanceProfiles"), "can't be empty"))
+		if len(b.NodesIAMInstanceProfiles) == 0 {
+			errs = append(errs,
+				field.Required(field.NewPath("spec", "s3Bucket", "nodesIAMInstanceProfiles"), "can't be empty"))
+		}
 	}
 
 	for i, iamInstanceProfile := range b.NodesIAMInstanceProfiles {
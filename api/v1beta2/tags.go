@@ -177,6 +177,10 @@ const (
 	// dedicated to this cluster api provider implementation.
 	NameAWSSubnetAssociation = NameAWSProviderPrefix + "association"
 
+	// NameAWSClusterNamespace is the tag name we use to record the namespace of the cluster owning
+	// a resource, as the cluster tag alone doesn't tell apart clusters of the same name in different namespaces.
+	NameAWSClusterNamespace = NameAWSProviderPrefix + "cluster-namespace"
+
 	// SecondarySubnetTagValue is the secondary subnet tag constant value.
 	SecondarySubnetTagValue = "secondary"
 
@@ -17,7 +17,14 @@ limitations under the License.
 package v1beta2
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
@@ -54,6 +61,59 @@ type AMIReference struct {
 	// +kubebuilder:validation:Enum:=AmazonLinux;AmazonLinuxGPU
 	// +optional
 	EKSOptimizedLookupType *EKSAMILookupType `json:"eksLookupType,omitempty"`
+
+	// SSMParameter is the name of an SSM parameter holding the ID of the AMI, which is resolved at reconcile time,
+	// e.g. /aws/service/canonical/ubuntu/server/22.04/stable/current/amd64/hvm/ebs-gp2/ami-id.
+	// The name is a Go template, where {{.K8sVersion}} is replaced with the Kubernetes version of the machine
+	// without the leading v, e.g. 1.27.3, and {{.K8sMajorMinorVersion}} with its major and minor version, e.g. 1.27.
+	// +optional
+	SSMParameter *string `json:"ssmParameter,omitempty"`
+}
+
+// Validate validates that at most one way to resolve the AMI is set and that the SSM parameter name is a valid template.
+func (ami *AMIReference) Validate(fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if ami.SSMParameter == nil {
+		return allErrs
+	}
+
+	if ami.ID != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("ssmParameter"), "cannot be set together with id"))
+	}
+	if ami.EKSOptimizedLookupType != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("ssmParameter"), "cannot be set together with eksLookupType"))
+	}
+	if *ami.SSMParameter == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("ssmParameter"), "must not be empty"))
+	} else if _, err := template.New("ssmParameter").Parse(*ami.SSMParameter); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("ssmParameter"), *ami.SSMParameter, fmt.Sprintf("is not a valid template: %v", err)))
+	}
+
+	return allErrs
+}
+
+// ResolvedAMI describes an AMI which was looked up, rather than referenced by its ID, and is pinned
+// so that the AMI of new machines doesn't change when a newer matching AMI is published.
+type ResolvedAMI struct {
+	// ID of the AMI.
+	ID string `json:"id"`
+
+	// Name of the AMI.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// CreationDate is the date the AMI was created.
+	// +optional
+	CreationDate *metav1.Time `json:"creationDate,omitempty"`
+
+	// KubernetesVersion is the Kubernetes version the AMI was looked up for.
+	// +optional
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+
+	// ObservedGeneration is the generation of the object the AMI was looked up for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 // Filter is a filter used to identify an AWS resource.
@@ -190,6 +250,10 @@ type Instance struct {
 	// Specifies ENIs attached to instance
 	NetworkInterfaces []string `json:"networkInterfaces,omitempty"`
 
+	// AdditionalNetworkInterfaces are the ENIs created and attached to the instance at launch.
+	// +optional
+	AdditionalNetworkInterfaces []AdditionalNetworkInterface `json:"additionalNetworkInterfaces,omitempty"`
+
 	// The tags associated with the instance.
 	Tags map[string]string `json:"tags,omitempty"`
 
@@ -203,9 +267,53 @@ type Instance struct {
 	// +optional
 	Tenancy string `json:"tenancy,omitempty"`
 
+	// HostID is the ID of the Dedicated Host the instance is placed on.
+	// +optional
+	HostID *string `json:"hostID,omitempty"`
+
+	// HostResourceGroupArn is the ARN of the host resource group the instance is placed in.
+	// +optional
+	HostResourceGroupArn *string `json:"hostResourceGroupArn,omitempty"`
+
+	// HostAffinity is the affinity setting for the instance on the Dedicated Host.
+	// +optional
+	HostAffinity *string `json:"hostAffinity,omitempty"`
+
 	// IDs of the instance's volumes
 	// +optional
 	VolumeIDs []string `json:"volumeIDs,omitempty"`
+
+	// InstanceStoreVolumes are the instance store volumes mapped to the instance at launch.
+	// +optional
+	InstanceStoreVolumes []InstanceStoreVolume `json:"instanceStoreVolumes,omitempty"`
+
+	// InstanceMetadataOptions is the metadata options for the EC2 instance.
+	// +optional
+	InstanceMetadataOptions *InstanceMetadataOptions `json:"instanceMetadataOptions,omitempty"`
+
+	// CapacityReservationSpecification is the Capacity Reservation targeting option the instance was launched with.
+	// +optional
+	CapacityReservationSpecification *CapacityReservationSpecification `json:"capacityReservationSpecification,omitempty"`
+
+	// CapacityReservationID is the ID of the Capacity Reservation the instance is running in, if any.
+	// +optional
+	CapacityReservationID *string `json:"capacityReservationID,omitempty"`
+
+	// CPUOptions are the CPU options of the instance.
+	// +optional
+	CPUOptions *CPUOptions `json:"cpuOptions,omitempty"`
+
+	// CreditSpecification is the credit option for CPU usage of the instance.
+	// +optional
+	CreditSpecification *CreditSpecification `json:"creditSpecification,omitempty"`
+
+	// LaunchTemplate is the launch template the instance is launched from.
+	// +optional
+	LaunchTemplate *LaunchTemplateReference `json:"launchTemplate,omitempty"`
+
+	// PrivateDNSNameOptions are the options for the hostname of the instance and the DNS records created for it.
+	// +optional
+	PrivateDNSNameOptions *PrivateDNSNameOptions `json:"privateDnsNameOptions,omitempty"`
 }
 
 // Volume encapsulates the configuration options for the storage device.
@@ -216,8 +324,11 @@ type Volume struct {
 
 	// Size specifies size (in Gi) of the storage device.
 	// Must be greater than the image snapshot size or 8 (whichever is greater).
+	// Required unless the volume is created from a snapshot, in which case it defaults to the
+	// snapshot size, or is an instance store volume.
 	// +kubebuilder:validation:Minimum=8
-	Size int64 `json:"size"`
+	// +optional
+	Size int64 `json:"size,omitempty"`
 
 	// Type is the type of the volume (e.g. gp2, io1, etc...).
 	// +optional
@@ -240,8 +351,91 @@ type Volume struct {
 	// The key must already exist and be accessible by the controller.
 	// +optional
 	EncryptionKey string `json:"encryptionKey,omitempty"`
+
+	// VolumeDeletionPolicy defines what happens to the volume when the machine is deleted.
+	// Delete, the default, deletes the volume with the instance. Retain keeps the volume.
+	// Snapshot takes a snapshot of the volume before deleting it.
+	// Only supported for AWSMachines.
+	// +kubebuilder:validation:Enum=Delete;Retain;Snapshot
+	// +optional
+	VolumeDeletionPolicy VolumeDeletionPolicy `json:"volumeDeletionPolicy,omitempty"`
+
+	// SnapshotID is the ID of the EBS snapshot the volume is created from, e.g. to pre-seed
+	// a container image cache. Not applicable to the root volume.
+	// +optional
+	SnapshotID *string `json:"snapshotID,omitempty"`
+
+	// VirtualName maps an instance store volume (ephemeral0 to ephemeral23) to the device
+	// instead of an EBS volume. Instance store volumes only support the device name.
+	// Not applicable to the root volume.
+	// +kubebuilder:validation:Pattern=`^ephemeral([0-9]|1[0-9]|2[0-3])$`
+	// +optional
+	VirtualName string `json:"virtualName,omitempty"`
 }
 
+// IsInstanceStore returns true if the volume is an instance store volume rather than an EBS volume.
+func (v *Volume) IsInstanceStore() bool {
+	return v.VirtualName != ""
+}
+
+// Validate validates the source and size of the volume. Root volumes are always EBS volumes
+// created from the image, so they can set neither a snapshot nor a virtual name.
+func (v *Volume) Validate(fldPath *field.Path, root bool) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if root {
+		if v.SnapshotID != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("snapshotID"), "the root volume is created from the image snapshot"))
+		}
+		if v.VirtualName != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("virtualName"), "the root volume cannot be an instance store volume"))
+		}
+		if v.Size == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("size"), "size is required for the root volume"))
+		}
+		return allErrs
+	}
+
+	if v.IsInstanceStore() {
+		if v.SnapshotID != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("snapshotID"), "snapshotID cannot be set for instance store volumes"))
+		}
+		if v.Size != 0 || v.Type != "" || v.IOPS != 0 || v.Throughput != nil || v.Encrypted != nil || v.EncryptionKey != "" || v.VolumeDeletionPolicy != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "instance store volumes only support deviceName and virtualName"))
+		}
+		return allErrs
+	}
+
+	if v.Size == 0 && v.SnapshotID == nil {
+		allErrs = append(allErrs, field.Required(fldPath.Child("size"), "size is required unless the volume is created from a snapshot"))
+	}
+
+	return allErrs
+}
+
+// InstanceStoreVolume describes an instance store volume mapped to an instance.
+type InstanceStoreVolume struct {
+	// DeviceName is the device name the instance store volume is mapped to.
+	DeviceName string `json:"deviceName"`
+
+	// VirtualName is the name of the instance store volume, e.g. ephemeral0.
+	VirtualName string `json:"virtualName"`
+}
+
+// VolumeDeletionPolicy describes what happens to an EBS volume when its machine is deleted.
+type VolumeDeletionPolicy string
+
+const (
+	// VolumeDeletionPolicyDelete deletes the volume together with the instance.
+	VolumeDeletionPolicyDelete = VolumeDeletionPolicy("Delete")
+
+	// VolumeDeletionPolicyRetain keeps the volume after the instance is terminated.
+	VolumeDeletionPolicyRetain = VolumeDeletionPolicy("Retain")
+
+	// VolumeDeletionPolicySnapshot takes a snapshot of the volume before it is deleted together with the instance.
+	VolumeDeletionPolicySnapshot = VolumeDeletionPolicy("Snapshot")
+)
+
 // VolumeType describes the EBS volume type.
 // See: https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/ebs-volume-types.html
 type VolumeType string
@@ -282,6 +476,338 @@ type SpotMarketOptions struct {
 	MaxPrice *string `json:"maxPrice,omitempty"`
 }
 
+// InstanceMetadataState describes the state of an instance metadata option.
+type InstanceMetadataState string
+
+const (
+	// InstanceMetadataEndpointStateDisabled represents the disabled state.
+	InstanceMetadataEndpointStateDisabled = InstanceMetadataState("disabled")
+
+	// InstanceMetadataEndpointStateEnabled represents the enabled state.
+	InstanceMetadataEndpointStateEnabled = InstanceMetadataState("enabled")
+)
+
+// HTTPTokensState describes the state of HTTP tokens for the instance metadata service.
+type HTTPTokensState string
+
+const (
+	// HTTPTokensStateOptional represents the optional state of the HTTP tokens,
+	// which allows both IMDSv1 and IMDSv2 requests.
+	HTTPTokensStateOptional = HTTPTokensState("optional")
+
+	// HTTPTokensStateRequired represents the required state of the HTTP tokens,
+	// which only allows IMDSv2 requests.
+	HTTPTokensStateRequired = HTTPTokensState("required")
+)
+
+// InstanceMetadataOptions describes the metadata options for the EC2 instance.
+type InstanceMetadataOptions struct {
+	// Enables or disables the HTTP metadata endpoint on your instances.
+	//
+	// If you specify a value of disabled, you cannot access your instance metadata.
+	//
+	// Default: enabled
+	//
+	// +kubebuilder:validation:Enum:=enabled;disabled
+	// +kubebuilder:default=enabled
+	HTTPEndpoint InstanceMetadataState `json:"httpEndpoint,omitempty"`
+
+	// The desired HTTP PUT response hop limit for instance metadata requests. The
+	// larger the number, the further instance metadata requests can travel.
+	//
+	// Default: 1
+	//
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=64
+	// +kubebuilder:default=1
+	HTTPPutResponseHopLimit int64 `json:"httpPutResponseHopLimit,omitempty"`
+
+	// The state of token usage for your instance metadata requests.
+	//
+	// If the state is optional, you can choose to retrieve instance metadata with
+	// or without a session token on your request. If you retrieve the IAM role
+	// credentials without a token, the version 1.0 role credentials are returned.
+	// If you retrieve the IAM role credentials using a valid session token, the
+	// version 2.0 role credentials are returned.
+	//
+	// If the state is required, you must send a session token with any instance
+	// metadata retrieval requests. In this state, retrieving the IAM role credentials
+	// always returns the version 2.0 credentials; the version 1.0 credentials are
+	// not available.
+	//
+	// Default: optional
+	//
+	// +kubebuilder:validation:Enum:=optional;required
+	// +kubebuilder:default=optional
+	HTTPTokens HTTPTokensState `json:"httpTokens,omitempty"`
+
+	// Set to enabled to allow access to instance tags from the instance metadata.
+	// Set to disabled to turn off access to instance tags from the instance metadata.
+	// For more information, see Work with instance tags using the instance metadata
+	// (https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/Using_Tags.html#work-with-tags-in-IMDS).
+	//
+	// Default: disabled
+	//
+	// +kubebuilder:validation:Enum:=enabled;disabled
+	// +kubebuilder:default=disabled
+	InstanceMetadataTags InstanceMetadataState `json:"instanceMetadataTags,omitempty"`
+}
+
+// SetDefaults sets the default values for the InstanceMetadataOptions.
+func (obj *InstanceMetadataOptions) SetDefaults() {
+	if obj.HTTPEndpoint == "" {
+		obj.HTTPEndpoint = InstanceMetadataEndpointStateEnabled
+	}
+	if obj.HTTPPutResponseHopLimit == 0 {
+		obj.HTTPPutResponseHopLimit = 1
+	}
+	if obj.HTTPTokens == "" {
+		obj.HTTPTokens = HTTPTokensStateOptional
+	}
+	if obj.InstanceMetadataTags == "" {
+		obj.InstanceMetadataTags = InstanceMetadataEndpointStateDisabled
+	}
+}
+
+// AdditionalNetworkInterface defines an ENI to be created and attached to an instance at launch.
+type AdditionalNetworkInterface struct {
+	// SubnetID is the ID of the subnet to create the network interface in.
+	// Defaults to the subnet of the instance.
+	// +optional
+	SubnetID string `json:"subnetID,omitempty"`
+
+	// SecurityGroupIDs is a list of security groups to associate with the network interface.
+	// Defaults to the security groups of the instance.
+	// +optional
+	SecurityGroupIDs []string `json:"securityGroupIDs,omitempty"`
+
+	// Description is the description of the network interface.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// SecondaryPrivateIPAddressCount is the number of secondary private IPv4 addresses to assign to the network interface.
+	// Can not be used together with IPv4PrefixCount.
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+	SecondaryPrivateIPAddressCount *int64 `json:"secondaryPrivateIPAddressCount,omitempty"`
+
+	// IPv4PrefixCount is the number of IPv4 delegated prefixes to assign to the network interface.
+	// Can not be used together with SecondaryPrivateIPAddressCount.
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+	IPv4PrefixCount *int64 `json:"ipv4PrefixCount,omitempty"`
+}
+
+// ElasticIPSpec defines how an Elastic IP is obtained for an instance.
+type ElasticIPSpec struct {
+	// Pool selects an unassociated Elastic IP from a pool of pre-allocated addresses. The address
+	// is returned to the pool when the machine is deleted. When not set, an Elastic IP is allocated
+	// for the machine and released when the machine is deleted.
+	// +optional
+	Pool *ElasticIPPool `json:"pool,omitempty"`
+}
+
+// ElasticIPPool describes a pool of pre-allocated Elastic IPs.
+type ElasticIPPool struct {
+	// Filters is a set of filters used to select the Elastic IPs of the pool,
+	// for example a tag:<key> filter matching the addresses tagged for the pool.
+	// +kubebuilder:validation:MinItems:=1
+	Filters []Filter `json:"filters"`
+}
+
+// CPUOptions defines the CPU options of an instance.
+type CPUOptions struct {
+	// CoreCount is the number of CPU cores for the instance.
+	// +kubebuilder:validation:Minimum:=1
+	CoreCount int64 `json:"coreCount"`
+
+	// ThreadsPerCore is the number of threads per CPU core. Set to 1 to disable simultaneous multithreading.
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=2
+	ThreadsPerCore int64 `json:"threadsPerCore"`
+}
+
+// CPUCredits describes the credit option for CPU usage of a burstable performance instance.
+type CPUCredits string
+
+var (
+	// CPUCreditsStandard allows a burstable performance instance to burst above the baseline
+	// only as long as it has accrued CPU credits.
+	CPUCreditsStandard = CPUCredits("standard")
+
+	// CPUCreditsUnlimited allows a burstable performance instance to sustain high CPU utilization
+	// for any period of time, at additional charge if it exceeds its accrued credits.
+	CPUCreditsUnlimited = CPUCredits("unlimited")
+)
+
+// CreditSpecification defines the credit option for CPU usage of a burstable performance instance.
+type CreditSpecification struct {
+	// CPUCredits is the credit option for CPU usage of the instance.
+	// +kubebuilder:validation:Enum:=standard;unlimited
+	CPUCredits CPUCredits `json:"cpuCredits"`
+}
+
+const (
+	// HostnameTypeIPName is the hostname type of instances whose hostname is based on their private IPv4 address,
+	// for example ip-10-0-1-2.ec2.internal.
+	HostnameTypeIPName = "ip-name"
+
+	// HostnameTypeResourceName is the hostname type of instances whose hostname is based on their instance ID,
+	// for example i-0123456789abcdef0.ec2.internal.
+	HostnameTypeResourceName = "resource-name"
+)
+
+// PrivateDNSNameOptions defines the hostname assigned to instances and the DNS records created for it.
+type PrivateDNSNameOptions struct {
+	// HostnameType is the type of hostname to assign to instances.
+	// +optional
+	// +kubebuilder:validation:Enum:=ip-name;resource-name
+	HostnameType *string `json:"hostnameType,omitempty"`
+
+	// EnableResourceNameDNSARecord enables DNS A records for the resource-name hostnames of instances.
+	// +optional
+	EnableResourceNameDNSARecord *bool `json:"enableResourceNameDnsARecord,omitempty"`
+
+	// EnableResourceNameDNSAAAARecord enables DNS AAAA records for the resource-name hostnames of instances.
+	// +optional
+	EnableResourceNameDNSAAAARecord *bool `json:"enableResourceNameDnsAAAARecord,omitempty"`
+}
+
+const (
+	// LaunchTemplateLatestVersion references the latest version of a launch template.
+	LaunchTemplateLatestVersion = "$Latest"
+	// LaunchTemplateDefaultVersion references the default version of a launch template.
+	LaunchTemplateDefaultVersion = "$Default"
+)
+
+// LaunchTemplateReference references a version of an EC2 launch template by the ID or the name of the template.
+type LaunchTemplateReference struct {
+	// ID of the launch template.
+	// +optional
+	ID *string `json:"id,omitempty"`
+
+	// Name of the launch template.
+	// +optional
+	Name *string `json:"name,omitempty"`
+
+	// Version of the launch template to use, either a version number, $Latest or $Default.
+	// Defaults to $Default.
+	// +optional
+	Version *string `json:"version,omitempty"`
+}
+
+// Validate checks that exactly one of the ID or the name of the launch template is set, and that the version is valid.
+func (r *LaunchTemplateReference) Validate(fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if r == nil {
+		return allErrs
+	}
+
+	if (r.ID == nil) == (r.Name == nil) {
+		allErrs = append(allErrs, field.Invalid(fldPath, r, "exactly one of id or name must be set"))
+	}
+
+	if r.Version != nil {
+		version := *r.Version
+		if _, err := strconv.ParseUint(version, 10, 64); err != nil && version != LaunchTemplateLatestVersion && version != LaunchTemplateDefaultVersion {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("version"), version,
+				fmt.Sprintf("must be a version number, %s or %s", LaunchTemplateLatestVersion, LaunchTemplateDefaultVersion)))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateCPUOptionsForInstanceType validates the CPU options and credit specification against the instance type.
+func ValidateCPUOptionsForInstanceType(cpuOptions *CPUOptions, creditSpecification *CreditSpecification, instanceType string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if cpuOptions != nil {
+		if cpuOptions.CoreCount < 1 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("cpuOptions", "coreCount"), cpuOptions.CoreCount, "must be greater than zero"))
+		}
+		if cpuOptions.ThreadsPerCore < 1 || cpuOptions.ThreadsPerCore > 2 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("cpuOptions", "threadsPerCore"), cpuOptions.ThreadsPerCore, "must be 1 or 2"))
+		}
+	}
+
+	if creditSpecification != nil && instanceType != "" && !IsBurstableInstanceType(instanceType) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("creditSpecification"), fmt.Sprintf("can only be set for burstable performance instance types, %q is not one", instanceType)))
+	}
+
+	return allErrs
+}
+
+// IsBurstableInstanceType returns true for the T family of burstable performance instance types, e.g. t3.large.
+func IsBurstableInstanceType(instanceType string) bool {
+	family := strings.SplitN(instanceType, ".", 2)[0]
+	return len(family) > 1 && family[0] == 't' && family[1] >= '0' && family[1] <= '9'
+}
+
+// CapacityReservationPreference describes the preferred Capacity Reservation targeting of an instance.
+type CapacityReservationPreference string
+
+var (
+	// CapacityReservationPreferenceOpen will run the instance in any open Capacity Reservation that has
+	// matching attributes (instance type, platform, Availability Zone), falling back to On-Demand capacity.
+	CapacityReservationPreferenceOpen = CapacityReservationPreference("open")
+
+	// CapacityReservationPreferenceNone will avoid running the instance in a Capacity Reservation even if one
+	// is available, and run it in On-Demand capacity instead.
+	CapacityReservationPreferenceNone = CapacityReservationPreference("none")
+)
+
+// CapacityReservationSpecification describes the Capacity Reservation targeting option of an instance.
+// Only one of CapacityReservationPreference or CapacityReservationTarget may be specified.
+type CapacityReservationSpecification struct {
+	// CapacityReservationPreference indicates the Capacity Reservation preference of the instance.
+	// +kubebuilder:validation:Enum:=open;none
+	// +optional
+	CapacityReservationPreference CapacityReservationPreference `json:"capacityReservationPreference,omitempty"`
+
+	// CapacityReservationTarget is a specific Capacity Reservation or Capacity Reservation
+	// resource group in which to run the instance.
+	// +optional
+	CapacityReservationTarget *CapacityReservationTarget `json:"capacityReservationTarget,omitempty"`
+}
+
+// CapacityReservationTarget describes a target Capacity Reservation or Capacity Reservation group.
+// Only one of CapacityReservationID or CapacityReservationResourceGroupArn may be specified.
+type CapacityReservationTarget struct {
+	// CapacityReservationID is the ID of the Capacity Reservation in which to run the instance.
+	// +optional
+	CapacityReservationID *string `json:"capacityReservationID,omitempty"`
+
+	// CapacityReservationResourceGroupArn is the ARN of the Capacity Reservation resource group
+	// in which to run the instance.
+	// +optional
+	CapacityReservationResourceGroupArn *string `json:"capacityReservationResourceGroupArn,omitempty"`
+}
+
+// Validate validates the CapacityReservationSpecification.
+func (c *CapacityReservationSpecification) Validate(fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if c == nil {
+		return allErrs
+	}
+
+	if c.CapacityReservationPreference != "" && c.CapacityReservationTarget != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("capacityReservationTarget"), "cannot be set together with capacityReservationPreference"))
+	}
+
+	if target := c.CapacityReservationTarget; target != nil {
+		if target.CapacityReservationID != nil && target.CapacityReservationResourceGroupArn != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("capacityReservationTarget"), "only one of capacityReservationID or capacityReservationResourceGroupArn may be specified"))
+		}
+		if target.CapacityReservationID == nil && target.CapacityReservationResourceGroupArn == nil {
+			allErrs = append(allErrs, field.Required(fldPath.Child("capacityReservationTarget"), "one of capacityReservationID or capacityReservationResourceGroupArn must be specified"))
+		}
+	}
+
+	return allErrs
+}
+
 // EKSAMILookupType specifies which AWS AMI to use for a AWSMachine and AWSMachinePool.
 type EKSAMILookupType string
 
@@ -25,3 +25,8 @@ import (
 func Convert_v1beta1_AWSIAMConfigurationSpec_To_v1alpha1_AWSIAMConfigurationSpec(in *v1beta1.AWSIAMConfigurationSpec, out *AWSIAMConfigurationSpec, s conversion.Scope) error {
 	return autoConvert_v1beta1_AWSIAMConfigurationSpec_To_v1alpha1_AWSIAMConfigurationSpec(in, out, s)
 }
+
+// Convert_v1beta1_ClusterAPIControllers_To_v1alpha1_ClusterAPIControllers is an autogenerated conversion function.
+func Convert_v1beta1_ClusterAPIControllers_To_v1alpha1_ClusterAPIControllers(in *v1beta1.ClusterAPIControllers, out *ClusterAPIControllers, s conversion.Scope) error {
+	return autoConvert_v1beta1_ClusterAPIControllers_To_v1alpha1_ClusterAPIControllers(in, out, s)
+}
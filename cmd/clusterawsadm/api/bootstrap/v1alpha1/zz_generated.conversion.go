@@ -78,11 +78,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.ClusterAPIControllers)(nil), (*ClusterAPIControllers)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_ClusterAPIControllers_To_v1alpha1_ClusterAPIControllers(a.(*v1beta1.ClusterAPIControllers), b.(*ClusterAPIControllers), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*ControlPlane)(nil), (*v1beta1.ControlPlane)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ControlPlane_To_v1beta1_ControlPlane(a.(*ControlPlane), b.(*v1beta1.ControlPlane), scope)
 	}); err != nil {
@@ -128,6 +123,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta1.ClusterAPIControllers)(nil), (*ClusterAPIControllers)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ClusterAPIControllers_To_v1alpha1_ClusterAPIControllers(a.(*v1beta1.ClusterAPIControllers), b.(*ClusterAPIControllers), scope)
+	}); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -206,6 +206,7 @@ func autoConvert_v1beta1_AWSIAMConfigurationSpec_To_v1alpha1_AWSIAMConfiguration
 	out.EventBridge = (*EventBridgeConfig)(unsafe.Pointer(in.EventBridge))
 	out.Partition = in.Partition
 	out.SecureSecretsBackends = *(*[]v1beta2.SecretBackend)(unsafe.Pointer(&in.SecureSecretsBackends))
+	// WARNING: in.SecureSecretsKMSKeyARNs requires manual conversion: does not exist in peer-type
 	// WARNING: in.S3Buckets requires manual conversion: does not exist in peer-type
 	return nil
 }
@@ -288,14 +289,10 @@ func autoConvert_v1beta1_ClusterAPIControllers_To_v1alpha1_ClusterAPIControllers
 		return err
 	}
 	out.AllowedEC2InstanceProfiles = *(*[]string)(unsafe.Pointer(&in.AllowedEC2InstanceProfiles))
+	// WARNING: in.AMIParameterPrefixes requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_ClusterAPIControllers_To_v1alpha1_ClusterAPIControllers is an autogenerated conversion function.
-func Convert_v1beta1_ClusterAPIControllers_To_v1alpha1_ClusterAPIControllers(in *v1beta1.ClusterAPIControllers, out *ClusterAPIControllers, s conversion.Scope) error {
-	return autoConvert_v1beta1_ClusterAPIControllers_To_v1alpha1_ClusterAPIControllers(in, out, s)
-}
-
 func autoConvert_v1alpha1_ControlPlane_To_v1beta1_ControlPlane(in *ControlPlane, out *v1beta1.ControlPlane, s conversion.Scope) error {
 	if err := Convert_v1alpha1_AWSIAMRoleSpec_To_v1beta1_AWSIAMRoleSpec(&in.AWSIAMRoleSpec, &out.AWSIAMRoleSpec, s); err != nil {
 		return err
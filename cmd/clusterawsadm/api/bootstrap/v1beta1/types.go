@@ -132,6 +132,12 @@ type ClusterAPIControllers struct {
 	// consumed by Cluster API when creating an ec2 instance. Defaults to
 	// *.<suffix>, where suffix is defaulted to .cluster-api-provider-aws.sigs.k8s.io
 	AllowedEC2InstanceProfiles []string `json:"allowedEC2InstanceProfiles,omitempty"`
+
+	// AMIParameterPrefixes lists custom AWS Systems Manager parameter paths, e.g. /my-org/amis/,
+	// that the controllers are allowed to read AMI IDs from. Public AWS parameters under
+	// /aws/service/ are always allowed.
+	// +optional
+	AMIParameterPrefixes []string `json:"amiParameterPrefixes,omitempty"`
 }
 
 // Nodes controls the configuration of the AWS IAM role for worker nodes
@@ -219,6 +225,12 @@ type AWSIAMConfigurationSpec struct {
 	// +kubebuilder:validation:Enum=secrets-manager;ssm-parameter-store
 	SecureSecretsBackends []infrav1.SecretBackend `json:"secureSecretBackends,omitempty"`
 
+	// SecureSecretsKMSKeyARNs are the ARNs of the customer managed AWS KMS keys that AWSMachines may
+	// use to encrypt their userdata in the secret backends. When set, controllers are allowed to
+	// encrypt and nodes to decrypt with the keys, through the enabled secret backends only.
+	// +optional
+	SecureSecretsKMSKeyARNs []string `json:"secureSecretsKMSKeyARNs,omitempty"`
+
 	// S3Buckets, when enabled, will add controller nodes permissions to
 	// create S3 Buckets for workload clusters.
 	// TODO: This field could be a pointer, but it seems it breaks setting default values?
@@ -86,6 +86,11 @@ func (in *AWSIAMConfigurationSpec) DeepCopyInto(out *AWSIAMConfigurationSpec) {
 		*out = make([]v1beta2.SecretBackend, len(*in))
 		copy(*out, *in)
 	}
+	if in.SecureSecretsKMSKeyARNs != nil {
+		in, out := &in.SecureSecretsKMSKeyARNs, &out.SecureSecretsKMSKeyARNs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	out.S3Buckets = in.S3Buckets
 }
 
@@ -188,6 +193,11 @@ func (in *ClusterAPIControllers) DeepCopyInto(out *ClusterAPIControllers) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.AMIParameterPrefixes != nil {
+		in, out := &in.AMIParameterPrefixes, &out.AMIParameterPrefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAPIControllers.
@@ -18,6 +18,7 @@ package bootstrap
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/awslabs/goformation/v4/cloudformation"
 	cfn_iam "github.com/awslabs/goformation/v4/cloudformation/iam"
@@ -84,6 +85,7 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"ec2:AttachNetworkInterface",
 				"ec2:DetachNetworkInterface",
 				"ec2:AllocateAddress",
+				"ec2:AssociateAddress",
 				"ec2:AssignIpv6Addresses",
 				"ec2:AssignPrivateIpAddresses",
 				"ec2:UnassignPrivateIpAddresses",
@@ -97,6 +99,7 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"ec2:CreateRoute",
 				"ec2:CreateRouteTable",
 				"ec2:CreateSecurityGroup",
+				"ec2:CreateSnapshot",
 				"ec2:CreateSubnet",
 				"ec2:CreateTags",
 				"ec2:CreateVpc",
@@ -117,16 +120,21 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"ec2:DescribeInternetGateways",
 				"ec2:DescribeEgressOnlyInternetGateways",
 				"ec2:DescribeInstanceTypes",
+				"ec2:DescribeInstanceStatus",
+				"ec2:DescribeInstanceCreditSpecifications",
 				"ec2:DescribeImages",
 				"ec2:DescribeNatGateways",
 				"ec2:DescribeNetworkInterfaces",
 				"ec2:DescribeNetworkInterfaceAttribute",
 				"ec2:DescribeRouteTables",
 				"ec2:DescribeSecurityGroups",
+				"ec2:DescribeSnapshots",
 				"ec2:DescribeSubnets",
 				"ec2:DescribeVpcs",
 				"ec2:DescribeVpcAttribute",
 				"ec2:DescribeVolumes",
+				"ec2:DescribeVolumesModifications",
+				"ec2:DescribeInstanceAttribute",
 				"ec2:DescribeTags",
 				"ec2:DetachInternetGateway",
 				"ec2:DisassociateRouteTable",
@@ -134,6 +142,7 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"ec2:ModifyInstanceAttribute",
 				"ec2:ModifyNetworkInterfaceAttribute",
 				"ec2:ModifySubnetAttribute",
+				"ec2:ModifyVolume",
 				"ec2:ReleaseAddress",
 				"ec2:RevokeSecurityGroupIngress",
 				"ec2:RunInstances",
@@ -154,6 +163,9 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"elasticloadbalancing:RemoveTags",
 				"autoscaling:DescribeAutoScalingGroups",
 				"autoscaling:DescribeInstanceRefreshes",
+				"autoscaling:DescribeWarmPool",
+				"autoscaling:DescribeLifecycleHooks",
+				"autoscaling:DescribeScheduledActions",
 				"ec2:CreateLaunchTemplate",
 				"ec2:CreateLaunchTemplateVersion",
 				"ec2:DescribeLaunchTemplates",
@@ -173,8 +185,16 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"autoscaling:UpdateAutoScalingGroup",
 				"autoscaling:CreateOrUpdateTags",
 				"autoscaling:StartInstanceRefresh",
+				"autoscaling:CancelInstanceRefresh",
 				"autoscaling:DeleteAutoScalingGroup",
 				"autoscaling:DeleteTags",
+				"autoscaling:PutWarmPool",
+				"autoscaling:DeleteWarmPool",
+				"autoscaling:PutLifecycleHook",
+				"autoscaling:DeleteLifecycleHook",
+				"autoscaling:CompleteLifecycleAction",
+				"autoscaling:PutScheduledUpdateGroupAction",
+				"autoscaling:DeleteScheduledAction",
 			},
 		},
 		{
@@ -220,6 +240,7 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"iam:PassRole",
 			},
 		},
+		t.amiParametersPolicy(),
 	}
 	for _, secureSecretBackend := range t.Spec.SecureSecretsBackends {
 		switch secureSecretBackend {
@@ -235,6 +256,14 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 					"secretsmanager:TagResource",
 				},
 			})
+			// Listing secrets can't be scoped to a resource, it's needed to sweep orphaned bootstrap secrets.
+			statement = append(statement, iamv1.StatementEntry{
+				Effect:   iamv1.EffectAllow,
+				Resource: iamv1.Resources{iamv1.Any},
+				Action: iamv1.Actions{
+					"secretsmanager:ListSecrets",
+				},
+			})
 		case infrav1.SecretBackendSSMParameterStore:
 			statement = append(statement, iamv1.StatementEntry{
 				Effect: iamv1.EffectAllow,
@@ -247,8 +276,24 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 					"ssm:AddTagsToResource",
 				},
 			})
+			// Describing parameters can't be scoped to a resource, it's needed to sweep orphaned bootstrap secrets.
+			statement = append(statement, iamv1.StatementEntry{
+				Effect:   iamv1.EffectAllow,
+				Resource: iamv1.Resources{iamv1.Any},
+				Action: iamv1.Actions{
+					"ssm:DescribeParameters",
+				},
+			})
 		}
 	}
+	if len(t.Spec.SecureSecretsKMSKeyARNs) > 0 {
+		// Secrets Manager generates data keys and SSM encrypts standard parameters directly.
+		statement = append(statement, t.secureSecretsKMSPolicy(iamv1.Actions{
+			"kms:Encrypt",
+			"kms:GenerateDataKey",
+			"kms:Decrypt",
+		}))
+	}
 	if t.Spec.S3Buckets.Enable {
 		statement = append(statement, iamv1.StatementEntry{
 			Effect: iamv1.EffectAllow,
@@ -259,10 +304,27 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"s3:CreateBucket",
 				"s3:DeleteBucket",
 				"s3:PutObject",
+				"s3:GetObject",
 				"s3:DeleteObject",
 				"s3:PutBucketPolicy",
 			},
 		})
+		// Allows encrypting bootstrap data with customer managed KMS keys, and reading it through pre-signed URLs.
+		statement = append(statement, iamv1.StatementEntry{
+			Effect: iamv1.EffectAllow,
+			Resource: iamv1.Resources{
+				iamv1.Any,
+			},
+			Action: iamv1.Actions{
+				"kms:GenerateDataKey",
+				"kms:Decrypt",
+			},
+			Condition: iamv1.Conditions{
+				"StringLike": map[string]string{
+					"kms:ViaService": "s3.*.amazonaws.com",
+				},
+			},
+		})
 	}
 	if t.Spec.EventBridge.Enable {
 		statement = append(statement, iamv1.StatementEntry{
@@ -300,15 +362,7 @@ func (t Template) ControllersPolicyEKS() *iamv1.PolicyDocument {
 		"iam:GetRole",
 		"iam:ListAttachedRolePolicies",
 	}
-	statement = append(statement, iamv1.StatementEntry{
-		Effect: iamv1.EffectAllow,
-		Resource: iamv1.Resources{
-			"arn:*:ssm:*:*:parameter/aws/service/eks/optimized-ami/*",
-		},
-		Action: iamv1.Actions{
-			"ssm:GetParameter",
-		},
-	})
+	statement = append(statement, t.amiParametersPolicy())
 
 	statement = append(statement, iamv1.StatementEntry{
 		Effect: iamv1.EffectAllow,
@@ -470,6 +524,23 @@ func (t Template) ControllersPolicyEKS() *iamv1.PolicyDocument {
 	}
 }
 
+// amiParametersPolicy allows the controllers to resolve AMI IDs from public AWS parameters
+// and from the configured custom parameter paths.
+func (t Template) amiParametersPolicy() iamv1.StatementEntry {
+	resources := iamv1.Resources{"arn:*:ssm:*:*:parameter/aws/service/*"}
+	for _, prefix := range t.Spec.ClusterAPIControllers.AMIParameterPrefixes {
+		resources = append(resources, fmt.Sprintf("arn:*:ssm:*:*:parameter/%s*", strings.TrimPrefix(prefix, "/")))
+	}
+
+	return iamv1.StatementEntry{
+		Effect:   iamv1.EffectAllow,
+		Resource: resources,
+		Action: iamv1.Actions{
+			"ssm:GetParameter",
+		},
+	}
+}
+
 func (t Template) allowedEC2InstanceProfiles() iamv1.Resources {
 	if t.Spec.ClusterAPIControllers.AllowedEC2InstanceProfiles == nil {
 		t.Spec.ClusterAPIControllers.AllowedEC2InstanceProfiles = []string{
@@ -49,6 +49,30 @@ func (t Template) secretPolicy(secureSecretsBackend infrav1.SecretBackend) iamv1
 	return iamv1.StatementEntry{}
 }
 
+// secureSecretsKMSPolicy allows the given actions on the customer managed KMS keys of the secret backends,
+// when they are used by one of the enabled secret backends.
+func (t Template) secureSecretsKMSPolicy(actions iamv1.Actions) iamv1.StatementEntry {
+	viaServices := []string{}
+	for _, secureSecretsBackend := range t.Spec.SecureSecretsBackends {
+		switch secureSecretsBackend {
+		case infrav1.SecretBackendSecretsManager:
+			viaServices = append(viaServices, "secretsmanager.*.amazonaws.com")
+		case infrav1.SecretBackendSSMParameterStore:
+			viaServices = append(viaServices, "ssm.*.amazonaws.com")
+		}
+	}
+	return iamv1.StatementEntry{
+		Effect:   iamv1.EffectAllow,
+		Resource: iamv1.Resources(t.Spec.SecureSecretsKMSKeyARNs),
+		Action:   actions,
+		Condition: iamv1.Conditions{
+			"StringLike": map[string][]string{
+				"kms:ViaService": viaServices,
+			},
+		},
+	}
+}
+
 func (t Template) sessionManagerPolicy() iamv1.StatementEntry {
 	return iamv1.StatementEntry{
 		Effect:   iamv1.EffectAllow,
@@ -89,6 +113,12 @@ func (t Template) nodePolicy() *iamv1.PolicyDocument {
 			t.secretPolicy(secureSecretsBackend),
 		)
 	}
+	if len(t.Spec.SecureSecretsKMSKeyARNs) > 0 {
+		policyDocument.Statement = append(
+			policyDocument.Statement,
+			t.secureSecretsKMSPolicy(iamv1.Actions{"kms:Decrypt"}),
+		)
+	}
 	policyDocument.Statement = append(
 		policyDocument.Statement,
 		t.sessionManagerPolicy(),
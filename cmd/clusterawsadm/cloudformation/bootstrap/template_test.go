@@ -61,6 +61,18 @@ func Test_RenderCloudformation(t *testing.T) {
 				return t
 			},
 		},
+		{
+			fixture: "with_secure_secrets_kms_keys",
+			template: func() Template {
+				t := NewTemplate()
+				t.Spec.SecureSecretsBackends = []infrav1.SecretBackend{
+					infrav1.SecretBackendSecretsManager,
+					infrav1.SecretBackendSSMParameterStore,
+				}
+				t.Spec.SecureSecretsKMSKeyARNs = []string{"arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"}
+				return t
+			},
+		},
 		{
 			fixture: "with_s3_bucket",
 			template: func() Template {
@@ -102,6 +114,14 @@ func Test_RenderCloudformation(t *testing.T) {
 				return t
 			},
 		},
+		{
+			fixture: "with_custom_ami_parameters",
+			template: func() Template {
+				t := NewTemplate()
+				t.Spec.ClusterAPIControllers.AMIParameterPrefixes = []string{"/my-org/amis/"}
+				return t
+			},
+		},
 		{
 			fixture: "with_eks_default_roles",
 			template: func() Template {
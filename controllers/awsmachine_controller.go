@@ -20,14 +20,23 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	ignV30Types "github.com/coreos/ignition/v2/config/v3_0/types"
+	ignV31Types "github.com/coreos/ignition/v2/config/v3_1/types"
+	ignV32Types "github.com/coreos/ignition/v2/config/v3_2/types"
+	ignV33Types "github.com/coreos/ignition/v2/config/v3_3/types"
 	ignTypes "github.com/flatcar/ignition/config/v2_3/types"
 	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/pointer"
@@ -60,12 +69,21 @@ import (
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/cluster-api/util/predicates"
 )
 
 // InstanceIDIndex defines the aws machine controller's instance ID index.
 const InstanceIDIndex = ".spec.instanceID"
 
+const (
+	// orphanedSecretsSweepInterval is how often the secret backends are swept for orphaned bootstrap secrets.
+	orphanedSecretsSweepInterval = 30 * time.Minute
+	// orphanedSecretsGracePeriod is how old a bootstrap secret must be before it is considered orphaned, so that
+	// the secrets of machines which haven't recorded their secret prefix yet are left alone.
+	orphanedSecretsGracePeriod = time.Hour
+)
+
 // AWSMachineReconciler reconciles a AwsMachine object.
 type AWSMachineReconciler struct {
 	client.Client
@@ -78,6 +96,10 @@ type AWSMachineReconciler struct {
 	objectStoreServiceFactory    func(cloud.ClusterScoper) services.ObjectStoreInterface
 	Endpoints                    []scope.ServiceEndpoint
 	WatchFilterValue             string
+
+	// orphanedSecretsSweeps holds the clusters whose secret backends were swept recently.
+	orphanedSecretsSweeps     cache.Store
+	orphanedSecretsSweepsOnce sync.Once
 }
 
 const (
@@ -93,6 +115,7 @@ func (r *AWSMachineReconciler) getEC2Service(scope scope.EC2Scope) services.EC2I
 	return ec2.NewService(scope)
 }
 
+// getSecretsManagerService factory func is added for testing purpose so that we can inject mocked Secrets Manager service to the AWSMachineReconciler.
 func (r *AWSMachineReconciler) getSecretsManagerService(scope cloud.ClusterScoper) services.SecretInterface {
 	if r.secretsManagerServiceFactory != nil {
 		return r.secretsManagerServiceFactory(scope)
@@ -101,6 +124,7 @@ func (r *AWSMachineReconciler) getSecretsManagerService(scope cloud.ClusterScope
 	return secretsmanager.NewService(scope)
 }
 
+// getSSMService factory func is added for testing purpose so that we can inject mocked SSM service to the AWSMachineReconciler.
 func (r *AWSMachineReconciler) getSSMService(scope cloud.ClusterScoper) services.SecretInterface {
 	if r.SSMServiceFactory != nil {
 		return r.SSMServiceFactory(scope)
@@ -135,7 +159,8 @@ func (r *AWSMachineReconciler) getObjectStoreService(scope scope.S3Scope) servic
 
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachines,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachines/status,verbs=get;update;patch
-// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines;machines/status,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachinetemplates,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines;machines/status,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups="",resources=secrets;,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch
@@ -311,6 +336,9 @@ func (r *AWSMachineReconciler) reconcileDelete(machineScope *scope.MachineScope,
 		// 4. Scale controller deployment to 1
 		machineScope.Debug("Unable to locate EC2 instance by ID or tags")
 		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "NoInstanceFound", "Unable to find matching EC2 instance")
+		if err := r.releaseElasticIP(ec2Service, machineScope); err != nil {
+			return ctrl.Result{}, err
+		}
 		controllerutil.RemoveFinalizer(machineScope.AWSMachine, infrav1.MachineFinalizer)
 		return ctrl.Result{}, nil
 	}
@@ -345,6 +373,10 @@ func (r *AWSMachineReconciler) reconcileDelete(machineScope *scope.MachineScope,
 	default:
 		machineScope.Info("Terminating EC2 instance", "instance-id", instance.ID)
 
+		if err := r.applyVolumeDeletionPolicies(ec2Service, machineScope, instance); err != nil {
+			return ctrl.Result{}, err
+		}
+
 		// Set the InstanceReadyCondition and patch the object before the blocking operation
 		conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, "")
 		if err := machineScope.PatchObject(); err != nil {
@@ -393,12 +425,165 @@ func (r *AWSMachineReconciler) reconcileDelete(machineScope *scope.MachineScope,
 		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeNormal, "SuccessfulTerminate", "Terminated instance %q", instance.ID)
 	}
 
+	if err := r.releaseElasticIP(ec2Service, machineScope); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// Instance is deleted so remove the finalizer.
 	controllerutil.RemoveFinalizer(machineScope.AWSMachine, infrav1.MachineFinalizer)
 
 	return ctrl.Result{}, nil
 }
 
+// reconcileElasticIP associates the Elastic IP requested by the AWSMachine with its running instance.
+func (r *AWSMachineReconciler) reconcileElasticIP(ec2svc services.EC2Interface, machineScope *scope.MachineScope, instance *infrav1.Instance) error {
+	if machineScope.AWSMachine.Spec.ElasticIP == nil || instance.State != infrav1.InstanceStateRunning {
+		return nil
+	}
+
+	if err := ec2svc.ReconcileElasticIP(machineScope, instance); err != nil {
+		conditions.MarkFalse(machineScope.AWSMachine, infrav1.ElasticIPAssociatedCondition, infrav1.ElasticIPAssociationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return err
+	}
+	conditions.MarkTrue(machineScope.AWSMachine, infrav1.ElasticIPAssociatedCondition)
+
+	return nil
+}
+
+// VolumesLastAppliedAnnotation is the key for the AWSMachine annotation which tracks the root and non root
+// volumes last applied to the volumes attached to its instance.
+const VolumesLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-aws/v2-last-applied-volumes"
+
+// reconcileVolumes applies changes of the root and non root volumes to the volumes attached to the running instance.
+// The attached volumes are only described again once the volumes of the spec differ from the last applied ones, or
+// while a previous modification hasn't completed.
+func (r *AWSMachineReconciler) reconcileVolumes(ec2svc services.EC2Interface, machineScope *scope.MachineScope, instance *infrav1.Instance) error {
+	awsMachine := machineScope.AWSMachine
+	if instance.State != infrav1.InstanceStateRunning || (awsMachine.Spec.RootVolume == nil && len(awsMachine.Spec.NonRootVolumes) == 0) {
+		return nil
+	}
+
+	volumes, err := json.Marshal(map[string]interface{}{
+		"rootVolume":     awsMachine.Spec.RootVolume,
+		"nonRootVolumes": awsMachine.Spec.NonRootVolumes,
+	})
+	if err != nil {
+		return err
+	}
+	if conditions.IsTrue(awsMachine, infrav1.VolumesUpToDateCondition) && r.machineAnnotation(awsMachine, VolumesLastAppliedAnnotation) == string(volumes) {
+		return nil
+	}
+
+	inProgress, err := ec2svc.ReconcileVolumes(instance, awsMachine.Spec.RootVolume, awsMachine.Spec.NonRootVolumes)
+	if err != nil {
+		conditions.MarkFalse(awsMachine, infrav1.VolumesUpToDateCondition, infrav1.VolumeModificationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		r.Recorder.Eventf(awsMachine, corev1.EventTypeWarning, "FailedModifyVolumes", "Failed to modify volumes of instance %q: %v", instance.ID, err)
+		return err
+	}
+
+	if inProgress {
+		conditions.MarkFalse(awsMachine, infrav1.VolumesUpToDateCondition, infrav1.VolumeModificationInProgressReason, clusterv1.ConditionSeverityInfo, "")
+		return nil
+	}
+	conditions.MarkTrue(awsMachine, infrav1.VolumesUpToDateCondition)
+
+	if awsMachine.Annotations == nil {
+		awsMachine.Annotations = map[string]string{}
+	}
+	r.updateMachineAnnotation(awsMachine, VolumesLastAppliedAnnotation, string(volumes))
+
+	return nil
+}
+
+// releaseElasticIP releases the Elastic IP of a deleted AWSMachine, or returns it to its pool.
+// remediateImpairedInstance flags the Machine of an instance failing its EC2 status checks for remediation, so that
+// its owner replaces it. The status checks are only reported on the AWSMachine, and a MachineHealthCheck only
+// evaluates the conditions of the Node, which may well stay healthy while the instance is impaired.
+func (r *AWSMachineReconciler) remediateImpairedInstance(machineScope *scope.MachineScope) error {
+	var failed *clusterv1.Condition
+	for _, condition := range []clusterv1.ConditionType{infrav1.InstanceStatusCheckPassedCondition, infrav1.SystemStatusCheckPassedCondition} {
+		if conditions.IsFalse(machineScope.AWSMachine, condition) {
+			failed = conditions.Get(machineScope.AWSMachine, condition)
+			break
+		}
+	}
+	machine := machineScope.Machine
+	if failed == nil || machine == nil || !machine.DeletionTimestamp.IsZero() {
+		return nil
+	}
+	if _, ok := machine.Annotations[clusterv1.MachineSkipRemediationAnnotation]; ok {
+		return nil
+	}
+	if _, ok := machine.Annotations[clusterv1.DeleteMachineAnnotation]; ok && conditions.IsFalse(machine, clusterv1.MachineOwnerRemediatedCondition) {
+		return nil
+	}
+
+	patchHelper, err := patch.NewHelper(machine, r.Client)
+	if err != nil {
+		return errors.Wrap(err, "failed to create patch helper for machine")
+	}
+
+	// Prefer the machine when scaling down and ask its owner to replace it, as a machine health check would.
+	message := fmt.Sprintf("Instance %s is impaired: %s", pointer.StringDeref(machineScope.AWSMachine.Spec.InstanceID, ""), failed.Message)
+	annotations.AddAnnotations(machine, map[string]string{clusterv1.DeleteMachineAnnotation: ""})
+	conditions.MarkFalse(machine, clusterv1.MachineOwnerRemediatedCondition, clusterv1.WaitingForRemediationReason, clusterv1.ConditionSeverityWarning, message)
+	if err := patchHelper.Patch(context.TODO(), machine, patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{clusterv1.MachineOwnerRemediatedCondition}}); err != nil {
+		return errors.Wrapf(err, "failed to patch machine %s", machine.Name)
+	}
+	r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "ImpairedInstanceRemediation", "Requested the replacement of machine %s: %s", machine.Name, message)
+
+	return nil
+}
+
+func (r *AWSMachineReconciler) releaseElasticIP(ec2svc services.EC2Interface, machineScope *scope.MachineScope) error {
+	if machineScope.AWSMachine.Status.ElasticIPAllocationID == nil {
+		return nil
+	}
+
+	if err := ec2svc.ReleaseElasticIP(machineScope); err != nil {
+		machineScope.Error(err, "failed to release Elastic IP")
+		conditions.MarkFalse(machineScope.AWSMachine, infrav1.ElasticIPAssociatedCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, err.Error())
+		return err
+	}
+	conditions.MarkFalse(machineScope.AWSMachine, infrav1.ElasticIPAssociatedCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
+
+	return nil
+}
+
+// applyVolumeDeletionPolicies retains or snapshots the volumes of the instance about to be terminated,
+// as requested by the deletion policy of the root and non root volumes.
+func (r *AWSMachineReconciler) applyVolumeDeletionPolicies(ec2svc services.EC2Interface, machineScope *scope.MachineScope, instance *infrav1.Instance) error {
+	if !hasVolumeDeletionPolicy(machineScope.AWSMachine.Spec.RootVolume, machineScope.AWSMachine.Spec.NonRootVolumes) {
+		return nil
+	}
+
+	if err := ec2svc.ApplyVolumeDeletionPolicies(machineScope, instance); err != nil {
+		machineScope.Error(err, "failed to apply volume deletion policies")
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedApplyVolumeDeletionPolicies", "Failed to apply volume deletion policies to instance %q: %v", instance.ID, err)
+		return err
+	}
+
+	status := machineScope.AWSMachine.Status
+	if len(status.RetainedVolumeIDs) > 0 || len(status.VolumeSnapshotIDs) > 0 {
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeNormal, "SuccessfulApplyVolumeDeletionPolicies",
+			"Retaining volumes %v and snapshots %v of instance %q", status.RetainedVolumeIDs, status.VolumeSnapshotIDs, instance.ID)
+	}
+
+	return nil
+}
+
+func hasVolumeDeletionPolicy(rootVolume *infrav1.Volume, nonRootVolumes []infrav1.Volume) bool {
+	if rootVolume != nil && rootVolume.VolumeDeletionPolicy != "" {
+		return true
+	}
+	for _, volume := range nonRootVolumes {
+		if volume.VolumeDeletionPolicy != "" {
+			return true
+		}
+	}
+	return false
+}
+
 // findInstance queries the EC2 apis and retrieves the instance if it exists.
 // If providerID is empty, finds instance by tags and if it cannot be found, returns empty instance with nil error.
 // If providerID is set, either finds the instance by ID or returns error.
@@ -452,6 +637,11 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 		return ctrl.Result{}, nil
 	}
 
+	if err := r.sweepOrphanedSecrets(machineScope, clusterScope); err != nil {
+		// non fatal error, so we continue
+		machineScope.Error(err, "non-fatal: failed to delete orphaned bootstrap secrets")
+	}
+
 	// Make sure bootstrap data is available and populated.
 	if machineScope.Machine.Spec.Bootstrap.DataSecretName == nil {
 		machineScope.Info("Bootstrap data secret reference is not yet available")
@@ -512,6 +702,14 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 	machineScope.SetProviderID(instance.ID, instance.AvailabilityZone)
 	machineScope.SetInstanceID(instance.ID)
 
+	// Record the instance type, which differs from the spec when the instance was launched with a fallback instance type.
+	machineScope.SetInstanceType(instance.Type)
+
+	// Record the instance store volumes, which are only known when the instance is launched.
+	if instance.InstanceStoreVolumes != nil {
+		machineScope.SetInstanceStoreVolumes(instance.InstanceStoreVolumes)
+	}
+
 	// See https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/ec2-instance-lifecycle.html
 
 	// Sets the AWSMachine status Interruptible, when the SpotMarketOptions is enabled for AWSMachine, Interruptible is set as true.
@@ -580,6 +778,11 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 
 	// tasks that can only take place during operational instance states
 	if machineScope.InstanceIsOperational() {
+		if err := r.reconcileElasticIP(ec2svc, machineScope, instance); err != nil {
+			machineScope.Error(err, "failed to reconcile Elastic IP")
+			return ctrl.Result{}, err
+		}
+
 		machineScope.SetAddresses(instance.Addresses)
 
 		existingSecurityGroups, err := ec2svc.GetInstanceSecurityGroups(*machineScope.GetInstanceID())
@@ -596,6 +799,29 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 			return ctrl.Result{}, err
 		}
 		conditions.MarkTrue(machineScope.AWSMachine, infrav1.SecurityGroupsReadyCondition)
+
+		if err := r.reconcileVolumes(ec2svc, machineScope, instance); err != nil {
+			machineScope.Error(err, "failed to reconcile volumes")
+			return ctrl.Result{}, err
+		}
+
+		if instance.State == infrav1.InstanceStateRunning {
+			if err := ec2svc.ReconcileInstanceStatus(machineScope, instance.ID); err != nil {
+				machineScope.Error(err, "failed to reconcile instance status")
+				return ctrl.Result{}, err
+			}
+			if err := r.remediateImpairedInstance(machineScope); err != nil {
+				machineScope.Error(err, "failed to request the remediation of the machine")
+				return ctrl.Result{}, err
+			}
+
+			// A failed check is reported with the AMIDeprecated condition and must not block the reconciliation.
+			if instance.ImageID != "" {
+				if err := ec2svc.ReconcileAMIDeprecation(machineScope.AWSMachine, instance.ImageID); err != nil {
+					machineScope.Error(err, "failed to check AMI deprecation")
+				}
+			}
+		}
 	}
 
 	return ctrl.Result{}, nil
@@ -634,6 +860,73 @@ func (r *AWSMachineReconciler) deleteEncryptedBootstrapDataSecret(machineScope *
 	return nil
 }
 
+// sweepOrphanedSecrets deletes the bootstrap secrets of the cluster that aren't used by any AWSMachine, e.g. because
+// the controller crashed before recording the secret prefix or the instance was terminated outside of CAPA. Only the
+// secret backends used by the AWSMachines of the cluster are swept, so that the controller isn't required to have
+// access to the others. The in-use prefixes are those of the AWSMachines in the namespace of the cluster, so only the
+// secrets tagged with that namespace are swept, which leaves alone the secrets of a cluster of the same name in
+// another namespace. The sweep runs at most once per orphanedSecretsSweepInterval for each cluster.
+func (r *AWSMachineReconciler) sweepOrphanedSecrets(machineScope *scope.MachineScope, clusterScope cloud.ClusterScoper) error {
+	r.orphanedSecretsSweepsOnce.Do(func() {
+		r.orphanedSecretsSweeps = cache.NewTTLStore(func(obj interface{}) (string, error) {
+			return obj.(string), nil
+		}, orphanedSecretsSweepInterval)
+	})
+	key := clusterScope.Namespace() + "/" + clusterScope.InfraClusterName()
+	if _, exists, _ := r.orphanedSecretsSweeps.GetByKey(key); exists {
+		return nil
+	}
+	if err := r.orphanedSecretsSweeps.Add(key); err != nil {
+		return err
+	}
+
+	// Take the cutoff before listing the machines, so that the secrets created after the list are never swept.
+	createdBefore := time.Now().Add(-orphanedSecretsGracePeriod)
+
+	// The prefixes are unique, so there is no need to restrict them to the machines of this cluster, which also
+	// covers the machines that haven't been labelled yet.
+	machineList := &infrav1.AWSMachineList{}
+	if err := r.Client.List(context.TODO(), machineList, client.InNamespace(clusterScope.Namespace())); err != nil {
+		return errors.Wrap(err, "failed to list AWSMachines")
+	}
+	inUsePrefixes := make([]string, 0, len(machineList.Items))
+	backends := map[infrav1.SecretBackend]bool{}
+	for _, machine := range machineList.Items {
+		if machine.Spec.CloudInit.SecretPrefix != "" {
+			inUsePrefixes = append(inUsePrefixes, machine.Spec.CloudInit.SecretPrefix)
+		}
+		if machine.Labels[clusterv1.ClusterLabelName] != machineScope.Cluster.Name || machine.Spec.CloudInit.InsecureSkipSecretsManager {
+			continue
+		}
+		backend := machine.Spec.CloudInit.SecureSecretsBackend
+		if backend == "" {
+			backend = infrav1.SecretBackendSecretsManager
+		}
+		backends[backend] = true
+	}
+
+	var secretSvcs []services.SecretInterface
+	if backends[infrav1.SecretBackendSecretsManager] {
+		secretSvcs = append(secretSvcs, r.getSecretsManagerService(clusterScope))
+	}
+	if backends[infrav1.SecretBackendSSMParameterStore] {
+		secretSvcs = append(secretSvcs, r.getSSMService(clusterScope))
+	}
+
+	var errs []error
+	for _, secretSvc := range secretSvcs {
+		deleted, err := secretSvc.DeleteOrphanedSecrets(inUsePrefixes, createdBefore)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if len(deleted) > 0 {
+			machineScope.Info("Deleted orphaned bootstrap secrets", "secrets", deleted)
+		}
+	}
+
+	return kerrors.NewAggregate(errs)
+}
+
 func (r *AWSMachineReconciler) createInstance(ec2svc services.EC2Interface, machineScope *scope.MachineScope, clusterScope cloud.ClusterScoper, objectStoreSvc services.ObjectStoreInterface) (*infrav1.Instance, error) {
 	machineScope.Info("Creating EC2 instance")
 
@@ -642,14 +935,54 @@ func (r *AWSMachineReconciler) createInstance(ec2svc services.EC2Interface, mach
 		return nil, errors.Wrapf(userDataErr, "failed to resolve userdata")
 	}
 
+	// The template the AWSMachine was cloned from pins the AMI looked up for its machines.
+	template, err := r.getAWSMachineTemplate(machineScope)
+	if err != nil {
+		return nil, err
+	}
+	if template != nil {
+		machineScope.AWSMachineTemplate = template
+		defer func() { machineScope.AWSMachineTemplate = nil }()
+	}
+
 	instance, err := ec2svc.CreateInstance(machineScope, userData, userDataFormat)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to create AWSMachine instance")
 	}
 
+	// The instance exists at this point, so failing to record the security groups of its launch template must not
+	// fail its creation.
+	if err := r.recordLaunchTemplateSecurityGroups(ec2svc, machineScope, instance); err != nil {
+		machineScope.Error(err, "failed to record the security groups of the launch template")
+	}
+
 	return instance, nil
 }
 
+// getAWSMachineTemplate returns the AWSMachineTemplate the AWSMachine was cloned from, or nil if it wasn't cloned
+// from one or the template no longer exists.
+func (r *AWSMachineReconciler) getAWSMachineTemplate(machineScope *scope.MachineScope) (*infrav1.AWSMachineTemplate, error) {
+	name, ok := machineScope.AWSMachine.Annotations[clusterv1.TemplateClonedFromNameAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	groupKind := infrav1.GroupVersion.WithKind("AWSMachineTemplate").GroupKind()
+	if machineScope.AWSMachine.Annotations[clusterv1.TemplateClonedFromGroupKindAnnotation] != groupKind.String() {
+		return nil, nil
+	}
+
+	template := &infrav1.AWSMachineTemplate{}
+	key := client.ObjectKey{Namespace: machineScope.Namespace(), Name: name}
+	if err := r.Client.Get(context.TODO(), key, template); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to get AWSMachineTemplate %q", name)
+	}
+
+	return template, nil
+}
+
 func (r *AWSMachineReconciler) resolveUserData(machineScope *scope.MachineScope, clusterScope cloud.ClusterScoper, objectStoreSvc services.ObjectStoreInterface) ([]byte, string, error) {
 	userData, userDataFormat, err := machineScope.GetRawBootstrapDataWithFormat()
 	if err != nil {
@@ -694,7 +1027,7 @@ func (r *AWSMachineReconciler) cloudInitUserData(machineScope *scope.MachineScop
 		machineScope.Error(serviceErr, "Failed to create AWS Secret entry", "secretPrefix", prefix)
 		return nil, serviceErr
 	}
-	encryptedCloudInit, err := secretSvc.UserData(machineScope.GetSecretPrefix(), machineScope.GetSecretCount(), machineScope.InfraCluster.Region(), r.Endpoints)
+	encryptedCloudInit, err := secretSvc.UserData(machineScope.GetSecretPrefix(), machineScope.GetSecretCount(), machineScope.InfraCluster.Region(), machineScope.SecureSecretsKMSKeyID(), r.Endpoints)
 	if err != nil {
 		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedGenerateAWSSecretsCloudInit", err.Error())
 		return nil, err
@@ -703,6 +1036,11 @@ func (r *AWSMachineReconciler) cloudInitUserData(machineScope *scope.MachineScop
 }
 
 func (r *AWSMachineReconciler) ignitionUserData(scope *scope.MachineScope, objectStoreSvc services.ObjectStoreInterface, userData []byte) ([]byte, error) {
+	ignition := scope.AWSMachine.Spec.Ignition
+	if ignition != nil && ignition.StorageType == infrav1.IgnitionStorageTypeUnencryptedUserData {
+		return userData, nil
+	}
+
 	if objectStoreSvc == nil {
 		return nil, errors.New("object store service not available")
 	}
@@ -712,26 +1050,186 @@ func (r *AWSMachineReconciler) ignitionUserData(scope *scope.MachineScope, objec
 		return nil, errors.Wrap(err, "creating userdata object")
 	}
 
-	ignData := &ignTypes.Config{
-		Ignition: ignTypes.Ignition{
-			Version: "2.3.0",
-			Config: ignTypes.IgnitionConfig{
-				Append: []ignTypes.ConfigReference{
+	ignitionUserData, err := ignitionStubUserData(ignition, objectURL)
+	if err != nil {
+		r.Recorder.Eventf(scope.AWSMachine, corev1.EventTypeWarning, "FailedGenerateIgnition", err.Error())
+		return nil, errors.Wrap(err, "serializing generated data")
+	}
+
+	return ignitionUserData, nil
+}
+
+// ignitionStubUserData returns the Ignition configuration that makes Ignition fetch the bootstrap data from
+// the given URL, in the Ignition version of the machine.
+func ignitionStubUserData(ignition *infrav1.Ignition, objectURL string) ([]byte, error) {
+	version := infrav1.DefaultIgnitionVersion
+	if ignition != nil && ignition.Version != "" {
+		version = ignition.Version
+	}
+
+	if !strings.HasPrefix(version, "3.") {
+		return json.Marshal(&ignTypes.Config{
+			Ignition: ignTypes.Ignition{
+				Version: version + ".0",
+				Config: ignTypes.IgnitionConfig{
+					Append: []ignTypes.ConfigReference{
+						{
+							Source: objectURL,
+						},
+					},
+				},
+			},
+		})
+	}
+
+	var tls *infrav1.IgnitionTLS
+	var proxy *infrav1.IgnitionProxy
+	if ignition != nil {
+		tls, proxy = ignition.TLS, ignition.Proxy
+	}
+
+	// Each Ignition version only accepts the configuration of its own spec version, so the stub is generated with
+	// the types of the version of the machine.
+	switch version {
+	case "3.0":
+		return ignitionV30StubUserData(version+".0", objectURL, tls)
+	case "3.1":
+		return ignitionV31StubUserData(version+".0", objectURL, tls, proxy)
+	case "3.2":
+		return ignitionV32StubUserData(version+".0", objectURL, tls, proxy)
+	case "3.3":
+		return ignitionV33StubUserData(version+".0", objectURL, tls, proxy)
+	default:
+		return nil, errors.Errorf("unsupported Ignition version %q", version)
+	}
+}
+
+// ignitionV30StubUserData returns the Ignition 3.0 stub configuration, which doesn't support proxies.
+func ignitionV30StubUserData(version, objectURL string, tls *infrav1.IgnitionTLS) ([]byte, error) {
+	ignData := &ignV30Types.Config{
+		Ignition: ignV30Types.Ignition{
+			Version: version,
+			Config: ignV30Types.IgnitionConfig{
+				Merge: []ignV30Types.ConfigReference{
 					{
-						Source: objectURL,
+						Source: aws.String(objectURL),
 					},
 				},
 			},
 		},
 	}
 
-	ignitionUserData, err := json.Marshal(ignData)
-	if err != nil {
-		r.Recorder.Eventf(scope.AWSMachine, corev1.EventTypeWarning, "FailedGenerateIgnition", err.Error())
-		return nil, errors.Wrap(err, "serializing generated data")
+	if tls != nil {
+		for _, caSource := range tls.CASources {
+			ignData.Ignition.Security.TLS.CertificateAuthorities = append(ignData.Ignition.Security.TLS.CertificateAuthorities, ignV30Types.CaReference{
+				Source: caSource,
+			})
+		}
 	}
 
-	return ignitionUserData, nil
+	return json.Marshal(ignData)
+}
+
+// ignitionV31StubUserData returns the Ignition 3.1 stub configuration.
+func ignitionV31StubUserData(version, objectURL string, tls *infrav1.IgnitionTLS, proxy *infrav1.IgnitionProxy) ([]byte, error) {
+	ignData := &ignV31Types.Config{
+		Ignition: ignV31Types.Ignition{
+			Version: version,
+			Config: ignV31Types.IgnitionConfig{
+				Merge: []ignV31Types.Resource{
+					{
+						Source: aws.String(objectURL),
+					},
+				},
+			},
+		},
+	}
+
+	if tls != nil {
+		for _, caSource := range tls.CASources {
+			ignData.Ignition.Security.TLS.CertificateAuthorities = append(ignData.Ignition.Security.TLS.CertificateAuthorities, ignV31Types.Resource{
+				Source: aws.String(caSource),
+			})
+		}
+	}
+
+	if proxy != nil {
+		ignData.Ignition.Proxy.HTTPProxy = proxy.HTTPProxy
+		ignData.Ignition.Proxy.HTTPSProxy = proxy.HTTPSProxy
+		for _, noProxy := range proxy.NoProxy {
+			ignData.Ignition.Proxy.NoProxy = append(ignData.Ignition.Proxy.NoProxy, ignV31Types.NoProxyItem(noProxy))
+		}
+	}
+
+	return json.Marshal(ignData)
+}
+
+// ignitionV32StubUserData returns the Ignition 3.2 stub configuration.
+func ignitionV32StubUserData(version, objectURL string, tls *infrav1.IgnitionTLS, proxy *infrav1.IgnitionProxy) ([]byte, error) {
+	ignData := &ignV32Types.Config{
+		Ignition: ignV32Types.Ignition{
+			Version: version,
+			Config: ignV32Types.IgnitionConfig{
+				Merge: []ignV32Types.Resource{
+					{
+						Source: aws.String(objectURL),
+					},
+				},
+			},
+		},
+	}
+
+	if tls != nil {
+		for _, caSource := range tls.CASources {
+			ignData.Ignition.Security.TLS.CertificateAuthorities = append(ignData.Ignition.Security.TLS.CertificateAuthorities, ignV32Types.Resource{
+				Source: aws.String(caSource),
+			})
+		}
+	}
+
+	if proxy != nil {
+		ignData.Ignition.Proxy.HTTPProxy = proxy.HTTPProxy
+		ignData.Ignition.Proxy.HTTPSProxy = proxy.HTTPSProxy
+		for _, noProxy := range proxy.NoProxy {
+			ignData.Ignition.Proxy.NoProxy = append(ignData.Ignition.Proxy.NoProxy, ignV32Types.NoProxyItem(noProxy))
+		}
+	}
+
+	return json.Marshal(ignData)
+}
+
+// ignitionV33StubUserData returns the Ignition 3.3 stub configuration.
+func ignitionV33StubUserData(version, objectURL string, tls *infrav1.IgnitionTLS, proxy *infrav1.IgnitionProxy) ([]byte, error) {
+	ignData := &ignV33Types.Config{
+		Ignition: ignV33Types.Ignition{
+			Version: version,
+			Config: ignV33Types.IgnitionConfig{
+				Merge: []ignV33Types.Resource{
+					{
+						Source: aws.String(objectURL),
+					},
+				},
+			},
+		},
+	}
+
+	if tls != nil {
+		for _, caSource := range tls.CASources {
+			ignData.Ignition.Security.TLS.CertificateAuthorities = append(ignData.Ignition.Security.TLS.CertificateAuthorities, ignV33Types.Resource{
+				Source: aws.String(caSource),
+			})
+		}
+	}
+
+	if proxy != nil {
+		ignData.Ignition.Proxy.HTTPProxy = proxy.HTTPProxy
+		ignData.Ignition.Proxy.HTTPSProxy = proxy.HTTPSProxy
+		for _, noProxy := range proxy.NoProxy {
+			ignData.Ignition.Proxy.NoProxy = append(ignData.Ignition.Proxy.NoProxy, ignV33Types.NoProxyItem(noProxy))
+		}
+	}
+
+	return json.Marshal(ignData)
 }
 
 func (r *AWSMachineReconciler) deleteBootstrapData(machineScope *scope.MachineScope, clusterScope cloud.ClusterScoper, objectStoreScope scope.S3Scope) error {
@@ -772,6 +1270,11 @@ func (r *AWSMachineReconciler) deleteIgnitionBootstrapDataFromS3(machineScope *s
 		return nil
 	}
 
+	// Bootstrap data passed as user data is not stored in S3.
+	if ignition := machineScope.AWSMachine.Spec.Ignition; ignition != nil && ignition.StorageType == infrav1.IgnitionStorageTypeUnencryptedUserData {
+		return nil
+	}
+
 	machineScope.Info("Deleting unneeded entry from AWS S3", "secretPrefix", machineScope.GetSecretPrefix())
 
 	if err := objectStoreSvc.Delete(machineScope); err != nil {
@@ -914,6 +1417,12 @@ func (r *AWSMachineReconciler) requestsForCluster(log logger.Wrapper, namespace,
 }
 
 func (r *AWSMachineReconciler) getInfraCluster(ctx context.Context, log *logger.Logger, cluster *clusterv1.Cluster, awsMachine *infrav1.AWSMachine) (scope.EC2Scope, error) {
+	return getInfraCluster(ctx, r.Client, r.Endpoints, log, cluster, awsMachine.Namespace)
+}
+
+// getInfraCluster returns the scope of the AWSManagedControlPlane or the AWSCluster of the given cluster, or nil
+// if it is not ready yet.
+func getInfraCluster(ctx context.Context, c client.Client, endpoints []scope.ServiceEndpoint, log *logger.Logger, cluster *clusterv1.Cluster, namespace string) (scope.EC2Scope, error) {
 	var clusterScope *scope.ClusterScope
 	var managedControlPlaneScope *scope.ManagedControlPlaneScope
 	var err error
@@ -921,22 +1430,22 @@ func (r *AWSMachineReconciler) getInfraCluster(ctx context.Context, log *logger.
 	if cluster.Spec.ControlPlaneRef != nil && cluster.Spec.ControlPlaneRef.Kind == "AWSManagedControlPlane" {
 		controlPlane := &ekscontrolplanev1.AWSManagedControlPlane{}
 		controlPlaneName := client.ObjectKey{
-			Namespace: awsMachine.Namespace,
+			Namespace: namespace,
 			Name:      cluster.Spec.ControlPlaneRef.Name,
 		}
 
-		if err := r.Get(ctx, controlPlaneName, controlPlane); err != nil {
+		if err := c.Get(ctx, controlPlaneName, controlPlane); err != nil {
 			// AWSManagedControlPlane is not ready
 			return nil, nil //nolint:nilerr
 		}
 
 		managedControlPlaneScope, err = scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
-			Client:         r.Client,
+			Client:         c,
 			Logger:         log,
 			Cluster:        cluster,
 			ControlPlane:   controlPlane,
 			ControllerName: "awsManagedControlPlane",
-			Endpoints:      r.Endpoints,
+			Endpoints:      endpoints,
 		})
 		if err != nil {
 			return nil, err
@@ -948,18 +1457,18 @@ func (r *AWSMachineReconciler) getInfraCluster(ctx context.Context, log *logger.
 	awsCluster := &infrav1.AWSCluster{}
 
 	infraClusterName := client.ObjectKey{
-		Namespace: awsMachine.Namespace,
+		Namespace: namespace,
 		Name:      cluster.Spec.InfrastructureRef.Name,
 	}
 
-	if err := r.Client.Get(ctx, infraClusterName, awsCluster); err != nil {
+	if err := c.Get(ctx, infraClusterName, awsCluster); err != nil {
 		// AWSCluster is not ready
 		return nil, nil //nolint:nilerr
 	}
 
 	// Create the cluster scope
 	clusterScope, err = scope.NewClusterScope(scope.ClusterScopeParams{
-		Client:         r.Client,
+		Client:         c,
 		Logger:         log,
 		Cluster:        cluster,
 		AWSCluster:     awsCluster,
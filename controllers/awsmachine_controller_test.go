@@ -485,7 +485,7 @@ func expectConditions(g *WithT, m *infrav1.AWSMachine, expected []conditionAsser
 
 func mockedCreateSecretCall(s *mock_services.MockSecretInterfaceMockRecorder) {
 	s.Create(gomock.AssignableToTypeOf(&scope.MachineScope{}), gomock.AssignableToTypeOf([]byte{}))
-	s.UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.AssignableToTypeOf([]scope.ServiceEndpoint{}))
+	s.UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.AssignableToTypeOf([]scope.ServiceEndpoint{}))
 }
 
 func mockedCreateInstanceCalls(m *mocks.MockEC2APIMockRecorder) {
@@ -588,6 +588,13 @@ func mockedCreateInstanceCalls(m *mocks.MockEC2APIMockRecorder) {
 		Attribute:          aws.String("groupSet"),
 	})).Return(&ec2.DescribeNetworkInterfaceAttributeOutput{Groups: []*ec2.GroupIdentifier{{GroupId: aws.String("3")}}}, nil).MaxTimes(1)
 	m.ModifyNetworkInterfaceAttribute(gomock.Any()).AnyTimes()
+	m.DescribeInstanceStatus(gomock.Eq(&ec2.DescribeInstanceStatusInput{
+		InstanceIds: aws.StringSlice([]string{"two"}),
+	})).Return(&ec2.DescribeInstanceStatusOutput{}, nil).MaxTimes(1)
+	m.DescribeImages(gomock.Eq(&ec2.DescribeImagesInput{
+		ImageIds:          aws.StringSlice([]string{"ami-1"}),
+		IncludeDeprecated: aws.Bool(true),
+	})).Return(&ec2.DescribeImagesOutput{Images: []*ec2.Image{{ImageId: aws.String("ami-1"), State: aws.String(ec2.ImageStateAvailable)}}}, nil).MaxTimes(1)
 	m.DescribeSubnets(gomock.Eq(&ec2.DescribeSubnetsInput{Filters: []*ec2.Filter{
 		{
 			Name:   aws.String("state"),
@@ -25,6 +25,10 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	ignV30 "github.com/coreos/ignition/v2/config/v3_0"
+	ignV31 "github.com/coreos/ignition/v2/config/v3_1"
+	ignV32 "github.com/coreos/ignition/v2/config/v3_2"
+	ignV33 "github.com/coreos/ignition/v2/config/v3_3"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -32,6 +36,7 @@ import (
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
@@ -42,6 +47,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
@@ -51,6 +57,7 @@ import (
 	"sigs.k8s.io/cluster-api/controllers/noderefutil"
 	capierrors "sigs.k8s.io/cluster-api/errors"
 	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
 const providerID = "aws:////myMachine"
@@ -144,6 +151,9 @@ func TestAWSMachineReconciler(t *testing.T) {
 			scope.MachineScopeParams{
 				Client: client,
 				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test",
+					},
 					Status: clusterv1.ClusterStatus{
 						InfrastructureReady: true,
 					},
@@ -172,6 +182,7 @@ func TestAWSMachineReconciler(t *testing.T) {
 		recorder = record.NewFakeRecorder(2)
 
 		reconciler = AWSMachineReconciler{
+			Client: client,
 			ec2ServiceFactory: func(scope.EC2Scope) services.EC2Interface {
 				return ec2Svc
 			},
@@ -308,7 +319,7 @@ func TestAWSMachineReconciler(t *testing.T) {
 				ec2Svc.EXPECT().InstanceIfExists(gomock.Any()).Return(nil, nil)
 				secretSvc.EXPECT().Create(gomock.Any(), gomock.Any()).Return("test", int32(1), nil).Times(1)
 				ec2Svc.EXPECT().CreateInstance(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, expectedErr)
-				secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+				secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
 
 				_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs, cs)
 				g.Expect(ms.AWSMachine.Finalizers).To(ContainElement(infrav1.MachineFinalizer))
@@ -365,7 +376,7 @@ func TestAWSMachineReconciler(t *testing.T) {
 					instanceCreate(t, g)
 					getInstanceSecurityGroups(t, g)
 
-					secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+					secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
 					_, _ = reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs, cs)
 					g.Expect(ms.AWSMachine.Spec.ProviderID).To(PointTo(Equal(providerID)))
 				})
@@ -378,7 +389,7 @@ func TestAWSMachineReconciler(t *testing.T) {
 					instanceCreate(t, g)
 					getInstanceSecurityGroups(t, g)
 
-					secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+					secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
 					instance.State = infrav1.InstanceStatePending
 					_, _ = reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs, cs)
 					g.Expect(ms.AWSMachine.Status.InstanceState).To(PointTo(Equal(infrav1.InstanceStatePending)))
@@ -397,7 +408,7 @@ func TestAWSMachineReconciler(t *testing.T) {
 					instanceCreate(t, g)
 					getInstanceSecurityGroups(t, g)
 
-					secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+					secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
 					instance.State = infrav1.InstanceStateRunning
 					_, _ = reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs, cs)
 					g.Expect(ms.AWSMachine.Status.InstanceState).To(PointTo(Equal(infrav1.InstanceStateRunning)))
@@ -419,7 +430,7 @@ func TestAWSMachineReconciler(t *testing.T) {
 				klog.SetOutput(buf)
 				instance.State = "NewAWSMachineState"
 				secretSvc.EXPECT().Delete(gomock.Any()).Return(nil).Times(1)
-				secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+				secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
 				secretSvc.EXPECT().Create(gomock.Any(), gomock.Any()).Return("test", int32(1), nil).Times(1)
 				_, _ = reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs, cs)
 				g.Expect(ms.AWSMachine.Status.Ready).To(Equal(false))
@@ -434,7 +445,7 @@ func TestAWSMachineReconciler(t *testing.T) {
 
 					ec2Svc.EXPECT().GetInstanceSecurityGroups(gomock.Any()).
 						Return(map[string][]string{"eid": {}}, nil)
-					secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+					secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
 					ec2Svc.EXPECT().GetCoreSecurityGroups(gomock.Any()).Return([]string{}, nil)
 					secretSvc.EXPECT().Create(gomock.Any(), gomock.Any()).Return("test", int32(1), nil).Times(1)
 				}
@@ -539,7 +550,7 @@ func TestAWSMachineReconciler(t *testing.T) {
 					klog.SetOutput(buf)
 					ec2Svc.EXPECT().GetInstanceSecurityGroups(gomock.Any()).
 						Return(map[string][]string{"eid": {}}, nil).Times(1)
-					secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+					secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
 					ec2Svc.EXPECT().GetCoreSecurityGroups(gomock.Any()).Return([]string{}, nil).Times(1)
 					secretSvc.EXPECT().Create(gomock.Any(), gomock.Any()).Return("test", int32(1), nil).Times(1)
 					ec2Svc.EXPECT().GetAdditionalSecurityGroupsIDs(gomock.Any()).Return(nil, nil)
@@ -586,6 +597,7 @@ func TestAWSMachineReconciler(t *testing.T) {
 					getCoreSecurityGroups(t, g)
 
 					instance.State = infrav1.InstanceStateRunning
+					ec2Svc.EXPECT().ReconcileInstanceStatus(gomock.Any(), gomock.Any()).Return(nil)
 					_, _ = reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs, cs)
 					g.Expect(ms.AWSMachine.Status.InstanceState).To(PointTo(Equal(infrav1.InstanceStateRunning)))
 					g.Expect(ms.AWSMachine.Status.Ready).To(Equal(true))
@@ -601,7 +613,7 @@ func TestAWSMachineReconciler(t *testing.T) {
 					klog.SetOutput(buf)
 					secretSvc.EXPECT().Delete(gomock.Any()).Return(nil).Times(1)
 					secretSvc.EXPECT().Create(gomock.Any(), gomock.Any()).Return("test", int32(1), nil).Times(1)
-					secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+					secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
 				}
 
 				t.Run("should warn if an instance is shutting-down", func(t *testing.T) {
@@ -649,7 +661,7 @@ func TestAWSMachineReconciler(t *testing.T) {
 				}
 
 				elbSvc.EXPECT().IsInstanceRegisteredWithAPIServerELB(gomock.Any()).Return(true, nil)
-				secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+				secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
 				secretSvc.EXPECT().Create(gomock.Any(), gomock.Any()).Return("test", int32(1), nil).Times(1)
 				ec2Svc.EXPECT().GetInstanceSecurityGroups(gomock.Any()).Return(map[string][]string{"eid": {}}, nil).Times(1)
 				ec2Svc.EXPECT().GetCoreSecurityGroups(gomock.Any()).Return([]string{}, nil).Times(1)
@@ -675,7 +687,7 @@ func TestAWSMachineReconciler(t *testing.T) {
 
 				elbSvc.EXPECT().IsInstanceRegisteredWithAPIServerELB(gomock.Any()).Return(false, nil)
 				elbSvc.EXPECT().RegisterInstanceWithAPIServerELB(gomock.Any()).Return(nil)
-				secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+				secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
 				secretSvc.EXPECT().Create(gomock.Any(), gomock.Any()).Return("test", int32(1), nil).Times(1)
 				ec2Svc.EXPECT().GetInstanceSecurityGroups(gomock.Any()).Return(map[string][]string{"eid": {}}, nil).Times(1)
 				ec2Svc.EXPECT().GetCoreSecurityGroups(gomock.Any()).Return([]string{}, nil).Times(1)
@@ -718,7 +730,7 @@ func TestAWSMachineReconciler(t *testing.T) {
 					Name:      "test",
 				}
 
-				secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+				secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
 				secretSvc.EXPECT().Create(gomock.Any(), gomock.Any()).Return("test", int32(1), nil).Times(1)
 				secretSvc.EXPECT().Delete(gomock.Any()).Return(errors.New("failed to delete entries from AWS Secret")).Times(1)
 
@@ -748,7 +760,7 @@ func TestAWSMachineReconciler(t *testing.T) {
 
 				ec2Svc.EXPECT().GetRunningInstanceByTags(gomock.Any()).Return(nil, nil)
 				secretSvc.EXPECT().Create(gomock.Any(), gomock.Any()).Return("test", int32(1), nil).Times(1)
-				secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New(expectedError)).Times(1)
+				secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New(expectedError)).Times(1)
 
 				_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs, cs)
 				g.Expect(err.Error()).To(ContainSubstring(expectedError))
@@ -772,7 +784,7 @@ func TestAWSMachineReconciler(t *testing.T) {
 				ec2Svc.EXPECT().CreateInstance(gomock.Any(), gomock.Any(), gomock.Any()).Return(instance, nil)
 				ec2Svc.EXPECT().GetRunningInstanceByTags(gomock.Any()).Return(nil, nil)
 				elbSvc.EXPECT().IsInstanceRegisteredWithAPIServerELB(gomock.Any()).Return(false, errors.New("error describing ELB"))
-				secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+				secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
 				secretSvc.EXPECT().Create(gomock.Any(), gomock.Any()).Return("test", int32(1), nil).Times(1)
 
 				_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs, cs)
@@ -800,7 +812,7 @@ func TestAWSMachineReconciler(t *testing.T) {
 				elbSvc.EXPECT().IsInstanceRegisteredWithAPIServerELB(gomock.Any()).Return(false, nil)
 				elbSvc.EXPECT().RegisterInstanceWithAPIServerELB(gomock.Any()).Return(errors.New("failed to attach ELB"))
 				secretSvc.EXPECT().Create(gomock.Any(), gomock.Any()).Return("test", int32(1), nil).Times(1)
-				secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+				secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
 
 				_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs, cs)
 				g.Expect(err).ToNot(BeNil())
@@ -828,7 +840,7 @@ func TestAWSMachineReconciler(t *testing.T) {
 					ec2Svc.EXPECT().InstanceIfExists(gomock.Any()).Return(nil, nil)
 					ec2Svc.EXPECT().CreateInstance(gomock.Any(), gomock.Any(), gomock.Any()).Return(instance, nil)
 					secretSvc.EXPECT().Create(gomock.Any(), gomock.Any()).Return("test", int32(1), nil)
-					secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+					secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
 				}
 
 				t.Run("Should fail to return machine annotations after instance is created", func(t *testing.T) {
@@ -874,7 +886,7 @@ func TestAWSMachineReconciler(t *testing.T) {
 					ec2Svc.EXPECT().InstanceIfExists(gomock.Any()).Return(nil, nil)
 					ec2Svc.EXPECT().CreateInstance(gomock.Any(), gomock.Any(), gomock.Any()).Return(instance, nil)
 					secretSvc.EXPECT().Create(gomock.Any(), gomock.Any()).Return("test", int32(1), nil)
-					secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+					secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
 					ec2Svc.EXPECT().GetInstanceSecurityGroups(gomock.Any()).Return(map[string][]string{"eid": {}}, nil)
 				}
 
@@ -999,7 +1011,7 @@ func TestAWSMachineReconciler(t *testing.T) {
 				ec2Svc.EXPECT().GetRunningInstanceByTags(gomock.Any()).Return(nil, nil).AnyTimes()
 				secretSvc.EXPECT().Create(gomock.Any(), gomock.Any()).Return(secretPrefix, int32(1), nil).Times(1)
 				ec2Svc.EXPECT().CreateInstance(gomock.Any(), gomock.Any(), gomock.Any()).Return(instance, nil).AnyTimes()
-				secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+				secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
 				ec2Svc.EXPECT().GetInstanceSecurityGroups(gomock.Any()).Return(map[string][]string{"eid": {}}, nil).Times(1)
 				ec2Svc.EXPECT().GetCoreSecurityGroups(gomock.Any()).Return([]string{}, nil).Times(1)
 				ec2Svc.EXPECT().GetAdditionalSecurityGroupsIDs(gomock.Any()).Return(nil, nil)
@@ -1042,6 +1054,7 @@ func TestAWSMachineReconciler(t *testing.T) {
 				setNodeRef(t, g)
 
 				instance.State = infrav1.InstanceStateRunning
+				ec2Svc.EXPECT().ReconcileInstanceStatus(gomock.Any(), gomock.Any()).Return(nil)
 				ec2Svc.EXPECT().GetInstanceSecurityGroups(gomock.Any()).
 					Return(map[string][]string{"eid": {}}, nil).Times(1)
 				secretSvc.EXPECT().Delete(gomock.Any()).Return(nil).Times(1)
@@ -1128,6 +1141,7 @@ func TestAWSMachineReconciler(t *testing.T) {
 				setSSM(t, g)
 
 				instance.State = infrav1.InstanceStateRunning
+				ec2Svc.EXPECT().ReconcileInstanceStatus(gomock.Any(), gomock.Any()).Return(nil)
 				ec2Svc.EXPECT().GetInstanceSecurityGroups(gomock.Any()).
 					Return(map[string][]string{"eid": {}}, nil).Times(1)
 				ec2Svc.EXPECT().GetCoreSecurityGroups(gomock.Any()).Return([]string{}, nil).Times(1)
@@ -1211,7 +1225,7 @@ func TestAWSMachineReconciler(t *testing.T) {
 				instance.State = infrav1.InstanceStatePending
 				secretSvc.EXPECT().Create(gomock.Any(), gomock.Any()).Return(secretPrefix, int32(1), nil).Times(1)
 				ec2Svc.EXPECT().CreateInstance(gomock.Any(), gomock.Any(), gomock.Any()).Return(instance, nil).AnyTimes()
-				secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+				secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
 				ec2Svc.EXPECT().GetInstanceSecurityGroups(gomock.Any()).Return(map[string][]string{"eid": {}}, nil).Times(1)
 				ec2Svc.EXPECT().GetCoreSecurityGroups(gomock.Any()).Return([]string{}, nil).Times(1)
 				ec2Svc.EXPECT().GetAdditionalSecurityGroupsIDs(gomock.Any()).Return(nil, nil)
@@ -1306,6 +1320,7 @@ func TestAWSMachineReconciler(t *testing.T) {
 				useIgnition(t, g)
 
 				instance.State = infrav1.InstanceStateRunning
+				ec2Svc.EXPECT().ReconcileInstanceStatus(gomock.Any(), gomock.Any()).Return(nil)
 				ec2Svc.EXPECT().GetInstanceSecurityGroups(gomock.Any()).Return(map[string][]string{"eid": {}}, nil).Times(1)
 				objectStoreSvc.EXPECT().Delete(gomock.Any()).Return(nil).Times(1)
 				ec2Svc.EXPECT().GetCoreSecurityGroups(gomock.Any()).Return([]string{}, nil).Times(1)
@@ -1389,6 +1404,7 @@ func TestAWSMachineReconciler(t *testing.T) {
 				getInstances(t, g)
 
 				instance.State = infrav1.InstanceStateRunning
+				ec2Svc.EXPECT().ReconcileInstanceStatus(gomock.Any(), gomock.Any()).Return(nil)
 				ec2Svc.EXPECT().GetInstanceSecurityGroups(gomock.Any()).Return(map[string][]string{"eid": {}}, nil).Times(1)
 				ec2Svc.EXPECT().GetCoreSecurityGroups(gomock.Any()).Return([]string{}, nil).Times(1)
 				ec2Svc.EXPECT().GetAdditionalSecurityGroupsIDs(gomock.Any()).Return(nil, nil)
@@ -2368,8 +2384,421 @@ func createObject(g *WithT, obj client.Object, namespace string) {
 	}
 }
 
+func TestIgnitionStubUserData(t *testing.T) {
+	const objectURL = "s3://bucket/node/machine"
+
+	tls := &infrav1.IgnitionTLS{
+		CASources: []string{"data:,ca"},
+	}
+	proxy := &infrav1.IgnitionProxy{
+		HTTPSProxy: aws.String("http://proxy:3128"),
+		NoProxy:    []string{"169.254.169.254"},
+	}
+
+	tests := []struct {
+		name     string
+		ignition *infrav1.Ignition
+		want     string
+		// parse parses the stub with the parser of its Ignition version, and returns its version and the sources
+		// of the configuration it merges and of its certificate authority.
+		parse func([]byte) (string, []string, error)
+	}{
+		{
+			name:     "defaults to an Ignition v2 stub",
+			ignition: nil,
+			want:     `{"ignition":{"config":{"append":[{"source":"s3://bucket/node/machine","verification":{}}]},"security":{"tls":{}},"timeouts":{},"version":"2.3.0"},"networkd":{},"passwd":{},"storage":{},"systemd":{}}`,
+		},
+		{
+			name: "generates an Ignition v3.0 stub with TLS settings",
+			ignition: &infrav1.Ignition{
+				Version: "3.0",
+				TLS:     tls,
+			},
+			want: `{"ignition":{"config":{"merge":[{"source":"s3://bucket/node/machine","verification":{}}],"replace":{"source":null,"verification":{}}},` +
+				`"security":{"tls":{"certificateAuthorities":[{"source":"data:,ca","verification":{}}]}},"timeouts":{},"version":"3.0.0"},` +
+				`"passwd":{},"storage":{},"systemd":{}}`,
+			parse: func(data []byte) (string, []string, error) {
+				cfg, _, err := ignV30.Parse(data)
+				return cfg.Ignition.Version, []string{aws.StringValue(cfg.Ignition.Config.Merge[0].Source), cfg.Ignition.Security.TLS.CertificateAuthorities[0].Source}, err
+			},
+		},
+		{
+			name: "generates an Ignition v3.1 stub with TLS and proxy settings",
+			ignition: &infrav1.Ignition{
+				Version: "3.1",
+				TLS:     tls,
+				Proxy:   proxy,
+			},
+			want: `{"ignition":{"config":{"merge":[{"source":"s3://bucket/node/machine","verification":{}}],"replace":{"verification":{}}},` +
+				`"proxy":{"httpsProxy":"http://proxy:3128","noProxy":["169.254.169.254"]},` +
+				`"security":{"tls":{"certificateAuthorities":[{"source":"data:,ca","verification":{}}]}},"timeouts":{},"version":"3.1.0"},` +
+				`"passwd":{},"storage":{},"systemd":{}}`,
+			parse: func(data []byte) (string, []string, error) {
+				cfg, _, err := ignV31.Parse(data)
+				return cfg.Ignition.Version, []string{aws.StringValue(cfg.Ignition.Config.Merge[0].Source), aws.StringValue(cfg.Ignition.Security.TLS.CertificateAuthorities[0].Source)}, err
+			},
+		},
+		{
+			name: "generates an Ignition v3.2 stub with TLS and proxy settings",
+			ignition: &infrav1.Ignition{
+				Version: "3.2",
+				TLS:     tls,
+				Proxy:   proxy,
+			},
+			want: `{"ignition":{"config":{"merge":[{"source":"s3://bucket/node/machine","verification":{}}],"replace":{"verification":{}}},` +
+				`"proxy":{"httpsProxy":"http://proxy:3128","noProxy":["169.254.169.254"]},` +
+				`"security":{"tls":{"certificateAuthorities":[{"source":"data:,ca","verification":{}}]}},"timeouts":{},"version":"3.2.0"},` +
+				`"passwd":{},"storage":{},"systemd":{}}`,
+			parse: func(data []byte) (string, []string, error) {
+				cfg, _, err := ignV32.Parse(data)
+				return cfg.Ignition.Version, []string{aws.StringValue(cfg.Ignition.Config.Merge[0].Source), aws.StringValue(cfg.Ignition.Security.TLS.CertificateAuthorities[0].Source)}, err
+			},
+		},
+		{
+			name: "generates an Ignition v3.3 stub with TLS and proxy settings",
+			ignition: &infrav1.Ignition{
+				Version: "3.3",
+				TLS:     tls,
+				Proxy:   proxy,
+			},
+			want: `{"ignition":{"config":{"merge":[{"source":"s3://bucket/node/machine","verification":{}}],"replace":{"verification":{}}},` +
+				`"proxy":{"httpsProxy":"http://proxy:3128","noProxy":["169.254.169.254"]},` +
+				`"security":{"tls":{"certificateAuthorities":[{"source":"data:,ca","verification":{}}]}},"timeouts":{},"version":"3.3.0"},` +
+				`"kernelArguments":{},"passwd":{},"storage":{},"systemd":{}}`,
+			parse: func(data []byte) (string, []string, error) {
+				cfg, _, err := ignV33.Parse(data)
+				return cfg.Ignition.Version, []string{aws.StringValue(cfg.Ignition.Config.Merge[0].Source), aws.StringValue(cfg.Ignition.Security.TLS.CertificateAuthorities[0].Source)}, err
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			userData, err := ignitionStubUserData(tc.ignition, objectURL)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(string(userData)).To(MatchJSON(tc.want))
+
+			if tc.parse != nil {
+				version, sources, err := tc.parse(userData)
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(version).To(Equal(tc.ignition.Version + ".0"))
+				g.Expect(sources).To(Equal([]string{objectURL, "data:,ca"}))
+			}
+		})
+	}
+}
+
 func cleanupObject(g *WithT, obj client.Object) {
 	if obj.DeepCopyObject() != nil {
 		g.Expect(testEnv.Cleanup(ctx, obj)).To(Succeed())
 	}
 }
+
+func TestAWSMachineReconciler_sweepOrphanedSecrets(t *testing.T) {
+	newAWSMachine := func(name, clusterName string, cloudInit infrav1.CloudInit) *infrav1.AWSMachine {
+		return &infrav1.AWSMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels:    map[string]string{clusterv1.ClusterLabelName: clusterName},
+			},
+			Spec: infrav1.AWSMachineSpec{CloudInit: cloudInit},
+		}
+	}
+
+	testCases := []struct {
+		name        string
+		managed     bool
+		awsMachines []client.Object
+		expectSM    []string
+		expectSSM   []string
+	}{
+		{
+			name: "should only sweep Secrets Manager when it is the only backend used by the cluster",
+			awsMachines: []client.Object{
+				newAWSMachine("machine-1", "cluster", infrav1.CloudInit{SecretPrefix: "prefix-1"}),
+				newAWSMachine("machine-2", "other-cluster", infrav1.CloudInit{SecretPrefix: "prefix-2", SecureSecretsBackend: infrav1.SecretBackendSSMParameterStore}),
+			},
+			expectSM: []string{"prefix-1", "prefix-2"},
+		},
+		{
+			name: "should sweep both backends when both are used by the cluster",
+			awsMachines: []client.Object{
+				newAWSMachine("machine-1", "cluster", infrav1.CloudInit{SecretPrefix: "prefix-1", SecureSecretsBackend: infrav1.SecretBackendSecretsManager}),
+				newAWSMachine("machine-2", "cluster", infrav1.CloudInit{SecretPrefix: "prefix-2", SecureSecretsBackend: infrav1.SecretBackendSSMParameterStore}),
+			},
+			expectSM:  []string{"prefix-1", "prefix-2"},
+			expectSSM: []string{"prefix-1", "prefix-2"},
+		},
+		{
+			name:    "should sweep the backends used by an AWSManagedControlPlane cluster",
+			managed: true,
+			awsMachines: []client.Object{
+				newAWSMachine("machine-1", "cluster", infrav1.CloudInit{SecretPrefix: "prefix-1", SecureSecretsBackend: infrav1.SecretBackendSSMParameterStore}),
+			},
+			expectSSM: []string{"prefix-1"},
+		},
+		{
+			name: "should not sweep when the machines of the cluster don't use a secret backend",
+			awsMachines: []client.Object{
+				newAWSMachine("machine-1", "cluster", infrav1.CloudInit{InsecureSkipSecretsManager: true}),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			testScheme := runtime.NewScheme()
+			g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+			g.Expect(ekscontrolplanev1.AddToScheme(testScheme)).To(Succeed())
+			g.Expect(clusterv1.AddToScheme(testScheme)).To(Succeed())
+			c := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(tc.awsMachines...).Build()
+
+			cluster := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "default"}}
+			var clusterScope cloud.ClusterScoper
+			if tc.managed {
+				managedScope, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+					Client:       c,
+					Cluster:      cluster,
+					ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{ObjectMeta: metav1.ObjectMeta{Name: "sweep", Namespace: "default"}},
+				})
+				g.Expect(err).NotTo(HaveOccurred())
+				clusterScope = managedScope
+			} else {
+				awsClusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+					Client:     c,
+					Cluster:    cluster,
+					AWSCluster: &infrav1.AWSCluster{ObjectMeta: metav1.ObjectMeta{Name: "sweep", Namespace: "default"}},
+				})
+				g.Expect(err).NotTo(HaveOccurred())
+				clusterScope = awsClusterScope
+			}
+			machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+				Client:       c,
+				Cluster:      cluster,
+				Machine:      &clusterv1.Machine{},
+				InfraCluster: clusterScope.(scope.EC2Scope),
+				AWSMachine:   &infrav1.AWSMachine{},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			secretsManagerSvc := mock_services.NewMockSecretInterface(mockCtrl)
+			ssmSvc := mock_services.NewMockSecretInterface(mockCtrl)
+			if tc.expectSM != nil {
+				secretsManagerSvc.EXPECT().DeleteOrphanedSecrets(gomock.InAnyOrder(tc.expectSM), gomock.Any()).Return(nil, nil)
+			}
+			if tc.expectSSM != nil {
+				ssmSvc.EXPECT().DeleteOrphanedSecrets(gomock.InAnyOrder(tc.expectSSM), gomock.Any()).Return(nil, nil)
+			}
+
+			reconciler := &AWSMachineReconciler{
+				Client: c,
+				secretsManagerServiceFactory: func(cloud.ClusterScoper) services.SecretInterface {
+					return secretsManagerSvc
+				},
+				SSMServiceFactory: func(cloud.ClusterScoper) services.SecretInterface {
+					return ssmSvc
+				},
+			}
+
+			// The second sweep is skipped, as the cluster was swept recently.
+			for i := 0; i < 2; i++ {
+				g.Expect(reconciler.sweepOrphanedSecrets(machineScope, clusterScope)).To(Succeed())
+			}
+		})
+	}
+}
+
+func TestAWSMachineReconciler_reconcileVolumes(t *testing.T) {
+	spec := infrav1.AWSMachineSpec{RootVolume: &infrav1.Volume{Size: 20}}
+	lastApplied := `{"nonRootVolumes":null,"rootVolume":{"size":20}}`
+	upToDate := clusterv1.Conditions{{Type: infrav1.VolumesUpToDateCondition, Status: corev1.ConditionTrue}}
+
+	testCases := []struct {
+		name             string
+		awsMachine       *infrav1.AWSMachine
+		expect           func(m *mock_services.MockEC2InterfaceMockRecorder)
+		expectAnnotation string
+	}{
+		{
+			name:       "should reconcile volumes that weren't applied yet",
+			awsMachine: &infrav1.AWSMachine{Spec: spec},
+			expect: func(m *mock_services.MockEC2InterfaceMockRecorder) {
+				m.ReconcileVolumes(gomock.Any(), spec.RootVolume, gomock.Any()).Return(false, nil)
+			},
+			expectAnnotation: lastApplied,
+		},
+		{
+			name: "should not describe the volumes when the spec matches the last applied volumes",
+			awsMachine: &infrav1.AWSMachine{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{VolumesLastAppliedAnnotation: lastApplied}},
+				Spec:       spec,
+				Status:     infrav1.AWSMachineStatus{Conditions: upToDate},
+			},
+			expectAnnotation: lastApplied,
+		},
+		{
+			name: "should reconcile volumes when the spec differs from the last applied volumes",
+			awsMachine: &infrav1.AWSMachine{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{VolumesLastAppliedAnnotation: `{"nonRootVolumes":null,"rootVolume":{"size":10}}`}},
+				Spec:       spec,
+				Status:     infrav1.AWSMachineStatus{Conditions: upToDate},
+			},
+			expect: func(m *mock_services.MockEC2InterfaceMockRecorder) {
+				m.ReconcileVolumes(gomock.Any(), spec.RootVolume, gomock.Any()).Return(false, nil)
+			},
+			expectAnnotation: lastApplied,
+		},
+		{
+			name: "should keep reconciling volumes while a modification is in progress",
+			awsMachine: &infrav1.AWSMachine{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{VolumesLastAppliedAnnotation: `{"nonRootVolumes":null,"rootVolume":{"size":10}}`}},
+				Spec:       spec,
+			},
+			expect: func(m *mock_services.MockEC2InterfaceMockRecorder) {
+				m.ReconcileVolumes(gomock.Any(), spec.RootVolume, gomock.Any()).Return(true, nil)
+			},
+			expectAnnotation: `{"nonRootVolumes":null,"rootVolume":{"size":10}}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			ec2Svc := mock_services.NewMockEC2Interface(mockCtrl)
+			if tc.expect != nil {
+				tc.expect(ec2Svc.EXPECT())
+			}
+
+			reconciler := &AWSMachineReconciler{}
+			machineScope := &scope.MachineScope{AWSMachine: tc.awsMachine}
+			instance := &infrav1.Instance{ID: "i-1", State: infrav1.InstanceStateRunning}
+
+			g.Expect(reconciler.reconcileVolumes(ec2Svc, machineScope, instance)).To(Succeed())
+			g.Expect(tc.awsMachine.Annotations[VolumesLastAppliedAnnotation]).To(Equal(tc.expectAnnotation))
+		})
+	}
+}
+
+func TestAWSMachineReconciler_launchTemplateSecurityGroups(t *testing.T) {
+	launchTemplate := &infrav1.LaunchTemplateReference{Name: aws.String("hardened")}
+
+	t.Run("should record the security groups of the launch template at launch", func(t *testing.T) {
+		g := NewWithT(t)
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ec2Svc := mock_services.NewMockEC2Interface(mockCtrl)
+		ec2Svc.EXPECT().GetCoreSecurityGroups(gomock.Any()).Return([]string{"sg-core"}, nil)
+		ec2Svc.EXPECT().GetAdditionalSecurityGroupsIDs(gomock.Any()).Return([]string{"sg-additional"}, nil)
+
+		reconciler := &AWSMachineReconciler{}
+		machineScope := &scope.MachineScope{AWSMachine: &infrav1.AWSMachine{Spec: infrav1.AWSMachineSpec{LaunchTemplate: launchTemplate}}}
+		instance := &infrav1.Instance{ID: "i-1", SecurityGroupIDs: []string{"sg-core", "sg-additional", "sg-launch-template"}}
+
+		g.Expect(reconciler.recordLaunchTemplateSecurityGroups(ec2Svc, machineScope, instance)).To(Succeed())
+		g.Expect(machineScope.AWSMachine.Annotations[LaunchTemplateSecurityGroupsAnnotation]).To(Equal(`{"sg-launch-template":{}}`))
+	})
+
+	t.Run("should not record security groups for machines launched without a launch template", func(t *testing.T) {
+		g := NewWithT(t)
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ec2Svc := mock_services.NewMockEC2Interface(mockCtrl)
+
+		reconciler := &AWSMachineReconciler{}
+		machineScope := &scope.MachineScope{AWSMachine: &infrav1.AWSMachine{}}
+		instance := &infrav1.Instance{ID: "i-1", SecurityGroupIDs: []string{"sg-core"}}
+
+		g.Expect(reconciler.recordLaunchTemplateSecurityGroups(ec2Svc, machineScope, instance)).To(Succeed())
+		g.Expect(machineScope.AWSMachine.Annotations).NotTo(HaveKey(LaunchTemplateSecurityGroupsAnnotation))
+	})
+
+	t.Run("should keep the security groups of the launch template when ensuring security groups", func(t *testing.T) {
+		g := NewWithT(t)
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ec2Svc := mock_services.NewMockEC2Interface(mockCtrl)
+		ec2Svc.EXPECT().GetCoreSecurityGroups(gomock.Any()).Return([]string{"sg-core"}, nil)
+		ec2Svc.EXPECT().GetAdditionalSecurityGroupsIDs(gomock.Any()).Return(nil, nil)
+		ec2Svc.EXPECT().UpdateInstanceSecurityGroups(gomock.Any(), gomock.Any()).Times(0)
+
+		reconciler := &AWSMachineReconciler{}
+		machineScope := &scope.MachineScope{AWSMachine: &infrav1.AWSMachine{
+			ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{LaunchTemplateSecurityGroupsAnnotation: `{"sg-launch-template":{}}`}},
+			Spec:       infrav1.AWSMachineSpec{LaunchTemplate: launchTemplate},
+		}}
+		existing := map[string][]string{"eni-1": {"sg-launch-template", "sg-core"}}
+
+		changed, err := reconciler.ensureSecurityGroups(ec2Svc, machineScope, nil, existing)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(changed).To(BeFalse())
+	})
+}
+
+func TestAWSMachineReconciler_remediateImpairedInstance(t *testing.T) {
+	impaired := func() *infrav1.AWSMachine {
+		awsMachine := &infrav1.AWSMachine{Spec: infrav1.AWSMachineSpec{InstanceID: aws.String("i-1")}}
+		conditions.MarkTrue(awsMachine, infrav1.InstanceStatusCheckPassedCondition)
+		conditions.MarkFalse(awsMachine, infrav1.SystemStatusCheckPassedCondition, infrav1.SystemStatusCheckFailedReason, clusterv1.ConditionSeverityError, "failed checks: reachability")
+		return awsMachine
+	}
+
+	tests := []struct {
+		name            string
+		awsMachine      *infrav1.AWSMachine
+		annotations     map[string]string
+		wantRemediation bool
+	}{
+		{
+			name:            "should flag the machine of an impaired instance for remediation",
+			awsMachine:      impaired(),
+			wantRemediation: true,
+		},
+		{
+			name: "should not flag the machine of an instance passing its status checks",
+			awsMachine: func() *infrav1.AWSMachine {
+				awsMachine := &infrav1.AWSMachine{Spec: infrav1.AWSMachineSpec{InstanceID: aws.String("i-1")}}
+				conditions.MarkTrue(awsMachine, infrav1.InstanceStatusCheckPassedCondition)
+				return awsMachine
+			}(),
+		},
+		{
+			name:        "should not flag a machine that skips remediation",
+			awsMachine:  impaired(),
+			annotations: map[string]string{clusterv1.MachineSkipRemediationAnnotation: ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			testScheme := runtime.NewScheme()
+			g.Expect(clusterv1.AddToScheme(testScheme)).To(Succeed())
+			machine := &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Name: "machine", Namespace: "default", Annotations: tt.annotations}}
+			c := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(machine.DeepCopy()).Build()
+
+			reconciler := &AWSMachineReconciler{Client: c, Recorder: record.NewFakeRecorder(10)}
+			machineScope := &scope.MachineScope{AWSMachine: tt.awsMachine, Machine: machine}
+
+			g.Expect(reconciler.remediateImpairedInstance(machineScope)).To(Succeed())
+
+			got := &clusterv1.Machine{}
+			g.Expect(c.Get(context.TODO(), client.ObjectKeyFromObject(machine), got)).To(Succeed())
+			if tt.wantRemediation {
+				g.Expect(got.Annotations).To(HaveKey(clusterv1.DeleteMachineAnnotation))
+				g.Expect(conditions.IsFalse(got, clusterv1.MachineOwnerRemediatedCondition)).To(BeTrue())
+				g.Expect(conditions.GetMessage(got, clusterv1.MachineOwnerRemediatedCondition)).To(ContainSubstring("failed checks: reachability"))
+			} else {
+				g.Expect(got.Annotations).NotTo(HaveKey(clusterv1.DeleteMachineAnnotation))
+				g.Expect(conditions.Has(got, clusterv1.MachineOwnerRemediatedCondition)).To(BeFalse())
+			}
+		})
+	}
+}
@@ -32,8 +32,52 @@ const (
 	// See https://kubernetes.io/docs/concepts/overview/working-with-objects/annotations/
 	// for annotation formatting rules.
 	SecurityGroupsLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-aws/v2-last-applied-security-groups"
+
+	// LaunchTemplateSecurityGroupsAnnotation is the key for the machine object
+	// annotation which tracks the SecurityGroups that the instance was launched
+	// with from its launch template. These are kept alongside the core and the
+	// additional SecurityGroups of the machine.
+	LaunchTemplateSecurityGroupsAnnotation = "sigs.k8s.io/cluster-api-provider-aws/v2-launch-template-security-groups"
 )
 
+// recordLaunchTemplateSecurityGroups records the security groups that the instance was launched with from its
+// launch template, which are the ones that are neither core nor additional security groups of the machine.
+func (r *AWSMachineReconciler) recordLaunchTemplateSecurityGroups(ec2svc service.EC2Interface, scope *scope.MachineScope, instance *infrav1.Instance) error {
+	if scope.AWSMachine.Spec.LaunchTemplate == nil {
+		return nil
+	}
+
+	core, err := ec2svc.GetCoreSecurityGroups(scope)
+	if err != nil {
+		return err
+	}
+
+	additionalSecurityGroupsIDs, err := ec2svc.GetAdditionalSecurityGroupsIDs(scope.AWSMachine.Spec.AdditionalSecurityGroups)
+	if err != nil {
+		return err
+	}
+
+	managed := map[string]bool{}
+	for _, id := range append(core, additionalSecurityGroupsIDs...) {
+		managed[id] = true
+	}
+
+	annotation := map[string]interface{}{}
+	for _, id := range instance.SecurityGroupIDs {
+		if !managed[id] {
+			annotation[id] = struct{}{}
+		}
+	}
+	if len(annotation) == 0 {
+		return nil
+	}
+
+	if scope.AWSMachine.Annotations == nil {
+		scope.AWSMachine.Annotations = map[string]string{}
+	}
+	return r.updateMachineAnnotationJSON(scope.AWSMachine, LaunchTemplateSecurityGroupsAnnotation, annotation)
+}
+
 // Ensures that the security groups of the machine are correct
 // Returns bool, error
 // Bool indicates if changes were made or not, allowing the caller to decide
@@ -44,11 +88,21 @@ func (r *AWSMachineReconciler) ensureSecurityGroups(ec2svc service.EC2Interface,
 		return false, err
 	}
 
+	launchTemplateAnnotation, err := r.machineAnnotationJSON(scope.AWSMachine, LaunchTemplateSecurityGroupsAnnotation)
+	if err != nil {
+		return false, err
+	}
+
 	core, err := ec2svc.GetCoreSecurityGroups(scope)
 	if err != nil {
 		return false, err
 	}
 
+	// The security groups the instance was launched with from its launch template are kept as well.
+	for groupID := range launchTemplateAnnotation {
+		core = append(core, groupID)
+	}
+
 	additionalSecurityGroupsIDs, err := ec2svc.GetAdditionalSecurityGroupsIDs(additional)
 	if err != nil {
 		return false, err
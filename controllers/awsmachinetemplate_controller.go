@@ -0,0 +1,158 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ec2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/predicates"
+)
+
+// amiDeprecationRequeueAfter is how often the AMI of an AWSMachineTemplate is checked for deprecation.
+const amiDeprecationRequeueAfter = time.Hour
+
+// AWSMachineTemplateReconciler reports whether the AMI of an AWSMachineTemplate is deprecated or no longer
+// available. It is the only writer of the AMIDeprecated condition of the template, which it checks periodically
+// rather than on every reconciliation of the machines cloned from it.
+type AWSMachineTemplateReconciler struct {
+	client.Client
+	ec2ServiceFactory func(scope.EC2Scope) services.EC2Interface
+	Endpoints         []scope.ServiceEndpoint
+	WatchFilterValue  string
+}
+
+func (r *AWSMachineTemplateReconciler) getEC2Service(scope scope.EC2Scope) services.EC2Interface {
+	if r.ec2ServiceFactory != nil {
+		return r.ec2ServiceFactory(scope)
+	}
+
+	return ec2.NewService(scope)
+}
+
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachinetemplates,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachines,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status,verbs=get;list;watch
+
+func (r *AWSMachineTemplateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logger.FromContext(ctx)
+
+	template := &infrav1.AWSMachineTemplate{}
+	if err := r.Get(ctx, req.NamespacedName, template); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	imageID := aws.StringValue(template.Spec.Template.Spec.AMI.ID)
+	if imageID == "" && template.Status.ResolvedAMI != nil {
+		imageID = template.Status.ResolvedAMI.ID
+	}
+	if imageID == "" {
+		return ctrl.Result{}, nil
+	}
+
+	cluster, err := r.getCluster(ctx, template)
+	if err != nil {
+		log.Info("AWSMachineTemplate cluster does not exist", "error", err.Error())
+		return ctrl.Result{}, nil
+	}
+	if cluster == nil {
+		log.Info("AWSMachineTemplate is not used by any cluster yet")
+		return ctrl.Result{}, nil
+	}
+
+	if annotations.IsPaused(cluster, template) {
+		log.Info("AWSMachineTemplate or linked Cluster is marked as paused. Won't reconcile")
+		return ctrl.Result{}, nil
+	}
+
+	log = log.WithValues("cluster", klog.KObj(cluster))
+
+	infraCluster, err := getInfraCluster(ctx, r.Client, r.Endpoints, log, cluster, template.Namespace)
+	if err != nil {
+		return ctrl.Result{}, errors.New("error getting infra provider cluster or control plane object")
+	}
+	if infraCluster == nil {
+		log.Info("AWSCluster or AWSManagedControlPlane is not ready yet")
+		return ctrl.Result{}, nil
+	}
+
+	// AWSMachineTemplate has no status subresource, so its status is patched with the rest of the object.
+	templatePatch := client.MergeFrom(template.DeepCopy())
+	if err := r.getEC2Service(infraCluster).ReconcileAMIDeprecation(template, imageID); err != nil {
+		log.Error(err, "failed to check AMI deprecation")
+	}
+	if err := r.Client.Patch(ctx, template, templatePatch); err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to patch AWSMachineTemplate %q", template.Name)
+	}
+
+	return ctrl.Result{RequeueAfter: amiDeprecationRequeueAfter}, nil
+}
+
+// getCluster returns the cluster the AWSMachineTemplate belongs to, either from its cluster label, which is set
+// on the templates of a ClusterClass topology, or from the AWSMachines cloned from it. It returns nil if neither
+// identifies a cluster.
+func (r *AWSMachineTemplateReconciler) getCluster(ctx context.Context, template *infrav1.AWSMachineTemplate) (*clusterv1.Cluster, error) {
+	if template.Labels[clusterv1.ClusterLabelName] != "" {
+		return util.GetClusterFromMetadata(ctx, r.Client, template.ObjectMeta)
+	}
+
+	awsMachines := &infrav1.AWSMachineList{}
+	if err := r.List(ctx, awsMachines, client.InNamespace(template.Namespace)); err != nil {
+		return nil, errors.Wrap(err, "failed to list AWSMachines")
+	}
+
+	groupKind := infrav1.GroupVersion.WithKind("AWSMachineTemplate").GroupKind().String()
+	for _, awsMachine := range awsMachines.Items {
+		if awsMachine.Annotations[clusterv1.TemplateClonedFromNameAnnotation] != template.Name ||
+			awsMachine.Annotations[clusterv1.TemplateClonedFromGroupKindAnnotation] != groupKind ||
+			awsMachine.Labels[clusterv1.ClusterLabelName] == "" {
+			continue
+		}
+		return util.GetClusterFromMetadata(ctx, r.Client, awsMachine.ObjectMeta)
+	}
+
+	return nil, nil
+}
+
+func (r *AWSMachineTemplateReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	log := logger.FromContext(ctx)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(options).
+		For(&infrav1.AWSMachineTemplate{}).
+		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(log.GetLogger(), r.WatchFilterValue)).
+		Complete(r)
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/mock_services"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestAWSMachineTemplateReconciler_Reconcile(t *testing.T) {
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default"},
+		Spec: clusterv1.ClusterSpec{
+			InfrastructureRef: &corev1.ObjectReference{Name: "test-aws-cluster"},
+		},
+	}
+	awsCluster := &infrav1.AWSCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-aws-cluster", Namespace: "default"},
+		Spec:       infrav1.AWSClusterSpec{Region: "us-east-1"},
+	}
+	deprecated := func(obj conditions.Setter, imageID string) error {
+		conditions.Set(obj, &clusterv1.Condition{
+			Type:     infrav1.AMIDeprecatedCondition,
+			Status:   corev1.ConditionTrue,
+			Severity: clusterv1.ConditionSeverityWarning,
+			Reason:   infrav1.AMIDeprecatedReason,
+		})
+		return nil
+	}
+
+	testCases := []struct {
+		name            string
+		template        *infrav1.AWSMachineTemplate
+		awsMachines     []client.Object
+		expect          func(m *mock_services.MockEC2InterfaceMockRecorder)
+		expectRequeue   bool
+		expectCondition *clusterv1.Condition
+	}{
+		{
+			name: "should mark the template of a cluster when its AMI is deprecated",
+			template: &infrav1.AWSMachineTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "template",
+					Namespace: "default",
+					Labels:    map[string]string{clusterv1.ClusterLabelName: "test-cluster"},
+				},
+				Spec: infrav1.AWSMachineTemplateSpec{Template: infrav1.AWSMachineTemplateResource{Spec: infrav1.AWSMachineSpec{
+					AMI: infrav1.AMIReference{ID: aws.String("ami-1")},
+				}}},
+			},
+			expect: func(m *mock_services.MockEC2InterfaceMockRecorder) {
+				m.ReconcileAMIDeprecation(gomock.Any(), "ami-1").DoAndReturn(deprecated)
+			},
+			expectRequeue:   true,
+			expectCondition: &clusterv1.Condition{Type: infrav1.AMIDeprecatedCondition, Status: corev1.ConditionTrue, Reason: infrav1.AMIDeprecatedReason},
+		},
+		{
+			name: "should find the cluster of the template from the machines cloned from it",
+			template: &infrav1.AWSMachineTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "template", Namespace: "default"},
+				Status: infrav1.AWSMachineTemplateStatus{
+					ResolvedAMI: &infrav1.ResolvedAMI{ID: "ami-2"},
+				},
+			},
+			awsMachines: []client.Object{
+				&infrav1.AWSMachine{ObjectMeta: metav1.ObjectMeta{
+					Name:      "other",
+					Namespace: "default",
+					Labels:    map[string]string{clusterv1.ClusterLabelName: "test-cluster"},
+					Annotations: map[string]string{
+						clusterv1.TemplateClonedFromNameAnnotation:      "other-template",
+						clusterv1.TemplateClonedFromGroupKindAnnotation: "AWSMachineTemplate.infrastructure.cluster.x-k8s.io",
+					},
+				}},
+				&infrav1.AWSMachine{ObjectMeta: metav1.ObjectMeta{
+					Name:      "machine",
+					Namespace: "default",
+					Labels:    map[string]string{clusterv1.ClusterLabelName: "test-cluster"},
+					Annotations: map[string]string{
+						clusterv1.TemplateClonedFromNameAnnotation:      "template",
+						clusterv1.TemplateClonedFromGroupKindAnnotation: "AWSMachineTemplate.infrastructure.cluster.x-k8s.io",
+					},
+				}},
+			},
+			expect: func(m *mock_services.MockEC2InterfaceMockRecorder) {
+				m.ReconcileAMIDeprecation(gomock.Any(), "ami-2").DoAndReturn(deprecated)
+			},
+			expectRequeue:   true,
+			expectCondition: &clusterv1.Condition{Type: infrav1.AMIDeprecatedCondition, Status: corev1.ConditionTrue, Reason: infrav1.AMIDeprecatedReason},
+		},
+		{
+			name: "should not fail when the AMI can't be checked",
+			template: &infrav1.AWSMachineTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "template",
+					Namespace: "default",
+					Labels:    map[string]string{clusterv1.ClusterLabelName: "test-cluster"},
+				},
+				Spec: infrav1.AWSMachineTemplateSpec{Template: infrav1.AWSMachineTemplateResource{Spec: infrav1.AWSMachineSpec{
+					AMI: infrav1.AMIReference{ID: aws.String("ami-1")},
+				}}},
+			},
+			expect: func(m *mock_services.MockEC2InterfaceMockRecorder) {
+				m.ReconcileAMIDeprecation(gomock.Any(), "ami-1").DoAndReturn(func(obj conditions.Setter, imageID string) error {
+					conditions.MarkUnknown(obj, infrav1.AMIDeprecatedCondition, infrav1.AMIDeprecationCheckFailedReason, "throttled")
+					return errors.New("throttled")
+				})
+			},
+			expectRequeue:   true,
+			expectCondition: &clusterv1.Condition{Type: infrav1.AMIDeprecatedCondition, Status: corev1.ConditionUnknown, Reason: infrav1.AMIDeprecationCheckFailedReason},
+		},
+		{
+			name: "should skip a template without a known AMI",
+			template: &infrav1.AWSMachineTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "template",
+					Namespace: "default",
+					Labels:    map[string]string{clusterv1.ClusterLabelName: "test-cluster"},
+				},
+			},
+		},
+		{
+			name: "should skip a template not used by any cluster",
+			template: &infrav1.AWSMachineTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "template", Namespace: "default"},
+				Spec: infrav1.AWSMachineTemplateSpec{Template: infrav1.AWSMachineTemplateResource{Spec: infrav1.AWSMachineSpec{
+					AMI: infrav1.AMIReference{ID: aws.String("ami-1")},
+				}}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			ec2Svc := mock_services.NewMockEC2Interface(mockCtrl)
+			if tc.expect != nil {
+				tc.expect(ec2Svc.EXPECT())
+			}
+
+			testScheme := runtime.NewScheme()
+			g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+			g.Expect(clusterv1.AddToScheme(testScheme)).To(Succeed())
+			objects := append([]client.Object{cluster.DeepCopy(), awsCluster.DeepCopy(), tc.template}, tc.awsMachines...)
+			c := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(objects...).Build()
+
+			reconciler := &AWSMachineTemplateReconciler{
+				Client: c,
+				ec2ServiceFactory: func(scope.EC2Scope) services.EC2Interface {
+					return ec2Svc
+				},
+			}
+
+			result, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(tc.template)})
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(result.RequeueAfter > 0).To(Equal(tc.expectRequeue))
+
+			template := &infrav1.AWSMachineTemplate{}
+			g.Expect(c.Get(context.TODO(), client.ObjectKeyFromObject(tc.template), template)).To(Succeed())
+			condition := conditions.Get(template, infrav1.AMIDeprecatedCondition)
+			if tc.expectCondition == nil {
+				g.Expect(condition).To(BeNil())
+				return
+			}
+			g.Expect(condition).NotTo(BeNil())
+			g.Expect(condition.Status).To(Equal(tc.expectCondition.Status))
+			g.Expect(condition.Reason).To(Equal(tc.expectCondition.Reason))
+		})
+	}
+}
@@ -43,7 +43,28 @@ func (src *AWSMachinePool) ConvertTo(dstRaw conversion.Hub) error {
 	}
 	if dst.Spec.RefreshPreferences != nil && restored.Spec.RefreshPreferences != nil {
 		dst.Spec.RefreshPreferences.Disable = restored.Spec.RefreshPreferences.Disable
+		dst.Spec.RefreshPreferences.CheckpointPercentages = restored.Spec.RefreshPreferences.CheckpointPercentages
+		dst.Spec.RefreshPreferences.CheckpointDelay = restored.Spec.RefreshPreferences.CheckpointDelay
+		dst.Spec.RefreshPreferences.SkipMatching = restored.Spec.RefreshPreferences.SkipMatching
+		dst.Spec.RefreshPreferences.RollbackOnFailure = restored.Spec.RefreshPreferences.RollbackOnFailure
 	}
+	dst.Spec.AWSLaunchTemplate.InstanceMetadataOptions = restored.Spec.AWSLaunchTemplate.InstanceMetadataOptions
+	dst.Spec.AWSLaunchTemplate.CapacityReservationSpecification = restored.Spec.AWSLaunchTemplate.CapacityReservationSpecification
+	dst.Spec.AWSLaunchTemplate.CPUOptions = restored.Spec.AWSLaunchTemplate.CPUOptions
+	dst.Spec.AWSLaunchTemplate.CreditSpecification = restored.Spec.AWSLaunchTemplate.CreditSpecification
+	dst.Spec.AWSLaunchTemplate.PrivateDNSNameOptions = restored.Spec.AWSLaunchTemplate.PrivateDNSNameOptions
+	dst.Spec.AWSLaunchTemplate.NonRootVolumes = restored.Spec.AWSLaunchTemplate.NonRootVolumes
+	dst.Status.ResolvedAMI = restored.Status.ResolvedAMI
+	dst.Spec.WarmPool = restored.Spec.WarmPool
+	dst.Status.WarmPoolInstances = restored.Status.WarmPoolInstances
+	dst.Spec.LifecycleHooks = restored.Spec.LifecycleHooks
+	dst.Status.InstanceRefresh = restored.Status.InstanceRefresh
+	dst.Status.RolledBackGeneration = restored.Status.RolledBackGeneration
+	dst.Status.RolledBackInstanceRefreshID = restored.Status.RolledBackInstanceRefreshID
+	dst.Status.CancelledInstanceRefreshID = restored.Status.CancelledInstanceRefreshID
+	dst.Spec.ScheduledActions = restored.Spec.ScheduledActions
+	dst.Status.ObservedCapacity = restored.Status.ObservedCapacity
+	dst.Status.ScheduledCapacity = restored.Status.ScheduledCapacity
 
 	return nil
 }
@@ -79,6 +100,22 @@ func (src *AWSManagedMachinePool) ConvertTo(dstRaw conversion.Hub) error {
 		return err
 	}
 
+	// Manually restore data.
+	restored := &infrav1exp.AWSManagedMachinePool{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+
+	if dst.Spec.AWSLaunchTemplate != nil && restored.Spec.AWSLaunchTemplate != nil {
+		dst.Spec.AWSLaunchTemplate.InstanceMetadataOptions = restored.Spec.AWSLaunchTemplate.InstanceMetadataOptions
+		dst.Spec.AWSLaunchTemplate.CapacityReservationSpecification = restored.Spec.AWSLaunchTemplate.CapacityReservationSpecification
+		dst.Spec.AWSLaunchTemplate.CPUOptions = restored.Spec.AWSLaunchTemplate.CPUOptions
+		dst.Spec.AWSLaunchTemplate.CreditSpecification = restored.Spec.AWSLaunchTemplate.CreditSpecification
+		dst.Spec.AWSLaunchTemplate.PrivateDNSNameOptions = restored.Spec.AWSLaunchTemplate.PrivateDNSNameOptions
+		dst.Spec.AWSLaunchTemplate.NonRootVolumes = restored.Spec.AWSLaunchTemplate.NonRootVolumes
+	}
+	dst.Status.ResolvedAMI = restored.Status.ResolvedAMI
+
 	return nil
 }
 
@@ -90,7 +127,7 @@ func (r *AWSManagedMachinePool) ConvertFrom(srcRaw conversion.Hub) error {
 		return err
 	}
 
-	return nil
+	return utilconversion.MarshalData(src, r)
 }
 
 // Convert_v1beta2_AWSManagedMachinePoolSpec_To_v1beta1_AWSManagedMachinePoolSpec is a conversion function.
@@ -184,3 +221,15 @@ func Convert_v1beta2_RefreshPreferences_To_v1beta1_RefreshPreferences(in *infrav
 	// spec.refreshPreferences.disable has been added to v1beta2.
 	return autoConvert_v1beta2_RefreshPreferences_To_v1beta1_RefreshPreferences(in, out, s)
 }
+
+// Convert_v1beta2_AWSMachinePoolStatus_To_v1beta1_AWSMachinePoolStatus converts the v1beta2 AWSMachinePoolStatus receiver to a v1beta1 AWSMachinePoolStatus.
+func Convert_v1beta2_AWSMachinePoolStatus_To_v1beta1_AWSMachinePoolStatus(in *infrav1exp.AWSMachinePoolStatus, out *AWSMachinePoolStatus, s apiconversion.Scope) error {
+	// status.resolvedAMI has been added to v1beta2.
+	return autoConvert_v1beta2_AWSMachinePoolStatus_To_v1beta1_AWSMachinePoolStatus(in, out, s)
+}
+
+// Convert_v1beta2_AWSManagedMachinePoolStatus_To_v1beta1_AWSManagedMachinePoolStatus converts the v1beta2 AWSManagedMachinePoolStatus receiver to a v1beta1 AWSManagedMachinePoolStatus.
+func Convert_v1beta2_AWSManagedMachinePoolStatus_To_v1beta1_AWSManagedMachinePoolStatus(in *infrav1exp.AWSManagedMachinePoolStatus, out *AWSManagedMachinePoolStatus, s apiconversion.Scope) error {
+	// status.resolvedAMI has been added to v1beta2.
+	return autoConvert_v1beta2_AWSManagedMachinePoolStatus_To_v1beta1_AWSManagedMachinePoolStatus(in, out, s)
+}
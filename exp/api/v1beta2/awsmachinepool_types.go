@@ -89,6 +89,27 @@ type AWSMachinePoolSpec struct {
 	// SuspendProcesses defines a list of processes to suspend for the given ASG. This is constantly reconciled.
 	// If a process is removed from this list it will automatically be resumed.
 	SuspendProcesses *SuspendProcessesTypes `json:"suspendProcesses,omitempty"`
+
+	// WarmPool describes a pool of pre-initialized instances kept alongside the ASG, which scale-ups draw
+	// from to avoid waiting for new instances to bootstrap. The warm pool is removed when unset.
+	// +optional
+	WarmPool *WarmPool `json:"warmPool,omitempty"`
+
+	// LifecycleHooks describes the lifecycle hooks of the ASG, which put instances into a wait state when they
+	// launch or terminate. Instances waiting on a terminating hook have their node drained by the controller,
+	// which then completes the lifecycle action.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	LifecycleHooks []AWSLifecycleHook `json:"lifecycleHooks,omitempty"`
+
+	// ScheduledActions describes the scheduled actions of the ASG, which change its size at given times.
+	// Changes a scheduled action makes to the size of the ASG are adopted by the AWSMachinePool minSize
+	// and maxSize and the MachinePool replicas, so they are not reverted.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	ScheduledActions []AWSScheduledAction `json:"scheduledActions,omitempty"`
 }
 
 // SuspendProcessesTypes contains user friendly auto-completable values for suspended process names.
@@ -160,6 +181,184 @@ type RefreshPreferences struct {
 	// during an instance refresh. The default is 90.
 	// +optional
 	MinHealthyPercentage *int64 `json:"minHealthyPercentage,omitempty"`
+
+	// CheckpointPercentages are the percentages of the instance refresh at which it pauses
+	// for CheckpointDelay, so a bad launch template doesn't replace the whole ASG at once.
+	// Values must be in ascending order and between 1 and 100.
+	// +optional
+	CheckpointPercentages []int64 `json:"checkpointPercentages,omitempty"`
+
+	// The number of seconds to wait after a checkpoint before continuing the instance refresh.
+	// The default is 3600 (1 hour). Requires CheckpointPercentages.
+	// +optional
+	CheckpointDelay *int64 `json:"checkpointDelay,omitempty"`
+
+	// SkipMatching, if true, skips replacing instances that already use the latest launch template version.
+	// +optional
+	SkipMatching *bool `json:"skipMatching,omitempty"`
+
+	// RollbackOnFailure, if true, rolls back the launch template to its previous version and
+	// replaces the instances already refreshed when an instance refresh fails. Further launch template
+	// changes are then held back until the AWSMachinePool is changed.
+	// +optional
+	RollbackOnFailure bool `json:"rollbackOnFailure,omitempty"`
+}
+
+// WarmPoolState is the state instances are kept in while in a warm pool.
+type WarmPoolState string
+
+var (
+	// WarmPoolStateStopped keeps warm pool instances stopped.
+	WarmPoolStateStopped = WarmPoolState("Stopped")
+
+	// WarmPoolStateRunning keeps warm pool instances running.
+	WarmPoolStateRunning = WarmPoolState("Running")
+)
+
+// WarmPool defines the specs for the warm pool of an ASG.
+type WarmPool struct {
+	// MinSize is the minimum number of instances to keep in the warm pool.
+	// Defaults to 0.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MinSize *int32 `json:"minSize,omitempty"`
+
+	// MaxGroupPreparedCapacity is the maximum number of instances allowed in the ASG and the warm pool
+	// combined. The warm pool holds the difference between this value and the desired capacity of the ASG.
+	// Defaults to the maximum size of the ASG, and must not be less than it.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxGroupPreparedCapacity *int32 `json:"maxGroupPreparedCapacity,omitempty"`
+
+	// PoolState is the state instances are kept in while in the warm pool.
+	// Defaults to Stopped.
+	// +optional
+	// +kubebuilder:validation:Enum=Stopped;Running
+	PoolState WarmPoolState `json:"poolState,omitempty"`
+
+	// ReuseOnScaleIn returns instances to the warm pool when the ASG scales in, rather than terminating them.
+	// +optional
+	ReuseOnScaleIn bool `json:"reuseOnScaleIn,omitempty"`
+}
+
+// LifecycleTransition is the instance transition a lifecycle hook is attached to.
+type LifecycleTransition string
+
+var (
+	// LifecycleTransitionInstanceLaunching is the transition of instances being launched.
+	LifecycleTransitionInstanceLaunching = LifecycleTransition("autoscaling:EC2_INSTANCE_LAUNCHING")
+
+	// LifecycleTransitionInstanceTerminating is the transition of instances being terminated.
+	LifecycleTransitionInstanceTerminating = LifecycleTransition("autoscaling:EC2_INSTANCE_TERMINATING")
+)
+
+// LifecycleHookDefaultResult is the action taken when the heartbeat timeout of a lifecycle hook elapses.
+type LifecycleHookDefaultResult string
+
+var (
+	// LifecycleHookDefaultResultContinue continues the transition of the instance.
+	LifecycleHookDefaultResultContinue = LifecycleHookDefaultResult("CONTINUE")
+
+	// LifecycleHookDefaultResultAbandon abandons the transition of the instance, terminating a launching instance.
+	LifecycleHookDefaultResultAbandon = LifecycleHookDefaultResult("ABANDON")
+)
+
+// AWSLifecycleHook describes an AWS lifecycle hook of an ASG.
+type AWSLifecycleHook struct {
+	// Name is the name of the lifecycle hook.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=255
+	Name string `json:"name"`
+
+	// LifecycleTransition is the instance transition the lifecycle hook is attached to.
+	// +kubebuilder:validation:Enum="autoscaling:EC2_INSTANCE_LAUNCHING";"autoscaling:EC2_INSTANCE_TERMINATING"
+	LifecycleTransition LifecycleTransition `json:"lifecycleTransition"`
+
+	// NotificationTargetARN is the ARN of the SNS topic or SQS queue notified when an instance is put into
+	// a wait state by the lifecycle hook.
+	// +optional
+	NotificationTargetARN *string `json:"notificationTargetARN,omitempty"`
+
+	// RoleARN is the ARN of the IAM role allowing the ASG to publish to the notification target.
+	// Required when NotificationTargetARN is set.
+	// +optional
+	RoleARN *string `json:"roleARN,omitempty"`
+
+	// HeartbeatTimeout is the maximum time an instance remains in a wait state before the default result
+	// is applied, between 30 seconds and 2 hours. Defaults to 1 hour.
+	// +optional
+	HeartbeatTimeout *metav1.Duration `json:"heartbeatTimeout,omitempty"`
+
+	// DefaultResult is the action taken when the heartbeat timeout elapses. Defaults to ABANDON.
+	// +optional
+	// +kubebuilder:validation:Enum=CONTINUE;ABANDON
+	DefaultResult *LifecycleHookDefaultResult `json:"defaultResult,omitempty"`
+
+	// NotificationMetadata is additional information sent to the notification target.
+	// +optional
+	NotificationMetadata *string `json:"notificationMetadata,omitempty"`
+}
+
+// AWSScheduledAction describes an AWS scheduled action of an ASG.
+type AWSScheduledAction struct {
+	// Name is the name of the scheduled action.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=255
+	Name string `json:"name"`
+
+	// Recurrence is the recurring schedule of the action in cron format, e.g. "0 20 * * 1-5".
+	// +optional
+	Recurrence *string `json:"recurrence,omitempty"`
+
+	// StartTime is the time the action runs, or the time after which a recurring action starts running.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// EndTime is the time after which a recurring action stops running.
+	// +optional
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+
+	// TimeZone is the IANA time zone the recurrence is evaluated in, e.g. "Europe/Berlin". Defaults to UTC.
+	// +optional
+	TimeZone *string `json:"timeZone,omitempty"`
+
+	// MinSize is the minimum size of the ASG set by the action.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinSize *int32 `json:"minSize,omitempty"`
+
+	// MaxSize is the maximum size of the ASG set by the action.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxSize *int32 `json:"maxSize,omitempty"`
+
+	// DesiredCapacity is the desired capacity of the ASG set by the action.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	DesiredCapacity *int32 `json:"desiredCapacity,omitempty"`
+}
+
+// AWSMachinePoolCapacity describes the size of the ASG of an AWSMachinePool.
+type AWSMachinePoolCapacity struct {
+	// MinSize is the minimum size of the ASG.
+	MinSize int32 `json:"minSize"`
+
+	// MaxSize is the maximum size of the ASG.
+	MaxSize int32 `json:"maxSize"`
+
+	// DesiredCapacity is the desired capacity of the ASG.
+	DesiredCapacity int32 `json:"desiredCapacity"`
+}
+
+// AWSMachinePoolScheduledCapacity describes the size of the ASG of an AWSMachinePool set by scheduled actions.
+type AWSMachinePoolScheduledCapacity struct {
+	// MinSize is the minimum size of the ASG set by a scheduled action.
+	// +optional
+	MinSize *int32 `json:"minSize,omitempty"`
+
+	// MaxSize is the maximum size of the ASG set by a scheduled action.
+	// +optional
+	MaxSize *int32 `json:"maxSize,omitempty"`
 }
 
 // AWSMachinePoolStatus defines the observed state of AWSMachinePool.
@@ -168,7 +367,7 @@ type AWSMachinePoolStatus struct {
 	// +optional
 	Ready bool `json:"ready"`
 
-	// Replicas is the most recently observed number of replicas
+	// Replicas is the most recently observed number of replicas. Instances in the warm pool are not counted.
 	// +optional
 	Replicas int32 `json:"replicas"`
 
@@ -180,6 +379,10 @@ type AWSMachinePoolStatus struct {
 	// +optional
 	Instances []AWSMachinePoolInstanceStatus `json:"instances,omitempty"`
 
+	// WarmPoolInstances contains the status for each instance in the warm pool
+	// +optional
+	WarmPoolInstances []AWSMachinePoolWarmPoolInstanceStatus `json:"warmPoolInstances,omitempty"`
+
 	// The ID of the launch template
 	LaunchTemplateID string `json:"launchTemplateID,omitempty"`
 
@@ -187,6 +390,42 @@ type AWSMachinePoolStatus struct {
 	// +optional
 	LaunchTemplateVersion *string `json:"launchTemplateVersion,omitempty"`
 
+	// ResolvedAMI is the AMI looked up for the launch template. It is used until the AWSMachinePool
+	// changes, the Kubernetes version changes or a refresh is requested with the
+	// aws.cluster.x-k8s.io/refresh-ami annotation.
+	// +optional
+	ResolvedAMI *infrav1.ResolvedAMI `json:"resolvedAMI,omitempty"`
+
+	// InstanceRefresh is the status of the most recent instance refresh of the ASG.
+	// +optional
+	InstanceRefresh *AWSMachinePoolInstanceRefreshStatus `json:"instanceRefresh,omitempty"`
+
+	// RolledBackGeneration is the generation of the AWSMachinePool whose launch template changes were
+	// rolled back after a failed instance refresh. Launch template changes are held back until the
+	// AWSMachinePool generation changes.
+	// +optional
+	RolledBackGeneration *int64 `json:"rolledBackGeneration,omitempty"`
+
+	// RolledBackInstanceRefreshID is the ID of the failed instance refresh the launch template was rolled back for.
+	// +optional
+	RolledBackInstanceRefreshID *string `json:"rolledBackInstanceRefreshID,omitempty"`
+
+	// CancelledInstanceRefreshID is the ID of the failing instance refresh that was cancelled. It is handled as a
+	// failed instance refresh once the cancellation completes.
+	// +optional
+	CancelledInstanceRefreshID *string `json:"cancelledInstanceRefreshID,omitempty"`
+
+	// ObservedCapacity is the size of the AWSMachinePool and MachinePool applied by the last reconciliation of an
+	// AWSMachinePool with scheduled actions. It is used to tell changes made by scheduled actions from changes to the
+	// AWSMachinePool and MachinePool.
+	// +optional
+	ObservedCapacity *AWSMachinePoolCapacity `json:"observedCapacity,omitempty"`
+
+	// ScheduledCapacity is the minimum and maximum size of the ASG set by scheduled actions, which take precedence
+	// over minSize and maxSize until these change on the AWSMachinePool.
+	// +optional
+	ScheduledCapacity *AWSMachinePoolScheduledCapacity `json:"scheduledCapacity,omitempty"`
+
 	// FailureReason will be set in the event that there is a terminal problem
 	// reconciling the Machine and will contain a succinct value suitable
 	// for machine interpretation.
@@ -239,6 +478,51 @@ type AWSMachinePoolInstanceStatus struct {
 	Version *string `json:"version,omitempty"`
 }
 
+// AWSMachinePoolWarmPoolInstanceStatus defines the status of an instance in the warm pool of an AWSMachinePool.
+type AWSMachinePoolWarmPoolInstanceStatus struct {
+	// InstanceID is the identification of the instance within the warm pool
+	// +optional
+	InstanceID string `json:"instanceID,omitempty"`
+
+	// LifecycleState is the lifecycle state of the instance within the warm pool, e.g. Warmed:Stopped
+	// +optional
+	LifecycleState string `json:"lifecycleState,omitempty"`
+
+	// AvailabilityZone is the availability zone of the instance
+	// +optional
+	AvailabilityZone string `json:"availabilityZone,omitempty"`
+}
+
+// AWSMachinePoolInstanceRefreshStatus defines the status of an instance refresh of the ASG of an AWSMachinePool.
+type AWSMachinePoolInstanceRefreshStatus struct {
+	// ID is the identification of the instance refresh
+	ID string `json:"id"`
+
+	// Status is the status of the instance refresh, e.g. InProgress, Successful or Failed
+	// +optional
+	Status string `json:"status,omitempty"`
+
+	// StatusReason provides more details about the status of the instance refresh
+	// +optional
+	StatusReason string `json:"statusReason,omitempty"`
+
+	// PercentageComplete is the percentage of the instance refresh that is complete
+	// +optional
+	PercentageComplete *int64 `json:"percentageComplete,omitempty"`
+
+	// InstancesToUpdate is the number of instances remaining to update
+	// +optional
+	InstancesToUpdate *int64 `json:"instancesToUpdate,omitempty"`
+
+	// StartTime is the time the instance refresh began
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// EndTime is the time the instance refresh ended
+	// +optional
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:storageversion
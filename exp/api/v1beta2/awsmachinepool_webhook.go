@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta2
 
 import (
+	"strings"
 	"time"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -74,10 +75,36 @@ func (r *AWSMachinePool) validateRootVolume() field.ErrorList {
 		}
 	}
 
+	if r.Spec.AWSLaunchTemplate.RootVolume.VolumeDeletionPolicy != "" {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec.awsLaunchTemplate.rootVolume.volumeDeletionPolicy"), "volume deletion policy is only supported for AWSMachines"))
+	}
+
 	if r.Spec.AWSLaunchTemplate.RootVolume.DeviceName != "" {
 		log.Info("root volume shouldn't have a device name (this can be ignored if performing a `clusterctl move`)")
 	}
 
+	allErrs = append(allErrs, r.Spec.AWSLaunchTemplate.RootVolume.Validate(field.NewPath("spec", "awsLaunchTemplate", "rootVolume"), true)...)
+
+	return allErrs
+}
+
+func (r *AWSMachinePool) validateNonRootVolumes() field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, volume := range r.Spec.AWSLaunchTemplate.NonRootVolumes {
+		fldPath := field.NewPath("spec", "awsLaunchTemplate", "nonRootVolumes").Index(i)
+
+		if volume.DeviceName == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("deviceName"), "non root volume should have device name"))
+		}
+
+		if volume.VolumeDeletionPolicy != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("volumeDeletionPolicy"), "volume deletion policy is only supported for AWSMachines"))
+		}
+
+		allErrs = append(allErrs, volume.Validate(fldPath, false)...)
+	}
+
 	return allErrs
 }
 
@@ -114,6 +141,136 @@ func (r *AWSMachinePool) validateAdditionalSecurityGroups() field.ErrorList {
 	return allErrs
 }
 
+func (r *AWSMachinePool) validateCapacityReservationSpecification() field.ErrorList {
+	var allErrs field.ErrorList
+	spec := r.Spec.AWSLaunchTemplate.CapacityReservationSpecification
+	if spec == nil {
+		return allErrs
+	}
+
+	allErrs = append(allErrs, spec.Validate(field.NewPath("spec", "awsLaunchTemplate", "capacityReservationSpecification"))...)
+	if r.Spec.AWSLaunchTemplate.SpotMarketOptions != nil && spec.CapacityReservationTarget != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "awsLaunchTemplate", "capacityReservationSpecification", "capacityReservationTarget"), "cannot be set if spec.awsLaunchTemplate.spotMarketOptions is set"))
+	}
+	return allErrs
+}
+
+func (r *AWSMachinePool) validateWarmPool() field.ErrorList {
+	var allErrs field.ErrorList
+	warmPool := r.Spec.WarmPool
+	if warmPool == nil {
+		return allErrs
+	}
+
+	fldPath := field.NewPath("spec", "warmPool")
+	if r.Spec.MixedInstancesPolicy != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "cannot be set if spec.mixedInstancesPolicy is set"))
+	}
+	if r.Spec.AWSLaunchTemplate.SpotMarketOptions != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "cannot be set if spec.awsLaunchTemplate.spotMarketOptions is set"))
+	}
+	if warmPool.MinSize != nil && warmPool.MaxGroupPreparedCapacity != nil && *warmPool.MinSize > *warmPool.MaxGroupPreparedCapacity {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("minSize"), *warmPool.MinSize, "must not be greater than spec.warmPool.maxGroupPreparedCapacity"))
+	}
+	if warmPool.MaxGroupPreparedCapacity != nil && *warmPool.MaxGroupPreparedCapacity < r.Spec.MaxSize {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxGroupPreparedCapacity"), *warmPool.MaxGroupPreparedCapacity, "must not be less than spec.maxSize"))
+	}
+	return allErrs
+}
+
+func (r *AWSMachinePool) validateRefreshPreferences() field.ErrorList {
+	var allErrs field.ErrorList
+	prefs := r.Spec.RefreshPreferences
+	if prefs == nil {
+		return allErrs
+	}
+
+	fldPath := field.NewPath("spec", "refreshPreferences")
+	for i, percentage := range prefs.CheckpointPercentages {
+		if percentage < 1 || percentage > 100 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("checkpointPercentages").Index(i), percentage, "must be between 1 and 100"))
+		}
+		if i > 0 && percentage <= prefs.CheckpointPercentages[i-1] {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("checkpointPercentages").Index(i), percentage, "must be in ascending order"))
+		}
+	}
+	if prefs.CheckpointDelay != nil {
+		if len(prefs.CheckpointPercentages) == 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("checkpointDelay"), "cannot be set if spec.refreshPreferences.checkpointPercentages is not set"))
+		}
+		if *prefs.CheckpointDelay < 0 || *prefs.CheckpointDelay > 172800 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("checkpointDelay"), *prefs.CheckpointDelay, "must be between 0 and 172800"))
+		}
+	}
+	return allErrs
+}
+
+func (r *AWSMachinePool) validateLifecycleHooks() field.ErrorList {
+	var allErrs field.ErrorList
+	names := make(map[string]struct{})
+	for i, hook := range r.Spec.LifecycleHooks {
+		fldPath := field.NewPath("spec", "lifecycleHooks").Index(i)
+		if _, ok := names[hook.Name]; ok {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("name"), hook.Name))
+		}
+		names[hook.Name] = struct{}{}
+
+		if hook.NotificationTargetARN != nil && hook.RoleARN == nil {
+			allErrs = append(allErrs, field.Required(fldPath.Child("roleARN"), "must be set if notificationTargetARN is set"))
+		}
+		if hook.RoleARN != nil && hook.NotificationTargetARN == nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("roleARN"), "cannot be set if notificationTargetARN is not set"))
+		}
+		if hook.HeartbeatTimeout != nil && (hook.HeartbeatTimeout.Duration < 30*time.Second || hook.HeartbeatTimeout.Duration > 2*time.Hour) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("heartbeatTimeout"), hook.HeartbeatTimeout.Duration.String(), "must be between 30s and 2h"))
+		}
+	}
+	return allErrs
+}
+
+func (r *AWSMachinePool) validateScheduledActions() field.ErrorList {
+	var allErrs field.ErrorList
+	names := make(map[string]struct{})
+	for i, action := range r.Spec.ScheduledActions {
+		fldPath := field.NewPath("spec", "scheduledActions").Index(i)
+		if _, ok := names[action.Name]; ok {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("name"), action.Name))
+		}
+		names[action.Name] = struct{}{}
+
+		if action.Recurrence == nil && action.StartTime == nil {
+			allErrs = append(allErrs, field.Required(fldPath, "one of recurrence or startTime must be set"))
+		}
+		if action.Recurrence != nil && len(strings.Fields(*action.Recurrence)) != 5 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("recurrence"), *action.Recurrence, "must be a cron expression with 5 fields"))
+		}
+		if action.EndTime != nil {
+			if action.Recurrence == nil {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child("endTime"), "cannot be set if recurrence is not set"))
+			}
+			if action.StartTime != nil && !action.EndTime.After(action.StartTime.Time) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("endTime"), action.EndTime.String(), "must be after startTime"))
+			}
+		}
+
+		if action.MinSize == nil && action.MaxSize == nil && action.DesiredCapacity == nil {
+			allErrs = append(allErrs, field.Required(fldPath, "one of minSize, maxSize or desiredCapacity must be set"))
+		}
+		if action.MinSize != nil && action.MaxSize != nil && *action.MinSize > *action.MaxSize {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("minSize"), *action.MinSize, "must not be greater than maxSize"))
+		}
+		if action.DesiredCapacity != nil {
+			if action.MinSize != nil && *action.DesiredCapacity < *action.MinSize {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("desiredCapacity"), *action.DesiredCapacity, "must not be less than minSize"))
+			}
+			if action.MaxSize != nil && *action.DesiredCapacity > *action.MaxSize {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("desiredCapacity"), *action.DesiredCapacity, "must not be greater than maxSize"))
+			}
+		}
+	}
+	return allErrs
+}
+
 // ValidateCreate will do any extra validation when creating a AWSMachinePool.
 func (r *AWSMachinePool) ValidateCreate() error {
 	log.Info("AWSMachinePool validate create", "machine-pool", klog.KObj(r))
@@ -122,9 +279,17 @@ func (r *AWSMachinePool) ValidateCreate() error {
 
 	allErrs = append(allErrs, r.validateDefaultCoolDown()...)
 	allErrs = append(allErrs, r.validateRootVolume()...)
+	allErrs = append(allErrs, r.validateNonRootVolumes()...)
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
 	allErrs = append(allErrs, r.validateSubnets()...)
 	allErrs = append(allErrs, r.validateAdditionalSecurityGroups()...)
+	allErrs = append(allErrs, r.validateCapacityReservationSpecification()...)
+	allErrs = append(allErrs, r.validateWarmPool()...)
+	allErrs = append(allErrs, r.validateLifecycleHooks()...)
+	allErrs = append(allErrs, r.validateRefreshPreferences()...)
+	allErrs = append(allErrs, r.validateScheduledActions()...)
+	allErrs = append(allErrs, v1beta2.ValidateCPUOptionsForInstanceType(r.Spec.AWSLaunchTemplate.CPUOptions, r.Spec.AWSLaunchTemplate.CreditSpecification, r.Spec.AWSLaunchTemplate.InstanceType, field.NewPath("spec", "awsLaunchTemplate"))...)
+	allErrs = append(allErrs, r.Spec.AWSLaunchTemplate.AMI.Validate(field.NewPath("spec", "awsLaunchTemplate", "ami"))...)
 
 	if len(allErrs) == 0 {
 		return nil
@@ -142,9 +307,17 @@ func (r *AWSMachinePool) ValidateUpdate(old runtime.Object) error {
 	var allErrs field.ErrorList
 
 	allErrs = append(allErrs, r.validateDefaultCoolDown()...)
+	allErrs = append(allErrs, r.validateNonRootVolumes()...)
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
 	allErrs = append(allErrs, r.validateSubnets()...)
 	allErrs = append(allErrs, r.validateAdditionalSecurityGroups()...)
+	allErrs = append(allErrs, r.validateCapacityReservationSpecification()...)
+	allErrs = append(allErrs, r.validateWarmPool()...)
+	allErrs = append(allErrs, r.validateLifecycleHooks()...)
+	allErrs = append(allErrs, r.validateRefreshPreferences()...)
+	allErrs = append(allErrs, r.validateScheduledActions()...)
+	allErrs = append(allErrs, v1beta2.ValidateCPUOptionsForInstanceType(r.Spec.AWSLaunchTemplate.CPUOptions, r.Spec.AWSLaunchTemplate.CreditSpecification, r.Spec.AWSLaunchTemplate.InstanceType, field.NewPath("spec", "awsLaunchTemplate"))...)
+	allErrs = append(allErrs, r.Spec.AWSLaunchTemplate.AMI.Validate(field.NewPath("spec", "awsLaunchTemplate", "ami"))...)
 
 	if len(allErrs) == 0 {
 		return nil
@@ -19,6 +19,7 @@ package v1beta2
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	. "github.com/onsi/gomega"
@@ -139,6 +140,376 @@ func TestAWSMachinePool_ValidateCreate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Should fail if root volume has a deletion policy",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						RootVolume: &infrav1.Volume{
+							Size:                 *aws.Int64(8),
+							VolumeDeletionPolicy: infrav1.VolumeDeletionPolicyRetain,
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass if capacity reservation targets a resource group",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						CapacityReservationSpecification: &infrav1.CapacityReservationSpecification{
+							CapacityReservationTarget: &infrav1.CapacityReservationTarget{
+								CapacityReservationResourceGroupArn: aws.String("arn:aws:resource-groups:us-west-2:123456789012:group/my-cr-group"),
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if capacity reservation target has both reservation ID and resource group ARN",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						CapacityReservationSpecification: &infrav1.CapacityReservationSpecification{
+							CapacityReservationTarget: &infrav1.CapacityReservationTarget{
+								CapacityReservationID:               aws.String("cr-1234567890abcdef0"),
+								CapacityReservationResourceGroupArn: aws.String("arn:aws:resource-groups:us-west-2:123456789012:group/my-cr-group"),
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if capacity reservation target is empty",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						CapacityReservationSpecification: &infrav1.CapacityReservationSpecification{
+							CapacityReservationTarget: &infrav1.CapacityReservationTarget{},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if capacity reservation target is used with spot market options",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						CapacityReservationSpecification: &infrav1.CapacityReservationSpecification{
+							CapacityReservationTarget: &infrav1.CapacityReservationTarget{
+								CapacityReservationID: aws.String("cr-1234567890abcdef0"),
+							},
+						},
+						SpotMarketOptions: &infrav1.SpotMarketOptions{},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass if CPU options and unlimited credits are set for a burstable instance type",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						InstanceType:        "t3.xlarge",
+						CPUOptions:          &infrav1.CPUOptions{CoreCount: 2, ThreadsPerCore: 1},
+						CreditSpecification: &infrav1.CreditSpecification{CPUCredits: infrav1.CPUCreditsUnlimited},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if threads per core is out of range",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						InstanceType: "m5.xlarge",
+						CPUOptions:   &infrav1.CPUOptions{CoreCount: 2, ThreadsPerCore: 3},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if credit specification is set for a non burstable instance type",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						InstanceType:        "m5.xlarge",
+						CreditSpecification: &infrav1.CreditSpecification{CPUCredits: infrav1.CPUCreditsUnlimited},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass if a warm pool is set",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					WarmPool: &WarmPool{
+						MinSize:                  aws.Int32(1),
+						MaxGroupPreparedCapacity: aws.Int32(3),
+						PoolState:                WarmPoolStateRunning,
+						ReuseOnScaleIn:           true,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if a warm pool is used with spot market options",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						SpotMarketOptions: &infrav1.SpotMarketOptions{},
+					},
+					WarmPool: &WarmPool{},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass if lifecycle hooks are valid",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					LifecycleHooks: []AWSLifecycleHook{
+						{
+							Name:                  "launching",
+							LifecycleTransition:   LifecycleTransitionInstanceLaunching,
+							NotificationTargetARN: aws.String("arn:aws:sns:us-east-1:123456789012:topic"),
+							RoleARN:               aws.String("arn:aws:iam::123456789012:role/hooks"),
+						},
+						{
+							Name:                "terminating",
+							LifecycleTransition: LifecycleTransitionInstanceTerminating,
+							HeartbeatTimeout:    &metav1.Duration{Duration: 10 * time.Minute},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if lifecycle hook names are duplicated",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					LifecycleHooks: []AWSLifecycleHook{
+						{
+							Name:                "hook",
+							LifecycleTransition: LifecycleTransitionInstanceLaunching,
+						},
+						{
+							Name:                "hook",
+							LifecycleTransition: LifecycleTransitionInstanceTerminating,
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if a lifecycle hook notification target is set without a role",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					LifecycleHooks: []AWSLifecycleHook{
+						{
+							Name:                  "hook",
+							LifecycleTransition:   LifecycleTransitionInstanceTerminating,
+							NotificationTargetARN: aws.String("arn:aws:sns:us-east-1:123456789012:topic"),
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if a lifecycle hook heartbeat timeout is out of range",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					LifecycleHooks: []AWSLifecycleHook{
+						{
+							Name:                "hook",
+							LifecycleTransition: LifecycleTransitionInstanceTerminating,
+							HeartbeatTimeout:    &metav1.Duration{Duration: 10 * time.Second},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if the warm pool minimum size is greater than its maximum prepared capacity",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					WarmPool: &WarmPool{
+						MinSize:                  aws.Int32(3),
+						MaxGroupPreparedCapacity: aws.Int32(1),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if the warm pool maximum prepared capacity is less than the maximum size",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					MaxSize: 5,
+					WarmPool: &WarmPool{
+						MaxGroupPreparedCapacity: aws.Int32(3),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass if refresh checkpoints are valid",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					RefreshPreferences: &RefreshPreferences{
+						CheckpointPercentages: []int64{20, 50, 100},
+						CheckpointDelay:       aws.Int64(600),
+						RollbackOnFailure:     true,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if refresh checkpoints are not in ascending order",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					RefreshPreferences: &RefreshPreferences{
+						CheckpointPercentages: []int64{50, 20, 100},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if a refresh checkpoint is out of range",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					RefreshPreferences: &RefreshPreferences{
+						CheckpointPercentages: []int64{0, 100},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if a refresh checkpoint delay is set without checkpoints",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					RefreshPreferences: &RefreshPreferences{
+						CheckpointDelay: aws.Int64(600),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass if scheduled actions are valid",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					ScheduledActions: []AWSScheduledAction{
+						{
+							Name:            "scale-down",
+							Recurrence:      aws.String("0 20 * * 1-5"),
+							TimeZone:        aws.String("Europe/Berlin"),
+							MinSize:         aws.Int32(0),
+							DesiredCapacity: aws.Int32(0),
+						},
+						{
+							Name:            "scale-up",
+							StartTime:       &metav1.Time{Time: time.Date(2022, 12, 1, 8, 0, 0, 0, time.UTC)},
+							DesiredCapacity: aws.Int32(3),
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if scheduled action names are duplicated",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					ScheduledActions: []AWSScheduledAction{
+						{Name: "action", Recurrence: aws.String("0 20 * * *"), DesiredCapacity: aws.Int32(0)},
+						{Name: "action", Recurrence: aws.String("0 8 * * *"), DesiredCapacity: aws.Int32(3)},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if a scheduled action has no schedule",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					ScheduledActions: []AWSScheduledAction{
+						{Name: "action", DesiredCapacity: aws.Int32(0)},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if a scheduled action recurrence is not a cron expression",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					ScheduledActions: []AWSScheduledAction{
+						{Name: "action", Recurrence: aws.String("every night"), DesiredCapacity: aws.Int32(0)},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if a scheduled action doesn't change the size of the ASG",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					ScheduledActions: []AWSScheduledAction{
+						{Name: "action", Recurrence: aws.String("0 20 * * *")},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if a scheduled action desired capacity is greater than its maximum size",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					ScheduledActions: []AWSScheduledAction{
+						{Name: "action", Recurrence: aws.String("0 20 * * *"), MaxSize: aws.Int32(2), DesiredCapacity: aws.Int32(3)},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if a one-time scheduled action has an end time",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					ScheduledActions: []AWSScheduledAction{
+						{
+							Name:            "action",
+							StartTime:       &metav1.Time{Time: time.Date(2022, 12, 1, 8, 0, 0, 0, time.UTC)},
+							EndTime:         &metav1.Time{Time: time.Date(2022, 12, 2, 8, 0, 0, 0, time.UTC)},
+							DesiredCapacity: aws.Int32(3),
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
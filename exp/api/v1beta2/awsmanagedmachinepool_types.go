@@ -190,6 +190,12 @@ type AWSManagedMachinePoolStatus struct {
 	// +optional
 	LaunchTemplateVersion *string `json:"launchTemplateVersion,omitempty"`
 
+	// ResolvedAMI is the AMI looked up for the launch template. It is used until the AWSManagedMachinePool
+	// changes, the Kubernetes version changes or a refresh is requested with the
+	// aws.cluster.x-k8s.io/refresh-ami annotation.
+	// +optional
+	ResolvedAMI *infrav1.ResolvedAMI `json:"resolvedAMI,omitempty"`
+
 	// FailureReason will be set in the event that there is a terminal problem
 	// reconciling the MachinePool and will contain a succinct value suitable
 	// for machine interpretation.
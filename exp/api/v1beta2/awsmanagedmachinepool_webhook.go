@@ -30,6 +30,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/eks"
 )
 
@@ -134,6 +135,28 @@ func (r *AWSManagedMachinePool) validateLaunchTemplate() field.ErrorList {
 		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "AWSLaunchTemplate", "IamInstanceProfile"), r.Spec.AWSLaunchTemplate.IamInstanceProfile, "IAM instance profile in launch template is prohibited in EKS managed node group"))
 	}
 
+	if r.Spec.AWSLaunchTemplate.RootVolume != nil {
+		if r.Spec.AWSLaunchTemplate.RootVolume.VolumeDeletionPolicy != "" {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "AWSLaunchTemplate", "RootVolume", "VolumeDeletionPolicy"), "volume deletion policy is only supported for AWSMachines"))
+		}
+		allErrs = append(allErrs, r.Spec.AWSLaunchTemplate.RootVolume.Validate(field.NewPath("spec", "AWSLaunchTemplate", "RootVolume"), true)...)
+	}
+
+	for i, volume := range r.Spec.AWSLaunchTemplate.NonRootVolumes {
+		fldPath := field.NewPath("spec", "AWSLaunchTemplate", "NonRootVolumes").Index(i)
+		if volume.DeviceName == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("DeviceName"), "non root volume should have device name"))
+		}
+		if volume.VolumeDeletionPolicy != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("VolumeDeletionPolicy"), "volume deletion policy is only supported for AWSMachines"))
+		}
+		allErrs = append(allErrs, volume.Validate(fldPath, false)...)
+	}
+
+	allErrs = append(allErrs, r.Spec.AWSLaunchTemplate.CapacityReservationSpecification.Validate(field.NewPath("spec", "AWSLaunchTemplate", "CapacityReservationSpecification"))...)
+	allErrs = append(allErrs, infrav1.ValidateCPUOptionsForInstanceType(r.Spec.AWSLaunchTemplate.CPUOptions, r.Spec.AWSLaunchTemplate.CreditSpecification, r.Spec.AWSLaunchTemplate.InstanceType, field.NewPath("spec", "AWSLaunchTemplate"))...)
+	allErrs = append(allErrs, r.Spec.AWSLaunchTemplate.AMI.Validate(field.NewPath("spec", "AWSLaunchTemplate", "AMI"))...)
+
 	return allErrs
 }
 
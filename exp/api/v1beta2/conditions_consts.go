@@ -36,6 +36,8 @@ const (
 	LaunchTemplateCreateFailedReason = "LaunchTemplateCreateFailed"
 	// LaunchTemplateReconcileFailedReason used for failures during Launch Template reconciliation.
 	LaunchTemplateReconcileFailedReason = "LaunchTemplateReconcileFailed"
+	// LaunchTemplateRolledBackReason is used when Launch Template changes were rolled back after a failed instance refresh.
+	LaunchTemplateRolledBackReason = "LaunchTemplateRolledBack"
 
 	// PreLaunchTemplateUpdateCheckCondition reports if all prerequisite are met for launch template update.
 	PreLaunchTemplateUpdateCheckCondition clusterv1.ConditionType = "PreLaunchTemplateUpdateCheckSuccess"
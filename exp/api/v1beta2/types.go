@@ -102,6 +102,11 @@ type AWSLaunchTemplate struct {
 	// +optional
 	RootVolume *infrav1.Volume `json:"rootVolume,omitempty"`
 
+	// NonRootVolumes are the configuration options for the non root storage volumes,
+	// including instance store volumes.
+	// +optional
+	NonRootVolumes []infrav1.Volume `json:"nonRootVolumes,omitempty"`
+
 	// SSHKeyName is the name of the ssh key to attach to the instance. Valid values are empty string
 	// (do not use SSH keys), a valid SSH key name, or omitted (use the default SSH key name)
 	// +optional
@@ -122,6 +127,28 @@ type AWSLaunchTemplate struct {
 
 	// SpotMarketOptions are options for configuring AWSMachinePool instances to be run using AWS Spot instances.
 	SpotMarketOptions *infrav1.SpotMarketOptions `json:"spotMarketOptions,omitempty"`
+
+	// InstanceMetadataOptions defines the behavior for applying metadata to instances.
+	// +optional
+	InstanceMetadataOptions *infrav1.InstanceMetadataOptions `json:"instanceMetadataOptions,omitempty"`
+
+	// CapacityReservationSpecification configures the On-Demand Capacity Reservation targeting of the instances.
+	// Can not be used together with SpotMarketOptions.
+	// +optional
+	CapacityReservationSpecification *infrav1.CapacityReservationSpecification `json:"capacityReservationSpecification,omitempty"`
+
+	// CPUOptions configures the number of CPU cores and threads per core of the instances.
+	// +optional
+	CPUOptions *infrav1.CPUOptions `json:"cpuOptions,omitempty"`
+
+	// CreditSpecification configures the credit option for CPU usage of burstable performance instances.
+	// +optional
+	CreditSpecification *infrav1.CreditSpecification `json:"creditSpecification,omitempty"`
+
+	// PrivateDNSNameOptions configures the hostname of the instances, which becomes the name of their nodes,
+	// and the DNS records created for them. Defaults to the options of the subnets the instances are launched in.
+	// +optional
+	PrivateDNSNameOptions *infrav1.PrivateDNSNameOptions `json:"privateDnsNameOptions,omitempty"`
 }
 
 // Overrides are used to override the instance type specified by the launch template with multiple
@@ -197,6 +224,9 @@ type AutoScalingGroup struct {
 	Status                    ASGStatus
 	Instances                 []infrav1.Instance `json:"instances,omitempty"`
 	CurrentlySuspendProcesses []string           `json:"currentlySuspendProcesses,omitempty"`
+	WarmPool                  *WarmPool          `json:"warmPool,omitempty"`
+	WarmPoolInstances         []infrav1.Instance `json:"warmPoolInstances,omitempty"`
+	LifecycleHooks            []AWSLifecycleHook `json:"lifecycleHooks,omitempty"`
 }
 
 // ASGStatus is a status string returned by the autoscaling API.
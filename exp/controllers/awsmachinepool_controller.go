@@ -28,6 +28,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -249,14 +250,27 @@ func (r *AWSMachinePoolReconciler) reconcileNormal(ctx context.Context, machineP
 		machinePoolScope.Info("starting instance refresh", "number of instances", machinePoolScope.MachinePool.Spec.Replicas)
 		return asgsvc.StartASGInstanceRefresh(machinePoolScope)
 	}
-	if err := ec2Svc.ReconcileLaunchTemplate(machinePoolScope, canUpdateLaunchTemplate, runPostLaunchTemplateUpdateOperation); err != nil {
-		r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedLaunchTemplateReconcile", "Failed to reconcile launch template: %v", err)
-		machinePoolScope.Error(err, "failed to reconcile launch template")
-		return ctrl.Result{}, err
+	if launchTemplateRolledBack(machinePoolScope.AWSMachinePool) {
+		// Applying the launch template changes again would start another instance refresh bound to fail.
+		machinePoolScope.Info("Launch template was rolled back after a failed instance refresh, skipping launch template changes until the AWSMachinePool changes")
+		conditions.MarkFalse(machinePoolScope.AWSMachinePool, expinfrav1.LaunchTemplateReadyCondition, expinfrav1.LaunchTemplateRolledBackReason, clusterv1.ConditionSeverityWarning, "")
+	} else {
+		if err := ec2Svc.ReconcileLaunchTemplate(machinePoolScope, canUpdateLaunchTemplate, runPostLaunchTemplateUpdateOperation); err != nil {
+			r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedLaunchTemplateReconcile", "Failed to reconcile launch template: %v", err)
+			machinePoolScope.Error(err, "failed to reconcile launch template")
+			return ctrl.Result{}, err
+		}
+
+		// set the LaunchTemplateReady condition
+		conditions.MarkTrue(machinePoolScope.AWSMachinePool, expinfrav1.LaunchTemplateReadyCondition)
 	}
 
-	// set the LaunchTemplateReady condition
-	conditions.MarkTrue(machinePoolScope.AWSMachinePool, expinfrav1.LaunchTemplateReadyCondition)
+	if imageID := launchTemplateImageID(machinePoolScope.AWSMachinePool); imageID != "" {
+		// A failed check is reported with the AMIDeprecated condition and must not block the reconciliation.
+		if err := ec2Svc.ReconcileAMIDeprecation(machinePoolScope.AWSMachinePool, imageID); err != nil {
+			machinePoolScope.Error(err, "failed to check AMI deprecation")
+		}
+	}
 
 	// Find existing ASG
 	asg, err := r.findASG(machinePoolScope, asgsvc)
@@ -287,11 +301,20 @@ func (r *AWSMachinePoolReconciler) reconcileNormal(ctx context.Context, machineP
 		}
 	}
 
+	if err := r.reconcileScheduledCapacity(ctx, machinePoolScope, asg); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	if err := r.updatePool(machinePoolScope, clusterScope, asg); err != nil {
 		machinePoolScope.Error(err, "error updating AWSMachinePool")
 		return ctrl.Result{}, err
 	}
 
+	if err := r.reconcileInstanceRefresh(machinePoolScope, ec2Svc, asgsvc); err != nil {
+		machinePoolScope.Error(err, "error reconciling instance refresh")
+		return ctrl.Result{}, err
+	}
+
 	launchTemplateID := machinePoolScope.GetLaunchTemplateIDStatus()
 	asgName := machinePoolScope.Name()
 	resourceServiceToUpdate := []scope.ResourceServiceToUpdate{
@@ -328,8 +351,17 @@ func (r *AWSMachinePoolReconciler) reconcileNormal(ctx context.Context, machineP
 	if err != nil {
 		machinePoolScope.Info("Failed updating instances", "instances", asg.Instances)
 	}
+	machinePoolScope.UpdateWarmPoolInstanceStatuses(asg.WarmPoolInstances)
 
-	return ctrl.Result{}, nil
+	result, err := r.reconcileTerminatingInstances(ctx, machinePoolScope, asgsvc, asg)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if instanceRefreshInProgress(machinePoolScope.AWSMachinePool) && (result.RequeueAfter == 0 || result.RequeueAfter > instanceRefreshRequeueAfter) {
+		result.RequeueAfter = instanceRefreshRequeueAfter
+	}
+
+	return result, nil
 }
 
 func (r *AWSMachinePoolReconciler) reconcileDelete(machinePoolScope *scope.MachinePoolScope, clusterScope cloud.ClusterScoper, ec2Scope scope.EC2Scope) (ctrl.Result, error) {
@@ -451,6 +483,29 @@ func (r *AWSMachinePoolReconciler) updatePool(machinePoolScope *scope.MachinePoo
 			}
 		}
 	}
+
+	warmPool := machinePoolScope.AWSMachinePool.Spec.WarmPool
+	switch {
+	case warmPool == nil && existingASG.WarmPool != nil:
+		machinePoolScope.Info("deleting warm pool of AutoScalingGroup")
+		if err := asgSvc.DeleteWarmPool(existingASG.Name); err != nil {
+			return errors.Wrapf(err, "failed to delete warm pool while trying update pool")
+		}
+	case warmPool != nil && warmPoolNeedsUpdates(warmPool, existingASG.WarmPool):
+		machinePoolScope.Info("updating warm pool of AutoScalingGroup")
+		if err := asgSvc.PutWarmPool(existingASG.Name, warmPool); err != nil {
+			return errors.Wrapf(err, "failed to put warm pool while trying update pool")
+		}
+	}
+
+	if err := r.reconcileLifecycleHooks(machinePoolScope, asgSvc); err != nil {
+		return errors.Wrapf(err, "failed to reconcile lifecycle hooks while trying update pool")
+	}
+
+	if err := r.reconcileScheduledActions(machinePoolScope, asgSvc); err != nil {
+		return errors.Wrapf(err, "failed to reconcile scheduled actions while trying update pool")
+	}
+	machinePoolScope.AWSMachinePool.Status.ObservedCapacity = observedCapacity(machinePoolScope)
 	return nil
 }
 
@@ -468,6 +523,11 @@ func (r *AWSMachinePoolReconciler) createPool(machinePoolScope *scope.MachinePoo
 	if err := asgsvc.SuspendProcesses(asg.Name, suspendedProcessesSlice); err != nil {
 		return nil, errors.Wrapf(err, "failed to suspend processes while trying to create Pool")
 	}
+	if warmPool := machinePoolScope.AWSMachinePool.Spec.WarmPool; warmPool != nil {
+		if err := asgsvc.PutWarmPool(machinePoolScope.Name(), warmPool); err != nil {
+			return nil, errors.Wrapf(err, "failed to put warm pool while trying to create Pool")
+		}
+	}
 	return asg, nil
 }
 
@@ -493,11 +553,11 @@ func asgNeedsUpdates(machinePoolScope *scope.MachinePoolScope, existingASG *expi
 		}
 	}
 
-	if machinePoolScope.AWSMachinePool.Spec.MaxSize != existingASG.MaxSize {
+	if machinePoolScope.MaxSize() != existingASG.MaxSize {
 		return true
 	}
 
-	if machinePoolScope.AWSMachinePool.Spec.MinSize != existingASG.MinSize {
+	if machinePoolScope.MinSize() != existingASG.MinSize {
 		return true
 	}
 
@@ -515,6 +575,43 @@ func asgNeedsUpdates(machinePoolScope *scope.MachinePoolScope, existingASG *expi
 	return false
 }
 
+// warmPoolNeedsUpdates compares the incoming warm pool against the existing warm pool of the ASG, using the
+// AWS defaults for unset fields.
+func warmPoolNeedsUpdates(incoming, existing *expinfrav1.WarmPool) bool {
+	if existing == nil {
+		return true
+	}
+
+	if pointer.Int32Deref(incoming.MinSize, 0) != pointer.Int32Deref(existing.MinSize, 0) {
+		return true
+	}
+
+	if !cmp.Equal(incoming.MaxGroupPreparedCapacity, existing.MaxGroupPreparedCapacity) {
+		return true
+	}
+
+	incomingPoolState := incoming.PoolState
+	if incomingPoolState == "" {
+		incomingPoolState = expinfrav1.WarmPoolStateStopped
+	}
+	if incomingPoolState != existing.PoolState {
+		return true
+	}
+
+	return incoming.ReuseOnScaleIn != existing.ReuseOnScaleIn
+}
+
+// launchTemplateImageID returns the ID of the AMI used by the launch template of the AWSMachinePool, if known.
+func launchTemplateImageID(awsMachinePool *expinfrav1.AWSMachinePool) string {
+	if awsMachinePool.Spec.AWSLaunchTemplate.AMI.ID != nil {
+		return *awsMachinePool.Spec.AWSLaunchTemplate.AMI.ID
+	}
+	if awsMachinePool.Status.ResolvedAMI != nil {
+		return awsMachinePool.Status.ResolvedAMI.ID
+	}
+	return ""
+}
+
 // getOwnerMachinePool returns the MachinePool object owning the current resource.
 func getOwnerMachinePool(ctx context.Context, c client.Client, obj metav1.ObjectMeta) (*expclusterv1.MachinePool, error) {
 	for _, ref := range obj.OwnerReferences {
@@ -22,6 +22,7 @@ import (
 	"flag"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/golang/mock/gomock"
@@ -371,6 +372,8 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 				asgSvc.EXPECT().UpdateASG(gomock.Any()).Return(nil).AnyTimes()
 				asgSvc.EXPECT().SuspendProcesses("name", []string{"Terminate"}).Return(nil).AnyTimes()
 				asgSvc.EXPECT().ResumeProcesses("name", []string{"process3"}).Return(nil).AnyTimes()
+				asgSvc.EXPECT().DescribeLifecycleHooks(gomock.Any()).Return(nil, nil).AnyTimes()
+				asgSvc.EXPECT().GetLatestASGInstanceRefresh(gomock.Any()).Return(nil, nil).AnyTimes()
 
 				_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
 				g.Expect(err).To(Succeed())
@@ -388,6 +391,8 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 			}
 			asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(&asg, nil).AnyTimes()
 			asgSvc.EXPECT().UpdateASG(gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().DescribeLifecycleHooks(gomock.Any()).Return(nil, nil).AnyTimes()
+			asgSvc.EXPECT().GetLatestASGInstanceRefresh(gomock.Any()).Return(nil, nil).AnyTimes()
 			ec2Svc.EXPECT().GetLaunchTemplate(gomock.Any()).Return(nil, "", nil).AnyTimes()
 			ec2Svc.EXPECT().DiscoverLaunchTemplateAMI(gomock.Any()).Return(nil, nil).AnyTimes()
 			ec2Svc.EXPECT().CreateLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
@@ -610,6 +615,35 @@ func TestASGNeedsUpdates(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "minSize != asg.minSize set by a scheduled action",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: pointer.Int32(1),
+						},
+					},
+					AWSMachinePool: &expinfrav1.AWSMachinePool{
+						Spec: expinfrav1.AWSMachinePoolSpec{
+							MaxSize: 2,
+							MinSize: 1,
+						},
+						Status: expinfrav1.AWSMachinePoolStatus{
+							ScheduledCapacity: &expinfrav1.AWSMachinePoolScheduledCapacity{
+								MinSize: pointer.Int32(0),
+							},
+						},
+					},
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity: pointer.Int32(1),
+					MaxSize:         2,
+					MinSize:         0,
+				},
+			},
+			want: false,
+		},
 		{
 			name: "capacityRebalance != asg.capacityRebalance",
 			args: args{
@@ -800,3 +834,755 @@ func TestASGNeedsUpdates(t *testing.T) {
 		})
 	}
 }
+
+func TestWarmPoolNeedsUpdates(t *testing.T) {
+	tests := []struct {
+		name     string
+		incoming *expinfrav1.WarmPool
+		existing *expinfrav1.WarmPool
+		want     bool
+	}{
+		{
+			name:     "should return true if the ASG has no warm pool",
+			incoming: &expinfrav1.WarmPool{},
+			existing: nil,
+			want:     true,
+		},
+		{
+			name:     "should return false if unset fields match the AWS defaults",
+			incoming: &expinfrav1.WarmPool{},
+			existing: &expinfrav1.WarmPool{
+				MinSize:   pointer.Int32(0),
+				PoolState: expinfrav1.WarmPoolStateStopped,
+			},
+			want: false,
+		},
+		{
+			name: "should return true if the maximum prepared capacity differs",
+			incoming: &expinfrav1.WarmPool{
+				MaxGroupPreparedCapacity: pointer.Int32(5),
+			},
+			existing: &expinfrav1.WarmPool{
+				MinSize:   pointer.Int32(0),
+				PoolState: expinfrav1.WarmPoolStateStopped,
+			},
+			want: true,
+		},
+		{
+			name: "should return true if the pool state differs",
+			incoming: &expinfrav1.WarmPool{
+				PoolState: expinfrav1.WarmPoolStateRunning,
+			},
+			existing: &expinfrav1.WarmPool{
+				MinSize:   pointer.Int32(0),
+				PoolState: expinfrav1.WarmPoolStateStopped,
+			},
+			want: true,
+		},
+		{
+			name: "should return true if the instance reuse policy differs",
+			incoming: &expinfrav1.WarmPool{
+				ReuseOnScaleIn: true,
+			},
+			existing: &expinfrav1.WarmPool{
+				MinSize:   pointer.Int32(0),
+				PoolState: expinfrav1.WarmPoolStateStopped,
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(warmPoolNeedsUpdates(tt.incoming, tt.existing)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestLifecycleHookNeedsUpdate(t *testing.T) {
+	continueResult := expinfrav1.LifecycleHookDefaultResultContinue
+	abandonResult := expinfrav1.LifecycleHookDefaultResultAbandon
+	tests := []struct {
+		name     string
+		incoming *expinfrav1.AWSLifecycleHook
+		existing *expinfrav1.AWSLifecycleHook
+		want     bool
+	}{
+		{
+			name: "should return false if unset fields match the AWS defaults",
+			incoming: &expinfrav1.AWSLifecycleHook{
+				Name:                "hook",
+				LifecycleTransition: expinfrav1.LifecycleTransitionInstanceTerminating,
+			},
+			existing: &expinfrav1.AWSLifecycleHook{
+				Name:                "hook",
+				LifecycleTransition: expinfrav1.LifecycleTransitionInstanceTerminating,
+				HeartbeatTimeout:    &metav1.Duration{Duration: time.Hour},
+				DefaultResult:       &abandonResult,
+			},
+			want: false,
+		},
+		{
+			name: "should return true if the lifecycle transition differs",
+			incoming: &expinfrav1.AWSLifecycleHook{
+				Name:                "hook",
+				LifecycleTransition: expinfrav1.LifecycleTransitionInstanceLaunching,
+			},
+			existing: &expinfrav1.AWSLifecycleHook{
+				Name:                "hook",
+				LifecycleTransition: expinfrav1.LifecycleTransitionInstanceTerminating,
+			},
+			want: true,
+		},
+		{
+			name: "should return true if the heartbeat timeout differs",
+			incoming: &expinfrav1.AWSLifecycleHook{
+				Name:                "hook",
+				LifecycleTransition: expinfrav1.LifecycleTransitionInstanceTerminating,
+				HeartbeatTimeout:    &metav1.Duration{Duration: 5 * time.Minute},
+			},
+			existing: &expinfrav1.AWSLifecycleHook{
+				Name:                "hook",
+				LifecycleTransition: expinfrav1.LifecycleTransitionInstanceTerminating,
+				HeartbeatTimeout:    &metav1.Duration{Duration: time.Hour},
+			},
+			want: true,
+		},
+		{
+			name: "should return true if the default result differs",
+			incoming: &expinfrav1.AWSLifecycleHook{
+				Name:                "hook",
+				LifecycleTransition: expinfrav1.LifecycleTransitionInstanceTerminating,
+				DefaultResult:       &continueResult,
+			},
+			existing: &expinfrav1.AWSLifecycleHook{
+				Name:                "hook",
+				LifecycleTransition: expinfrav1.LifecycleTransitionInstanceTerminating,
+				DefaultResult:       &abandonResult,
+			},
+			want: true,
+		},
+		{
+			name: "should return true if the notification target differs",
+			incoming: &expinfrav1.AWSLifecycleHook{
+				Name:                  "hook",
+				LifecycleTransition:   expinfrav1.LifecycleTransitionInstanceTerminating,
+				NotificationTargetARN: pointer.String("arn:aws:sns:us-east-1:123456789012:topic"),
+				RoleARN:               pointer.String("arn:aws:iam::123456789012:role/hooks"),
+			},
+			existing: &expinfrav1.AWSLifecycleHook{
+				Name:                "hook",
+				LifecycleTransition: expinfrav1.LifecycleTransitionInstanceTerminating,
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(lifecycleHookNeedsUpdate(tt.incoming, tt.existing)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestInstanceRefreshNewlyFailed(t *testing.T) {
+	inProgress := &expinfrav1.AWSMachinePoolInstanceRefreshStatus{ID: "refresh-1", Status: "InProgress"}
+	failed := &expinfrav1.AWSMachinePoolInstanceRefreshStatus{ID: "refresh-1", Status: "Failed"}
+	cancelling := &expinfrav1.AWSMachinePoolInstanceRefreshStatus{ID: "refresh-1", Status: "Cancelling"}
+	cancelled := &expinfrav1.AWSMachinePoolInstanceRefreshStatus{ID: "refresh-1", Status: "Cancelled"}
+	tests := []struct {
+		name        string
+		previous    *expinfrav1.AWSMachinePoolInstanceRefreshStatus
+		current     *expinfrav1.AWSMachinePoolInstanceRefreshStatus
+		cancelledID string
+		want        bool
+	}{
+		{
+			name:     "should return true if an observed instance refresh failed",
+			previous: inProgress,
+			current:  failed,
+			want:     true,
+		},
+		{
+			name:     "should return true if a new instance refresh failed",
+			previous: &expinfrav1.AWSMachinePoolInstanceRefreshStatus{ID: "refresh-0", Status: "Failed"},
+			current:  failed,
+			want:     true,
+		},
+		{
+			name:     "should return false if the failure was already handled",
+			previous: failed,
+			current:  failed,
+			want:     false,
+		},
+		{
+			name:     "should return false if the instance refresh status wasn't recorded yet",
+			previous: nil,
+			current:  failed,
+			want:     false,
+		},
+		{
+			name:     "should return false if the instance refresh didn't fail",
+			previous: inProgress,
+			current:  &expinfrav1.AWSMachinePoolInstanceRefreshStatus{ID: "refresh-1", Status: "Successful"},
+			want:     false,
+		},
+		{
+			name:        "should return true if an instance refresh cancelled because it was failing ended",
+			previous:    cancelling,
+			current:     cancelled,
+			cancelledID: "refresh-1",
+			want:        true,
+		},
+		{
+			name:        "should return false if an instance refresh cancelled because it was failing didn't end yet",
+			previous:    inProgress,
+			current:     cancelling,
+			cancelledID: "refresh-1",
+			want:        false,
+		},
+		{
+			name:        "should return false if the cancelled instance refresh was already handled",
+			previous:    cancelled,
+			current:     cancelled,
+			cancelledID: "refresh-1",
+			want:        false,
+		},
+		{
+			name:     "should return false if the instance refresh was cancelled by someone else",
+			previous: cancelling,
+			current:  cancelled,
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(instanceRefreshNewlyFailed(tt.previous, tt.current, tt.cancelledID)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestReconcileInstanceRefresh(t *testing.T) {
+	const generation = 2
+	inProgress := &expinfrav1.AWSMachinePoolInstanceRefreshStatus{ID: "refresh-1", Status: "InProgress"}
+	failed := &expinfrav1.AWSMachinePoolInstanceRefreshStatus{ID: "refresh-1", Status: "Failed", StatusReason: "instances failed health checks"}
+	failing := &expinfrav1.AWSMachinePoolInstanceRefreshStatus{ID: "refresh-1", Status: "InProgress", StatusReason: "Instances failed health checks at checkpoint"}
+	cancelled := &expinfrav1.AWSMachinePoolInstanceRefreshStatus{ID: "refresh-1", Status: "Cancelled"}
+	rollbackFailed := &expinfrav1.AWSMachinePoolInstanceRefreshStatus{ID: "refresh-1", Status: "RollbackFailed"}
+	rollbackOnFailure := &expinfrav1.RefreshPreferences{RollbackOnFailure: true}
+
+	tests := []struct {
+		name                            string
+		refreshPreferences              *expinfrav1.RefreshPreferences
+		rolledBackGeneration            *int64
+		rolledBackInstanceRefreshID     *string
+		cancelledInstanceRefreshID      *string
+		expect                          func(ec2 *mock_services.MockEC2InterfaceMockRecorder, asg *mock_services.MockASGInterfaceMockRecorder)
+		wantErr                         bool
+		wantInstanceRefresh             *expinfrav1.AWSMachinePoolInstanceRefreshStatus
+		wantLaunchTemplateVersion       *string
+		wantRolledBackGeneration        *int64
+		wantRolledBackInstanceRefreshID *string
+		wantCancelledInstanceRefreshID  *string
+	}{
+		{
+			name: "should record the instance refresh in progress",
+			expect: func(ec2 *mock_services.MockEC2InterfaceMockRecorder, asg *mock_services.MockASGInterfaceMockRecorder) {
+				asg.GetLatestASGInstanceRefresh("test").Return(inProgress, nil)
+			},
+			wantInstanceRefresh: inProgress,
+		},
+		{
+			name: "should record a failed instance refresh without rolling back the launch template",
+			expect: func(ec2 *mock_services.MockEC2InterfaceMockRecorder, asg *mock_services.MockASGInterfaceMockRecorder) {
+				asg.GetLatestASGInstanceRefresh("test").Return(failed, nil)
+			},
+			wantInstanceRefresh: failed,
+		},
+		{
+			name:               "should roll back the launch template and restart the instance refresh",
+			refreshPreferences: rollbackOnFailure,
+			expect: func(ec2 *mock_services.MockEC2InterfaceMockRecorder, asg *mock_services.MockASGInterfaceMockRecorder) {
+				asg.GetLatestASGInstanceRefresh("test").Return(failed, nil)
+				ec2.RollbackLaunchTemplateVersion("lt-1").Return("1", nil)
+				asg.StartASGInstanceRefresh(gomock.Any()).Return(nil)
+			},
+			wantInstanceRefresh:             failed,
+			wantLaunchTemplateVersion:       pointer.String("1"),
+			wantRolledBackGeneration:        pointer.Int64(generation),
+			wantRolledBackInstanceRefreshID: pointer.String("refresh-1"),
+		},
+		{
+			name:               "should roll back the launch template without restarting the instance refresh if disabled",
+			refreshPreferences: &expinfrav1.RefreshPreferences{RollbackOnFailure: true, Disable: true},
+			expect: func(ec2 *mock_services.MockEC2InterfaceMockRecorder, asg *mock_services.MockASGInterfaceMockRecorder) {
+				asg.GetLatestASGInstanceRefresh("test").Return(failed, nil)
+				ec2.RollbackLaunchTemplateVersion("lt-1").Return("1", nil)
+			},
+			wantInstanceRefresh:             failed,
+			wantLaunchTemplateVersion:       pointer.String("1"),
+			wantRolledBackGeneration:        pointer.Int64(generation),
+			wantRolledBackInstanceRefreshID: pointer.String("refresh-1"),
+		},
+		{
+			name:                        "should not roll back a generation that was already rolled back",
+			refreshPreferences:          rollbackOnFailure,
+			rolledBackGeneration:        pointer.Int64(generation),
+			rolledBackInstanceRefreshID: pointer.String("refresh-0"),
+			expect: func(ec2 *mock_services.MockEC2InterfaceMockRecorder, asg *mock_services.MockASGInterfaceMockRecorder) {
+				asg.GetLatestASGInstanceRefresh("test").Return(failed, nil)
+			},
+			wantInstanceRefresh:             failed,
+			wantRolledBackGeneration:        pointer.Int64(generation),
+			wantRolledBackInstanceRefreshID: pointer.String("refresh-0"),
+		},
+		{
+			name:                        "should retry restarting the instance refresh after the launch template was rolled back",
+			refreshPreferences:          rollbackOnFailure,
+			rolledBackGeneration:        pointer.Int64(generation),
+			rolledBackInstanceRefreshID: pointer.String("refresh-1"),
+			expect: func(ec2 *mock_services.MockEC2InterfaceMockRecorder, asg *mock_services.MockASGInterfaceMockRecorder) {
+				asg.GetLatestASGInstanceRefresh("test").Return(failed, nil)
+				asg.StartASGInstanceRefresh(gomock.Any()).Return(nil)
+			},
+			wantInstanceRefresh:             failed,
+			wantRolledBackGeneration:        pointer.Int64(generation),
+			wantRolledBackInstanceRefreshID: pointer.String("refresh-1"),
+		},
+		{
+			name:               "should cancel a failing instance refresh without recording it",
+			refreshPreferences: rollbackOnFailure,
+			expect: func(ec2 *mock_services.MockEC2InterfaceMockRecorder, asg *mock_services.MockASGInterfaceMockRecorder) {
+				asg.GetLatestASGInstanceRefresh("test").Return(failing, nil)
+				asg.CancelASGInstanceRefresh("test").Return(nil)
+			},
+			wantInstanceRefresh:            inProgress,
+			wantCancelledInstanceRefreshID: pointer.String("refresh-1"),
+		},
+		{
+			name:               "should cancel an instance refresh whose rollback failed",
+			refreshPreferences: rollbackOnFailure,
+			expect: func(ec2 *mock_services.MockEC2InterfaceMockRecorder, asg *mock_services.MockASGInterfaceMockRecorder) {
+				asg.GetLatestASGInstanceRefresh("test").Return(rollbackFailed, nil)
+				asg.CancelASGInstanceRefresh("test").Return(nil)
+			},
+			wantInstanceRefresh:            inProgress,
+			wantCancelledInstanceRefreshID: pointer.String("refresh-1"),
+		},
+		{
+			name:                       "should not cancel a failing instance refresh again",
+			cancelledInstanceRefreshID: pointer.String("refresh-1"),
+			expect: func(ec2 *mock_services.MockEC2InterfaceMockRecorder, asg *mock_services.MockASGInterfaceMockRecorder) {
+				asg.GetLatestASGInstanceRefresh("test").Return(failing, nil)
+			},
+			wantInstanceRefresh:            failing,
+			wantCancelledInstanceRefreshID: pointer.String("refresh-1"),
+		},
+		{
+			name:               "should not record the failing instance refresh if it can't be cancelled",
+			refreshPreferences: rollbackOnFailure,
+			expect: func(ec2 *mock_services.MockEC2InterfaceMockRecorder, asg *mock_services.MockASGInterfaceMockRecorder) {
+				asg.GetLatestASGInstanceRefresh("test").Return(failing, nil)
+				asg.CancelASGInstanceRefresh("test").Return(errors.New("an error"))
+			},
+			wantErr:             true,
+			wantInstanceRefresh: inProgress,
+		},
+		{
+			name:                       "should roll back the launch template once the cancelled instance refresh ended",
+			refreshPreferences:         rollbackOnFailure,
+			cancelledInstanceRefreshID: pointer.String("refresh-1"),
+			expect: func(ec2 *mock_services.MockEC2InterfaceMockRecorder, asg *mock_services.MockASGInterfaceMockRecorder) {
+				asg.GetLatestASGInstanceRefresh("test").Return(cancelled, nil)
+				ec2.RollbackLaunchTemplateVersion("lt-1").Return("1", nil)
+				asg.StartASGInstanceRefresh(gomock.Any()).Return(nil)
+			},
+			wantInstanceRefresh:             cancelled,
+			wantLaunchTemplateVersion:       pointer.String("1"),
+			wantRolledBackGeneration:        pointer.Int64(generation),
+			wantRolledBackInstanceRefreshID: pointer.String("refresh-1"),
+			wantCancelledInstanceRefreshID:  pointer.String("refresh-1"),
+		},
+		{
+			name:               "should not roll back the launch template for an instance refresh cancelled by someone else",
+			refreshPreferences: rollbackOnFailure,
+			expect: func(ec2 *mock_services.MockEC2InterfaceMockRecorder, asg *mock_services.MockASGInterfaceMockRecorder) {
+				asg.GetLatestASGInstanceRefresh("test").Return(cancelled, nil)
+			},
+			wantInstanceRefresh: cancelled,
+		},
+		{
+			name: "should fail if the instance refresh can't be described",
+			expect: func(ec2 *mock_services.MockEC2InterfaceMockRecorder, asg *mock_services.MockASGInterfaceMockRecorder) {
+				asg.GetLatestASGInstanceRefresh("test").Return(nil, errors.New("an error"))
+			},
+			wantErr:             true,
+			wantInstanceRefresh: inProgress,
+		},
+		{
+			name:               "should not record the failed instance refresh if the launch template can't be rolled back",
+			refreshPreferences: rollbackOnFailure,
+			expect: func(ec2 *mock_services.MockEC2InterfaceMockRecorder, asg *mock_services.MockASGInterfaceMockRecorder) {
+				asg.GetLatestASGInstanceRefresh("test").Return(failed, nil)
+				ec2.RollbackLaunchTemplateVersion("lt-1").Return("", errors.New("an error"))
+			},
+			wantErr:             true,
+			wantInstanceRefresh: inProgress,
+		},
+		{
+			name:               "should not record the failed instance refresh if the instance refresh can't be restarted",
+			refreshPreferences: rollbackOnFailure,
+			expect: func(ec2 *mock_services.MockEC2InterfaceMockRecorder, asg *mock_services.MockASGInterfaceMockRecorder) {
+				asg.GetLatestASGInstanceRefresh("test").Return(failed, nil)
+				ec2.RollbackLaunchTemplateVersion("lt-1").Return("1", nil)
+				asg.StartASGInstanceRefresh(gomock.Any()).Return(errors.New("an error"))
+			},
+			wantErr:                         true,
+			wantInstanceRefresh:             inProgress,
+			wantLaunchTemplateVersion:       pointer.String("1"),
+			wantRolledBackGeneration:        pointer.Int64(generation),
+			wantRolledBackInstanceRefreshID: pointer.String("refresh-1"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			ec2Svc := mock_services.NewMockEC2Interface(mockCtrl)
+			asgSvc := mock_services.NewMockASGInterface(mockCtrl)
+
+			awsMachinePool := &expinfrav1.AWSMachinePool{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", Generation: generation},
+				Spec:       expinfrav1.AWSMachinePoolSpec{RefreshPreferences: tt.refreshPreferences},
+				Status: expinfrav1.AWSMachinePoolStatus{
+					LaunchTemplateID:            "lt-1",
+					InstanceRefresh:             inProgress,
+					RolledBackGeneration:        tt.rolledBackGeneration,
+					RolledBackInstanceRefreshID: tt.rolledBackInstanceRefreshID,
+					CancelledInstanceRefreshID:  tt.cancelledInstanceRefreshID,
+				},
+			}
+			machinePoolScope := newTestMachinePoolScope(g, awsMachinePool)
+
+			tt.expect(ec2Svc.EXPECT(), asgSvc.EXPECT())
+			reconciler := AWSMachinePoolReconciler{Recorder: record.NewFakeRecorder(10)}
+
+			err := reconciler.reconcileInstanceRefresh(machinePoolScope, ec2Svc, asgSvc)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(awsMachinePool.Status.InstanceRefresh).To(Equal(tt.wantInstanceRefresh))
+			g.Expect(awsMachinePool.Status.LaunchTemplateVersion).To(Equal(tt.wantLaunchTemplateVersion))
+			g.Expect(awsMachinePool.Status.RolledBackGeneration).To(Equal(tt.wantRolledBackGeneration))
+			g.Expect(awsMachinePool.Status.RolledBackInstanceRefreshID).To(Equal(tt.wantRolledBackInstanceRefreshID))
+			g.Expect(awsMachinePool.Status.CancelledInstanceRefreshID).To(Equal(tt.wantCancelledInstanceRefreshID))
+		})
+	}
+}
+
+// newTestMachinePoolScope returns a machine pool scope for the AWSMachinePool, backed by a fake client.
+func newTestMachinePoolScope(g *WithT, awsMachinePool *expinfrav1.AWSMachinePool) *scope.MachinePoolScope {
+	scheme := runtime.NewScheme()
+	_ = expinfrav1.AddToScheme(scheme)
+	_ = expclusterv1.AddToScheme(scheme)
+	machinePool := &expclusterv1.MachinePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "mp", Namespace: "default"},
+		Spec:       expclusterv1.MachinePoolSpec{Replicas: pointer.Int32(1)},
+	}
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(machinePool.DeepCopy()).Build()
+
+	cs, err := setupCluster("test-cluster")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	machinePoolScope, err := scope.NewMachinePoolScope(scope.MachinePoolScopeParams{
+		Client:         client,
+		Cluster:        &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default"}},
+		MachinePool:    machinePool,
+		InfraCluster:   cs,
+		AWSMachinePool: awsMachinePool,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	return machinePoolScope
+}
+
+func TestScheduledActionNeedsUpdate(t *testing.T) {
+	startTime := metav1.NewTime(time.Date(2022, 12, 1, 8, 0, 0, 0, time.UTC))
+	nextRun := metav1.NewTime(time.Date(2022, 12, 5, 20, 0, 0, 0, time.UTC))
+	tests := []struct {
+		name     string
+		incoming *expinfrav1.AWSScheduledAction
+		existing *expinfrav1.AWSScheduledAction
+		want     bool
+	}{
+		{
+			name: "should return false if the scheduled actions match",
+			incoming: &expinfrav1.AWSScheduledAction{
+				Name:            "scale-up",
+				StartTime:       &startTime,
+				DesiredCapacity: pointer.Int32(3),
+			},
+			existing: &expinfrav1.AWSScheduledAction{
+				Name:            "scale-up",
+				StartTime:       &startTime,
+				DesiredCapacity: pointer.Int32(3),
+			},
+			want: false,
+		},
+		{
+			name: "should return false if only the next run of a recurring action differs",
+			incoming: &expinfrav1.AWSScheduledAction{
+				Name:            "scale-down",
+				Recurrence:      pointer.String("0 20 * * 1-5"),
+				StartTime:       &startTime,
+				DesiredCapacity: pointer.Int32(0),
+			},
+			existing: &expinfrav1.AWSScheduledAction{
+				Name:            "scale-down",
+				Recurrence:      pointer.String("0 20 * * 1-5"),
+				StartTime:       &nextRun,
+				DesiredCapacity: pointer.Int32(0),
+			},
+			want: false,
+		},
+		{
+			name: "should return true if the start time of a one-time action differs",
+			incoming: &expinfrav1.AWSScheduledAction{
+				Name:            "scale-up",
+				StartTime:       &nextRun,
+				DesiredCapacity: pointer.Int32(3),
+			},
+			existing: &expinfrav1.AWSScheduledAction{
+				Name:            "scale-up",
+				StartTime:       &startTime,
+				DesiredCapacity: pointer.Int32(3),
+			},
+			want: true,
+		},
+		{
+			name: "should return true if the recurrence differs",
+			incoming: &expinfrav1.AWSScheduledAction{
+				Name:            "scale-down",
+				Recurrence:      pointer.String("0 21 * * 1-5"),
+				DesiredCapacity: pointer.Int32(0),
+			},
+			existing: &expinfrav1.AWSScheduledAction{
+				Name:            "scale-down",
+				Recurrence:      pointer.String("0 20 * * 1-5"),
+				DesiredCapacity: pointer.Int32(0),
+			},
+			want: true,
+		},
+		{
+			name: "should return true if the sizes differ",
+			incoming: &expinfrav1.AWSScheduledAction{
+				Name:            "scale-down",
+				Recurrence:      pointer.String("0 20 * * 1-5"),
+				MinSize:         pointer.Int32(0),
+				DesiredCapacity: pointer.Int32(0),
+			},
+			existing: &expinfrav1.AWSScheduledAction{
+				Name:            "scale-down",
+				Recurrence:      pointer.String("0 20 * * 1-5"),
+				DesiredCapacity: pointer.Int32(0),
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(scheduledActionNeedsUpdate(tt.incoming, tt.existing)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestScheduledActionExpired(t *testing.T) {
+	now := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	past := metav1.NewTime(now.Add(-time.Hour))
+	future := metav1.NewTime(now.Add(time.Hour))
+	tests := []struct {
+		name   string
+		action *expinfrav1.AWSScheduledAction
+		want   bool
+	}{
+		{
+			name:   "should return true for a one-time action that already ran",
+			action: &expinfrav1.AWSScheduledAction{Name: "action", StartTime: &past},
+			want:   true,
+		},
+		{
+			name:   "should return false for a one-time action that didn't run yet",
+			action: &expinfrav1.AWSScheduledAction{Name: "action", StartTime: &future},
+			want:   false,
+		},
+		{
+			name:   "should return false for a recurring action that already started",
+			action: &expinfrav1.AWSScheduledAction{Name: "action", Recurrence: pointer.String("0 20 * * *"), StartTime: &past},
+			want:   false,
+		},
+		{
+			name:   "should return true for a recurring action that ended",
+			action: &expinfrav1.AWSScheduledAction{Name: "action", Recurrence: pointer.String("0 20 * * *"), EndTime: &past},
+			want:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(scheduledActionExpired(tt.action, now)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestReconcileScheduledCapacity(t *testing.T) {
+	scheduledActions := []expinfrav1.AWSScheduledAction{{Name: "scale-down", Recurrence: pointer.String("0 20 * * *"), MinSize: pointer.Int32(0), DesiredCapacity: pointer.Int32(0)}}
+	observed := &expinfrav1.AWSMachinePoolCapacity{MinSize: 1, MaxSize: 10, DesiredCapacity: 3}
+
+	tests := []struct {
+		name                  string
+		scheduledActions      []expinfrav1.AWSScheduledAction
+		minSize               int32
+		replicas              int32
+		scheduledCapacity     *expinfrav1.AWSMachinePoolScheduledCapacity
+		asg                   *expinfrav1.AutoScalingGroup
+		wantReplicas          int32
+		wantScheduledCapacity *expinfrav1.AWSMachinePoolScheduledCapacity
+	}{
+		{
+			name:             "should adopt the desired capacity set by a scheduled action into the MachinePool replicas",
+			scheduledActions: scheduledActions,
+			minSize:          1,
+			replicas:         3,
+			asg:              &expinfrav1.AutoScalingGroup{MinSize: 1, MaxSize: 10, DesiredCapacity: pointer.Int32(5)},
+			wantReplicas:     5,
+		},
+		{
+			name:             "should keep the MachinePool replicas changed since the last reconciliation",
+			scheduledActions: scheduledActions,
+			minSize:          1,
+			replicas:         4,
+			asg:              &expinfrav1.AutoScalingGroup{MinSize: 1, MaxSize: 10, DesiredCapacity: pointer.Int32(5)},
+			wantReplicas:     4,
+		},
+		{
+			name:                  "should record the minimum size set by a scheduled action without changing the AWSMachinePool",
+			scheduledActions:      scheduledActions,
+			minSize:               1,
+			replicas:              3,
+			asg:                   &expinfrav1.AutoScalingGroup{MinSize: 0, MaxSize: 10, DesiredCapacity: pointer.Int32(3)},
+			wantReplicas:          3,
+			wantScheduledCapacity: &expinfrav1.AWSMachinePoolScheduledCapacity{MinSize: pointer.Int32(0)},
+		},
+		{
+			name:              "should drop the minimum size set by a scheduled action once minSize changed on the AWSMachinePool",
+			scheduledActions:  scheduledActions,
+			minSize:           2,
+			replicas:          3,
+			scheduledCapacity: &expinfrav1.AWSMachinePoolScheduledCapacity{MinSize: pointer.Int32(0)},
+			asg:               &expinfrav1.AutoScalingGroup{MinSize: 0, MaxSize: 10, DesiredCapacity: pointer.Int32(3)},
+			wantReplicas:      3,
+		},
+		{
+			name:              "should drop the scheduled capacity once the scheduled actions are removed",
+			minSize:           1,
+			replicas:          3,
+			scheduledCapacity: &expinfrav1.AWSMachinePoolScheduledCapacity{MinSize: pointer.Int32(0)},
+			asg:               &expinfrav1.AutoScalingGroup{MinSize: 0, MaxSize: 10, DesiredCapacity: pointer.Int32(5)},
+			wantReplicas:      3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			awsMachinePool := &expinfrav1.AWSMachinePool{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				Spec: expinfrav1.AWSMachinePoolSpec{
+					MinSize:          tt.minSize,
+					MaxSize:          10,
+					ScheduledActions: tt.scheduledActions,
+				},
+				Status: expinfrav1.AWSMachinePoolStatus{
+					ObservedCapacity:  observed,
+					ScheduledCapacity: tt.scheduledCapacity,
+				},
+			}
+			machinePoolScope := newTestMachinePoolScope(g, awsMachinePool)
+			machinePoolScope.MachinePool.Spec.Replicas = pointer.Int32(tt.replicas)
+			reconciler := AWSMachinePoolReconciler{Recorder: record.NewFakeRecorder(10)}
+
+			g.Expect(reconciler.reconcileScheduledCapacity(context.Background(), machinePoolScope, tt.asg)).To(Succeed())
+			g.Expect(*machinePoolScope.MachinePool.Spec.Replicas).To(Equal(tt.wantReplicas))
+			g.Expect(awsMachinePool.Status.ScheduledCapacity).To(Equal(tt.wantScheduledCapacity))
+			g.Expect(awsMachinePool.Spec.MinSize).To(Equal(tt.minSize))
+			g.Expect(awsMachinePool.Spec.MaxSize).To(Equal(int32(10)))
+		})
+	}
+}
+
+func TestUpdatePoolObservedCapacity(t *testing.T) {
+	tests := []struct {
+		name                 string
+		scheduledActions     []expinfrav1.AWSScheduledAction
+		expect               func(m *mock_services.MockASGInterfaceMockRecorder)
+		wantObservedCapacity *expinfrav1.AWSMachinePoolCapacity
+	}{
+		{
+			name:             "should record the size of the AWSMachinePool with scheduled actions",
+			scheduledActions: []expinfrav1.AWSScheduledAction{{Name: "scale-down", Recurrence: pointer.String("0 20 * * *"), DesiredCapacity: pointer.Int32(0)}},
+			expect: func(m *mock_services.MockASGInterfaceMockRecorder) {
+				m.DescribeScheduledActions("test").Return([]*expinfrav1.AWSScheduledAction{
+					{Name: "scale-down", Recurrence: pointer.String("0 20 * * *"), DesiredCapacity: pointer.Int32(0)},
+				}, nil)
+			},
+			wantObservedCapacity: &expinfrav1.AWSMachinePoolCapacity{MinSize: 1, MaxSize: 10, DesiredCapacity: 3},
+		},
+		{
+			name: "should clear the observed capacity once all scheduled actions are removed",
+			expect: func(m *mock_services.MockASGInterfaceMockRecorder) {
+				m.DescribeScheduledActions("test").Return([]*expinfrav1.AWSScheduledAction{{Name: "scale-down"}}, nil)
+				m.DeleteScheduledAction("test", "scale-down").Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			asgSvc := mock_services.NewMockASGInterface(mockCtrl)
+
+			awsMachinePool := &expinfrav1.AWSMachinePool{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				Spec: expinfrav1.AWSMachinePoolSpec{
+					MinSize:          1,
+					MaxSize:          10,
+					ScheduledActions: tt.scheduledActions,
+				},
+				Status: expinfrav1.AWSMachinePoolStatus{
+					ObservedCapacity: &expinfrav1.AWSMachinePoolCapacity{MinSize: 1, MaxSize: 10, DesiredCapacity: 2},
+				},
+			}
+			machinePoolScope := newTestMachinePoolScope(g, awsMachinePool)
+			machinePoolScope.MachinePool.Spec.Replicas = pointer.Int32(3)
+
+			asgSvc.EXPECT().DescribeLifecycleHooks("test").Return(nil, nil)
+			tt.expect(asgSvc.EXPECT())
+			reconciler := AWSMachinePoolReconciler{
+				asgServiceFactory: func(cloud.ClusterScoper) services.ASGInterface {
+					return asgSvc
+				},
+				Recorder: record.NewFakeRecorder(10),
+			}
+
+			asg := &expinfrav1.AutoScalingGroup{Name: "test", MinSize: 1, MaxSize: 10, DesiredCapacity: pointer.Int32(3)}
+			g.Expect(reconciler.updatePool(machinePoolScope, machinePoolScope.InfraCluster, asg)).To(Succeed())
+			g.Expect(awsMachinePool.Status.ObservedCapacity).To(Equal(tt.wantObservedCapacity))
+		})
+	}
+}
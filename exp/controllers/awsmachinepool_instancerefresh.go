@@ -0,0 +1,169 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+)
+
+// instanceRefreshRequeueAfter is how often the status of an instance refresh in progress is updated, as the ASG
+// doesn't notify the controller of its progress.
+const instanceRefreshRequeueAfter = 30 * time.Second
+
+// Instance refresh statuses of auto rollbacks, which the AWS SDK doesn't define yet.
+const (
+	instanceRefreshStatusRollbackInProgress = "RollbackInProgress"
+	instanceRefreshStatusRollbackFailed     = "RollbackFailed"
+)
+
+// instanceRefreshFailureReasons are fragments of the status reason the ASG reports while an instance refresh in
+// progress is held up by failing instances, e.g. by instances failing their health checks at a checkpoint.
+var instanceRefreshFailureReasons = []string{"failed", "unhealthy"}
+
+// reconcileInstanceRefresh updates the AWSMachinePool status with the most recent instance refresh of the ASG.
+// When the instance refresh failed, the launch template is optionally rolled back and the instances already
+// replaced are refreshed again. The ASG stops a failed instance refresh by itself, but an instance refresh that
+// is failing without having ended yet is cancelled first, and handled as failed once it is cancelled.
+// The status only records a failed instance refresh once the failure is handled, so that the handling is
+// retried on errors.
+func (r *AWSMachinePoolReconciler) reconcileInstanceRefresh(machinePoolScope *scope.MachinePoolScope, ec2Svc services.EC2Interface, asgSvc services.ASGInterface) error {
+	refresh, err := asgSvc.GetLatestASGInstanceRefresh(machinePoolScope.Name())
+	if err != nil {
+		return err
+	}
+
+	awsMachinePool := machinePoolScope.AWSMachinePool
+	if instanceRefreshNewlyFailing(awsMachinePool, refresh) {
+		r.Recorder.Eventf(awsMachinePool, corev1.EventTypeWarning, "FailingInstanceRefresh", "Cancelling failing instance refresh %q: %s", refresh.ID, refresh.StatusReason)
+		if err := asgSvc.CancelASGInstanceRefresh(machinePoolScope.Name()); err != nil {
+			return err
+		}
+		// The status isn't updated, so that the cancelled instance refresh is handled as failed once it ended.
+		awsMachinePool.Status.CancelledInstanceRefreshID = pointer.String(refresh.ID)
+		return nil
+	}
+
+	if !instanceRefreshNewlyFailed(awsMachinePool.Status.InstanceRefresh, refresh, pointer.StringDeref(awsMachinePool.Status.CancelledInstanceRefreshID, "")) {
+		awsMachinePool.Status.InstanceRefresh = refresh
+		return nil
+	}
+
+	r.Recorder.Eventf(awsMachinePool, corev1.EventTypeWarning, "FailedInstanceRefresh", "Instance refresh %q failed: %s", refresh.ID, refresh.StatusReason)
+	if awsMachinePool.Spec.RefreshPreferences == nil || !awsMachinePool.Spec.RefreshPreferences.RollbackOnFailure {
+		awsMachinePool.Status.InstanceRefresh = refresh
+		return nil
+	}
+
+	switch {
+	case !launchTemplateRolledBack(awsMachinePool):
+		version, err := ec2Svc.RollbackLaunchTemplateVersion(machinePoolScope.GetLaunchTemplateIDStatus())
+		if err != nil {
+			r.Recorder.Eventf(awsMachinePool, corev1.EventTypeWarning, "FailedRollbackLaunchTemplate", "Failed to roll back launch template after failed instance refresh %q: %v", refresh.ID, err)
+			return errors.Wrap(err, "failed to roll back launch template")
+		}
+		machinePoolScope.SetLaunchTemplateLatestVersionStatus(version)
+		awsMachinePool.Status.RolledBackGeneration = pointer.Int64(awsMachinePool.Generation)
+		awsMachinePool.Status.RolledBackInstanceRefreshID = pointer.String(refresh.ID)
+		r.Recorder.Eventf(awsMachinePool, corev1.EventTypeNormal, "SuccessfulRollbackLaunchTemplate", "Rolled back launch template to version %s after failed instance refresh %q", version, refresh.ID)
+	case pointer.StringDeref(awsMachinePool.Status.RolledBackInstanceRefreshID, "") != refresh.ID:
+		// Rolling back the launch template again would undo changes that were already known to work.
+		machinePoolScope.Info("Launch template was already rolled back, not rolling it back again", "instanceRefresh", refresh.ID)
+		awsMachinePool.Status.InstanceRefresh = refresh
+		return nil
+	}
+
+	if !awsMachinePool.Spec.RefreshPreferences.Disable {
+		// Replace the instances the failed instance refresh already launched from the rolled back version.
+		machinePoolScope.Info("starting instance refresh to roll back instances", "number of instances", machinePoolScope.MachinePool.Spec.Replicas)
+		if err := asgSvc.StartASGInstanceRefresh(machinePoolScope); err != nil {
+			return err
+		}
+	}
+	awsMachinePool.Status.InstanceRefresh = refresh
+
+	return nil
+}
+
+// instanceRefreshInProgress returns true if the most recent instance refresh of the ASG didn't end yet.
+func instanceRefreshInProgress(awsMachinePool *expinfrav1.AWSMachinePool) bool {
+	refresh := awsMachinePool.Status.InstanceRefresh
+	if refresh == nil {
+		return false
+	}
+	switch refresh.Status {
+	case autoscaling.InstanceRefreshStatusPending, autoscaling.InstanceRefreshStatusInProgress, autoscaling.InstanceRefreshStatusCancelling,
+		instanceRefreshStatusRollbackInProgress:
+		return true
+	}
+	return false
+}
+
+// instanceRefreshNewlyFailing returns true if the current instance refresh is failing without having ended yet, and
+// it wasn't cancelled yet. Like failures, it is only acted upon once the status was recorded.
+func instanceRefreshNewlyFailing(awsMachinePool *expinfrav1.AWSMachinePool, current *expinfrav1.AWSMachinePoolInstanceRefreshStatus) bool {
+	if awsMachinePool.Status.InstanceRefresh == nil || current == nil ||
+		pointer.StringDeref(awsMachinePool.Status.CancelledInstanceRefreshID, "") == current.ID {
+		return false
+	}
+	switch current.Status {
+	case instanceRefreshStatusRollbackFailed:
+		return true
+	case autoscaling.InstanceRefreshStatusInProgress:
+		reason := strings.ToLower(current.StatusReason)
+		for _, fragment := range instanceRefreshFailureReasons {
+			if strings.Contains(reason, fragment) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// instanceRefreshNewlyFailed returns true if the current instance refresh failed and the failure wasn't handled yet.
+// An instance refresh cancelled by the controller because it was failing counts as failed once it ended.
+// Failures are only handled for instance refreshes observed after the status was recorded, so that an old failure is
+// not acted upon when the status is first populated.
+func instanceRefreshNewlyFailed(previous, current *expinfrav1.AWSMachinePoolInstanceRefreshStatus, cancelledID string) bool {
+	if previous == nil || current == nil {
+		return false
+	}
+	switch {
+	case current.Status == autoscaling.InstanceRefreshStatusFailed:
+	case current.ID == cancelledID &&
+		(current.Status == autoscaling.InstanceRefreshStatusCancelled || current.Status == instanceRefreshStatusRollbackFailed):
+	default:
+		return false
+	}
+	return previous.ID != current.ID || previous.Status != current.Status
+}
+
+// launchTemplateRolledBack returns true if the launch template changes of the current generation of the
+// AWSMachinePool were rolled back after a failed instance refresh.
+func launchTemplateRolledBack(awsMachinePool *expinfrav1.AWSMachinePool) bool {
+	rolledBackGeneration := awsMachinePool.Status.RolledBackGeneration
+	return rolledBackGeneration != nil && *rolledBackGeneration == awsMachinePool.Generation
+}
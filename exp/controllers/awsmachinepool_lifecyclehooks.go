@@ -0,0 +1,256 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	kubedrain "k8s.io/kubectl/pkg/drain"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api/controllers/remote"
+	"sigs.k8s.io/cluster-api/util"
+)
+
+const (
+	// defaultLifecycleHookHeartbeatTimeout is the heartbeat timeout AWS uses for lifecycle hooks without one.
+	defaultLifecycleHookHeartbeatTimeout = time.Hour
+
+	// lifecycleHookRequeueAfter is how often instances waiting on a terminating lifecycle hook are looked for,
+	// as the ASG doesn't notify the controller when an instance starts terminating.
+	lifecycleHookRequeueAfter = time.Minute
+
+	// drainRequeueAfter is how long to wait before retrying a node drain that didn't complete.
+	drainRequeueAfter = 20 * time.Second
+)
+
+// reconcileLifecycleHooks creates, updates and deletes the lifecycle hooks of the ASG to match the AWSMachinePool.
+func (r *AWSMachinePoolReconciler) reconcileLifecycleHooks(machinePoolScope *scope.MachinePoolScope, asgSvc services.ASGInterface) error {
+	asgName := machinePoolScope.Name()
+	existingHooks, err := asgSvc.DescribeLifecycleHooks(asgName)
+	if err != nil {
+		return err
+	}
+
+	existingHooksByName := make(map[string]*expinfrav1.AWSLifecycleHook, len(existingHooks))
+	for _, hook := range existingHooks {
+		existingHooksByName[hook.Name] = hook
+	}
+
+	for i := range machinePoolScope.AWSMachinePool.Spec.LifecycleHooks {
+		hook := &machinePoolScope.AWSMachinePool.Spec.LifecycleHooks[i]
+		existingHook, ok := existingHooksByName[hook.Name]
+		delete(existingHooksByName, hook.Name)
+		if ok && !lifecycleHookNeedsUpdate(hook, existingHook) {
+			continue
+		}
+
+		machinePoolScope.Info("Putting lifecycle hook", "name", hook.Name)
+		if err := asgSvc.PutLifecycleHook(asgName, hook); err != nil {
+			return errors.Wrapf(err, "failed to put lifecycle hook %q", hook.Name)
+		}
+	}
+
+	for name := range existingHooksByName {
+		machinePoolScope.Info("Deleting lifecycle hook", "name", name)
+		if err := asgSvc.DeleteLifecycleHook(asgName, name); err != nil {
+			return errors.Wrapf(err, "failed to delete lifecycle hook %q", name)
+		}
+	}
+
+	return nil
+}
+
+// lifecycleHookNeedsUpdate compares the incoming lifecycle hook against the existing lifecycle hook of the ASG,
+// using the AWS defaults for unset fields.
+func lifecycleHookNeedsUpdate(incoming, existing *expinfrav1.AWSLifecycleHook) bool {
+	if incoming.LifecycleTransition != existing.LifecycleTransition {
+		return true
+	}
+
+	if pointer.StringDeref(incoming.NotificationTargetARN, "") != pointer.StringDeref(existing.NotificationTargetARN, "") ||
+		pointer.StringDeref(incoming.RoleARN, "") != pointer.StringDeref(existing.RoleARN, "") ||
+		pointer.StringDeref(incoming.NotificationMetadata, "") != pointer.StringDeref(existing.NotificationMetadata, "") {
+		return true
+	}
+
+	incomingHeartbeatTimeout, existingHeartbeatTimeout := defaultLifecycleHookHeartbeatTimeout, defaultLifecycleHookHeartbeatTimeout
+	if incoming.HeartbeatTimeout != nil {
+		incomingHeartbeatTimeout = incoming.HeartbeatTimeout.Duration
+	}
+	if existing.HeartbeatTimeout != nil {
+		existingHeartbeatTimeout = existing.HeartbeatTimeout.Duration
+	}
+	if incomingHeartbeatTimeout != existingHeartbeatTimeout {
+		return true
+	}
+
+	incomingDefaultResult, existingDefaultResult := expinfrav1.LifecycleHookDefaultResultAbandon, expinfrav1.LifecycleHookDefaultResultAbandon
+	if incoming.DefaultResult != nil {
+		incomingDefaultResult = *incoming.DefaultResult
+	}
+	if existing.DefaultResult != nil {
+		existingDefaultResult = *existing.DefaultResult
+	}
+	return incomingDefaultResult != existingDefaultResult
+}
+
+// terminatingLifecycleHooks returns the names of the lifecycle hooks of the AWSMachinePool attached to terminating instances.
+func terminatingLifecycleHooks(awsMachinePool *expinfrav1.AWSMachinePool) []string {
+	var names []string
+	for _, hook := range awsMachinePool.Spec.LifecycleHooks {
+		if hook.LifecycleTransition == expinfrav1.LifecycleTransitionInstanceTerminating {
+			names = append(names, hook.Name)
+		}
+	}
+	return names
+}
+
+// reconcileTerminatingInstances cordons and drains the nodes of instances waiting on a terminating lifecycle hook,
+// then completes their lifecycle actions so the ASG terminates them.
+func (r *AWSMachinePoolReconciler) reconcileTerminatingInstances(ctx context.Context, machinePoolScope *scope.MachinePoolScope, asgSvc services.ASGInterface, asg *expinfrav1.AutoScalingGroup) (ctrl.Result, error) {
+	hookNames := terminatingLifecycleHooks(machinePoolScope.AWSMachinePool)
+	if len(hookNames) == 0 {
+		return ctrl.Result{}, nil
+	}
+
+	result := ctrl.Result{RequeueAfter: lifecycleHookRequeueAfter}
+	for _, instance := range asg.Instances {
+		if string(instance.State) != autoscaling.LifecycleStateTerminatingWait {
+			continue
+		}
+
+		drained, err := r.drainInstanceNode(ctx, machinePoolScope, instance.ID)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !drained {
+			result.RequeueAfter = drainRequeueAfter
+			continue
+		}
+
+		for _, hookName := range hookNames {
+			if err := asgSvc.CompleteLifecycleAction(asg.Name, hookName, instance.ID); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// drainInstanceNode cordons and drains the node of an instance in the workload cluster. It returns false if the
+// drain didn't complete and should be retried.
+func (r *AWSMachinePoolReconciler) drainInstanceNode(ctx context.Context, machinePoolScope *scope.MachinePoolScope, instanceID string) (bool, error) {
+	restConfig, err := remote.RESTConfig(ctx, "awsmachinepool-controller", r.Client, util.ObjectKey(machinePoolScope.Cluster))
+	if err != nil {
+		return false, errors.Wrap(err, "failed to create a workload cluster client config")
+	}
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to create a workload cluster client")
+	}
+
+	node, err := nodeByInstanceID(ctx, kubeClient, instanceID)
+	if err != nil {
+		return false, err
+	}
+	if node == nil {
+		// The instance never joined the cluster, or its node was already deleted.
+		machinePoolScope.Info("Could not find node of terminating instance, skipping drain", "instance", instanceID)
+		return true, nil
+	}
+
+	log := machinePoolScope.WithValues("instance", instanceID, "node", node.Name)
+	drainer := &kubedrain.Helper{
+		Client:              kubeClient,
+		Ctx:                 ctx,
+		Force:               true,
+		IgnoreAllDaemonSets: true,
+		DeleteEmptyDirData:  true,
+		GracePeriodSeconds:  -1,
+		// If a pod is not evicted in 20 seconds, retry the eviction on the next reconcile
+		// to allow other instances to be drained.
+		Timeout: drainRequeueAfter,
+		OnPodDeletedOrEvicted: func(pod *corev1.Pod, usingEviction bool) {
+			verbStr := "Deleted"
+			if usingEviction {
+				verbStr = "Evicted"
+			}
+			log.Info(fmt.Sprintf("%s pod from Node", verbStr), "pod", fmt.Sprintf("%s/%s", pod.Name, pod.Namespace))
+		},
+		Out:    writer{log.Info},
+		ErrOut: writer{log.Warn},
+	}
+
+	if err := kubedrain.RunCordonOrUncordon(drainer, node, true); err != nil {
+		return false, errors.Wrapf(err, "failed to cordon node %q", node.Name)
+	}
+
+	if err := kubedrain.RunNodeDrain(drainer, node.Name); err != nil {
+		log.Info("Drain failed, retrying", "error", err.Error())
+		return false, nil
+	}
+
+	r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeNormal, "SuccessfulDrainNode", "Drained node %q of terminating instance %q", node.Name, instanceID)
+	return true, nil
+}
+
+// nodeByInstanceID returns the node of an instance in the workload cluster, or nil if there is none.
+func nodeByInstanceID(ctx context.Context, kubeClient kubernetes.Interface, instanceID string) (*corev1.Node, error) {
+	opts := metav1.ListOptions{}
+	for {
+		nodeList, err := kubeClient.CoreV1().Nodes().List(ctx, opts)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list nodes")
+		}
+
+		for i := range nodeList.Items {
+			if strings.HasSuffix(nodeList.Items[i].Spec.ProviderID, "/"+instanceID) {
+				return &nodeList.Items[i], nil
+			}
+		}
+
+		if nodeList.Continue == "" {
+			return nil, nil
+		}
+		opts.Continue = nodeList.Continue
+	}
+}
+
+// writer implements io.Writer interface as a pass-through for logging.
+type writer struct {
+	logFunc func(msg string, keysAndValues ...interface{})
+}
+
+// Write passes string(p) into writer's logFunc and always returns len(p).
+func (w writer) Write(p []byte) (n int, err error) {
+	w.logFunc(string(p))
+	return len(p), nil
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+)
+
+// reconcileScheduledActions creates, updates and deletes the scheduled actions of the ASG to match the AWSMachinePool.
+func (r *AWSMachinePoolReconciler) reconcileScheduledActions(machinePoolScope *scope.MachinePoolScope, asgSvc services.ASGInterface) error {
+	// Leave scheduled actions managed outside of the AWSMachinePool alone, unless it had scheduled actions before
+	// and they must be deleted.
+	if len(machinePoolScope.AWSMachinePool.Spec.ScheduledActions) == 0 && machinePoolScope.AWSMachinePool.Status.ObservedCapacity == nil {
+		return nil
+	}
+
+	asgName := machinePoolScope.Name()
+	existingActions, err := asgSvc.DescribeScheduledActions(asgName)
+	if err != nil {
+		return err
+	}
+
+	existingActionsByName := make(map[string]*expinfrav1.AWSScheduledAction, len(existingActions))
+	for _, action := range existingActions {
+		existingActionsByName[action.Name] = action
+	}
+
+	now := time.Now()
+	for i := range machinePoolScope.AWSMachinePool.Spec.ScheduledActions {
+		action := &machinePoolScope.AWSMachinePool.Spec.ScheduledActions[i]
+		existingAction, ok := existingActionsByName[action.Name]
+		delete(existingActionsByName, action.Name)
+		if ok && !scheduledActionNeedsUpdate(action, existingAction) {
+			continue
+		}
+		if !ok && scheduledActionExpired(action, now) {
+			// AWS deletes one-time actions once they ran, they must not be created again.
+			continue
+		}
+
+		machinePoolScope.Info("Putting scheduled action", "name", action.Name)
+		if err := asgSvc.PutScheduledAction(asgName, action); err != nil {
+			return errors.Wrapf(err, "failed to put scheduled action %q", action.Name)
+		}
+	}
+
+	for name := range existingActionsByName {
+		machinePoolScope.Info("Deleting scheduled action", "name", name)
+		if err := asgSvc.DeleteScheduledAction(asgName, name); err != nil {
+			return errors.Wrapf(err, "failed to delete scheduled action %q", name)
+		}
+	}
+
+	return nil
+}
+
+// scheduledActionNeedsUpdate compares the incoming scheduled action against the existing scheduled action of the ASG.
+func scheduledActionNeedsUpdate(incoming, existing *expinfrav1.AWSScheduledAction) bool {
+	// The start time of a recurring action is reported as the time it runs next, so it is only compared for one-time actions.
+	if incoming.Recurrence == nil && !cmp.Equal(incoming.StartTime, existing.StartTime) {
+		return true
+	}
+
+	return !cmp.Equal(incoming.Recurrence, existing.Recurrence) ||
+		!cmp.Equal(incoming.EndTime, existing.EndTime) ||
+		!cmp.Equal(incoming.TimeZone, existing.TimeZone) ||
+		!cmp.Equal(incoming.MinSize, existing.MinSize) ||
+		!cmp.Equal(incoming.MaxSize, existing.MaxSize) ||
+		!cmp.Equal(incoming.DesiredCapacity, existing.DesiredCapacity)
+}
+
+// scheduledActionExpired returns true if the scheduled action will never run again.
+func scheduledActionExpired(action *expinfrav1.AWSScheduledAction, now time.Time) bool {
+	if action.Recurrence == nil {
+		return action.StartTime != nil && action.StartTime.Time.Before(now)
+	}
+	return action.EndTime != nil && action.EndTime.Time.Before(now)
+}
+
+// reconcileScheduledCapacity records the minimum and maximum size scheduled actions set on the ASG since the last
+// reconciliation, so that updating the ASG doesn't revert them, and adopts the desired capacity they set into the
+// MachinePool. A size that was changed on the AWSMachinePool or MachinePool in the meantime takes precedence.
+func (r *AWSMachinePoolReconciler) reconcileScheduledCapacity(ctx context.Context, machinePoolScope *scope.MachinePoolScope, asg *expinfrav1.AutoScalingGroup) error {
+	awsMachinePool := machinePoolScope.AWSMachinePool
+	observed := awsMachinePool.Status.ObservedCapacity
+	if len(awsMachinePool.Spec.ScheduledActions) == 0 || observed == nil {
+		awsMachinePool.Status.ScheduledCapacity = nil
+		return nil
+	}
+
+	var scheduled *expinfrav1.AWSMachinePoolScheduledCapacity
+	minSize := scheduledSize(awsMachinePool.Spec.MinSize, observed.MinSize, asg.MinSize)
+	maxSize := scheduledSize(awsMachinePool.Spec.MaxSize, observed.MaxSize, asg.MaxSize)
+	if minSize != nil || maxSize != nil {
+		scheduled = &expinfrav1.AWSMachinePoolScheduledCapacity{MinSize: minSize, MaxSize: maxSize}
+	}
+	if !cmp.Equal(scheduled, awsMachinePool.Status.ScheduledCapacity) {
+		machinePoolScope.Info("Scheduled actions changed the size of the ASG", "minSize", asg.MinSize, "maxSize", asg.MaxSize)
+	}
+	awsMachinePool.Status.ScheduledCapacity = scheduled
+
+	replicas := machinePoolScope.MachinePool.Spec.Replicas
+	if asg.DesiredCapacity == nil || replicas == nil || *asg.DesiredCapacity == observed.DesiredCapacity || *replicas != observed.DesiredCapacity {
+		return nil
+	}
+
+	machinePoolScope.Info("Setting MachinePool replicas to ASG DesiredCapacity changed by a scheduled action", "local", *replicas, "scheduled", *asg.DesiredCapacity)
+	machinePoolScope.MachinePool.Spec.Replicas = asg.DesiredCapacity
+	if err := machinePoolScope.PatchCAPIMachinePoolObject(ctx); err != nil {
+		return err
+	}
+	r.Recorder.Eventf(awsMachinePool, corev1.EventTypeNormal, "ScheduledCapacityChange", "Scaled MachinePool from %d to %d replicas following a scheduled action", *replicas, *asg.DesiredCapacity)
+	return nil
+}
+
+// scheduledSize returns the size of the ASG if it was set by a scheduled action, or nil if the ASG has the size of
+// the AWSMachinePool or the size of the AWSMachinePool changed since the last reconciliation.
+func scheduledSize(local, observed, current int32) *int32 {
+	if local != observed || current == local {
+		return nil
+	}
+	return pointer.Int32(current)
+}
+
+// observedCapacity returns the size of the ASG once it was updated to match the AWSMachinePool, or nil if
+// the AWSMachinePool has no scheduled actions.
+func observedCapacity(machinePoolScope *scope.MachinePoolScope) *expinfrav1.AWSMachinePoolCapacity {
+	replicas := machinePoolScope.MachinePool.Spec.Replicas
+	if len(machinePoolScope.AWSMachinePool.Spec.ScheduledActions) == 0 || replicas == nil {
+		return nil
+	}
+
+	return &expinfrav1.AWSMachinePoolCapacity{
+		MinSize:         machinePoolScope.AWSMachinePool.Spec.MinSize,
+		MaxSize:         machinePoolScope.AWSMachinePool.Spec.MaxSize,
+		DesiredCapacity: *replicas,
+	}
+}
@@ -19,6 +19,7 @@ package instancestate
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"sync"
 	"time"
 
@@ -39,6 +40,11 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/instancestate"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/cluster-api/util/predicates"
 )
@@ -57,7 +63,10 @@ type AwsInstanceStateReconciler struct {
 }
 
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsclusters,verbs=get;list;watch
-// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachines,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachines,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachines/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines;machines/status,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch
 
 func (r *AwsInstanceStateReconciler) getSQSService(region string) (sqsiface.SQSAPI, error) {
 	if r.sqsServiceFactory != nil {
@@ -175,41 +184,120 @@ func (r *AwsInstanceStateReconciler) watchQueuesForInstanceEvents() {
 
 // processMessage triggers a reconcile on an AWSMachine if its EC2 instance state changed.
 func (r *AwsInstanceStateReconciler) processMessage(ctx context.Context, msg message) {
-	if msg.Source != "aws.ec2" || msg.DetailType != instancestate.Ec2StateChangeNotification || msg.MessageDetail == nil {
+	if msg.Source != "aws.ec2" || msg.MessageDetail == nil {
 		return
 	}
 
-	// Fetch the awsMachine instance by InstanceID
-	awsMachines := &infrav1.AWSMachineList{}
-	err := r.List(ctx, awsMachines, client.MatchingFields{controllers.InstanceIDIndex: msg.MessageDetail.InstanceID})
+	switch msg.DetailType {
+	case instancestate.Ec2StateChangeNotification:
+		r.processStateChange(ctx, msg)
+	case instancestate.Ec2SpotInstanceInterruptionWarning, instancestate.Ec2InstanceRebalanceRecommendation:
+		r.processSpotEvent(ctx, msg)
+	}
+}
+
+func (r *AwsInstanceStateReconciler) processStateChange(ctx context.Context, msg message) {
+	machine := r.getAWSMachine(ctx, msg.MessageDetail.InstanceID)
+	if machine == nil {
+		return
+	}
 
+	patchHelper, err := patch.NewHelper(machine, r.Client)
 	if err != nil {
-		r.Log.Error(err, "unable to list machines by instance ID", "instanceID", msg.MessageDetail.InstanceID)
+		r.Log.Error(err, "unable to create patch helper")
+		return
 	}
 
-	if len(awsMachines.Items) > 0 {
-		machine := awsMachines.Items[0]
-		if !machine.ObjectMeta.DeletionTimestamp.IsZero() {
-			return
-		}
-		patchHelper, err := patch.NewHelper(&machine, r.Client)
+	// Trigger an update on the machine
+	labels := machine.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	labels[Ec2InstanceStateLabelKey] = string(msg.MessageDetail.State)
+	machine.SetLabels(labels)
+	err = patchHelper.Patch(ctx, machine)
+	if err != nil {
+		r.Log.Error(err, "unable to patch AWS machine")
+	}
+}
+
+// processSpotEvent marks the AWSMachine of a spot instance about to be interrupted, or at an elevated risk
+// of interruption, and flags its Machine for remediation so that it gets replaced before the instance vanishes.
+func (r *AwsInstanceStateReconciler) processSpotEvent(ctx context.Context, msg message) {
+	awsMachine := r.getAWSMachine(ctx, msg.MessageDetail.InstanceID)
+	if awsMachine == nil {
+		return
+	}
+
+	reason := infrav1.SpotRebalanceRecommendationReason
+	message := fmt.Sprintf("Spot instance %s is at an elevated risk of interruption", msg.MessageDetail.InstanceID)
+	if msg.DetailType == instancestate.Ec2SpotInstanceInterruptionWarning {
+		reason = infrav1.SpotInterruptionWarningReason
+		message = fmt.Sprintf("Spot instance %s will be interrupted in two minutes, instance action: %s", msg.MessageDetail.InstanceID, msg.MessageDetail.InstanceAction)
+	}
+
+	if conditions.GetReason(awsMachine, infrav1.SpotInstanceHealthyCondition) != reason {
+		patchHelper, err := patch.NewHelper(awsMachine, r.Client)
 		if err != nil {
 			r.Log.Error(err, "unable to create patch helper")
+			return
 		}
-		// Trigger an update on the machine
-		labels := machine.GetLabels()
-		if labels == nil {
-			labels = make(map[string]string)
+		conditions.MarkFalse(awsMachine, infrav1.SpotInstanceHealthyCondition, reason, clusterv1.ConditionSeverityWarning, message)
+		if err := patchHelper.Patch(ctx, awsMachine); err != nil {
+			r.Log.Error(err, "unable to patch AWS machine")
+			return
 		}
+		record.Warnf(awsMachine, reason, message)
+	}
+
+	machine, err := util.GetOwnerMachine(ctx, r.Client, awsMachine.ObjectMeta)
+	if err != nil {
+		r.Log.Error(err, "unable to get owner machine", "awsMachine", klog.KObj(awsMachine))
+		return
+	}
+	if machine == nil || !machine.DeletionTimestamp.IsZero() {
+		return
+	}
+	if _, ok := machine.Annotations[clusterv1.MachineSkipRemediationAnnotation]; ok {
+		return
+	}
+	if _, ok := machine.Annotations[clusterv1.DeleteMachineAnnotation]; ok && conditions.IsFalse(machine, clusterv1.MachineOwnerRemediatedCondition) {
+		return
+	}
 
-		labels[Ec2InstanceStateLabelKey] = string(msg.MessageDetail.State)
-		machine.SetLabels(labels)
+	patchHelper, err := patch.NewHelper(machine, r.Client)
+	if err != nil {
+		r.Log.Error(err, "unable to create patch helper")
+		return
+	}
 
-		err = patchHelper.Patch(ctx, &machine)
-		if err != nil {
-			r.Log.Error(err, "unable to patch AWS machine")
-		}
+	// Prefer the machine when scaling down and ask its owner to replace it, as a machine health check would.
+	annotations.AddAnnotations(machine, map[string]string{clusterv1.DeleteMachineAnnotation: ""})
+	conditions.MarkFalse(machine, clusterv1.MachineOwnerRemediatedCondition, clusterv1.WaitingForRemediationReason, clusterv1.ConditionSeverityWarning, message)
+	if err := patchHelper.Patch(ctx, machine, patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{clusterv1.MachineOwnerRemediatedCondition}}); err != nil {
+		r.Log.Error(err, "unable to patch machine", "machine", klog.KObj(machine))
+		return
+	}
+	record.Eventf(awsMachine, "SpotInstanceRemediation", "Requested the replacement of machine %s", machine.Name)
+}
+
+// getAWSMachine returns the AWSMachine of the instance, or nil if there is none or it is being deleted.
+func (r *AwsInstanceStateReconciler) getAWSMachine(ctx context.Context, instanceID string) *infrav1.AWSMachine {
+	// Fetch the awsMachine instance by InstanceID
+	awsMachines := &infrav1.AWSMachineList{}
+	err := r.List(ctx, awsMachines, client.MatchingFields{controllers.InstanceIDIndex: instanceID})
+	if err != nil {
+		r.Log.Error(err, "unable to list machines by instance ID", "instanceID", instanceID)
+	}
+	if len(awsMachines.Items) == 0 {
+		return nil
+	}
+
+	machine := &awsMachines.Items[0]
+	if !machine.ObjectMeta.DeletionTimestamp.IsZero() {
+		return nil
 	}
+	return machine
 }
 
 // getQueueURL retrieves the SQS queue URL for a given cluster.
@@ -249,6 +337,7 @@ type message struct {
 }
 
 type messageDetail struct {
-	InstanceID string                `json:"instance-id,omitempty"`
-	State      infrav1.InstanceState `json:"state,omitempty"`
+	InstanceID     string                `json:"instance-id,omitempty"`
+	State          infrav1.InstanceState `json:"state,omitempty"`
+	InstanceAction string                `json:"instance-action,omitempty"`
 }
@@ -37,6 +37,7 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/controllers"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/instancestate/mock_sqsiface"
+	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
 func TestAWSInstanceStateController(t *testing.T) {
@@ -58,6 +59,10 @@ func TestAWSInstanceStateController(t *testing.T) {
 			Name:      "aws-cluster-1-instance-1",
 			Namespace: "default",
 		}
+		spotMachineMeta := metav1.ObjectMeta{
+			Name:      "aws-cluster-3-instance-1",
+			Namespace: "default",
+		}
 		sqsSvs.EXPECT().GetQueueUrl(&sqs.GetQueueUrlInput{QueueName: aws.String("aws-cluster-1-queue")}).AnyTimes().
 			Return(&sqs.GetQueueUrlOutput{QueueUrl: aws.String("aws-cluster-1-url")}, nil)
 		sqsSvs.EXPECT().GetQueueUrl(&sqs.GetQueueUrlInput{QueueName: aws.String("aws-cluster-2-queue")}).AnyTimes().
@@ -88,9 +93,29 @@ func TestAWSInstanceStateController(t *testing.T) {
 		sqsSvs.EXPECT().ReceiveMessage(&sqs.ReceiveMessageInput{QueueUrl: aws.String("aws-cluster-2-url")}).AnyTimes().
 			Return(&sqs.ReceiveMessageOutput{Messages: []*sqs.Message{}}, nil)
 		sqsSvs.EXPECT().ReceiveMessage(&sqs.ReceiveMessageInput{QueueUrl: aws.String("aws-cluster-3-url")}).AnyTimes().
-			Return(&sqs.ReceiveMessageOutput{Messages: []*sqs.Message{}}, nil)
+			DoAndReturn(func(arg *sqs.ReceiveMessageInput) (*sqs.ReceiveMessageOutput, error) {
+				m := &infrav1.AWSMachine{}
+				lookupKey := types.NamespacedName{
+					Namespace: spotMachineMeta.Namespace,
+					Name:      spotMachineMeta.Name,
+				}
+				err := k8sClient.Get(context.TODO(), lookupKey, m)
+				// start returning a message once the AWSMachine is available
+				if err == nil {
+					return &sqs.ReceiveMessageOutput{
+						Messages: []*sqs.Message{{
+							ReceiptHandle: aws.String("spot-message-receipt-handle"),
+							Body:          aws.String(spotInterruptionMessageBodyJSON),
+						}},
+					}, nil
+				}
+
+				return &sqs.ReceiveMessageOutput{Messages: []*sqs.Message{}}, nil
+			})
 		sqsSvs.EXPECT().DeleteMessage(&sqs.DeleteMessageInput{QueueUrl: aws.String("aws-cluster-1-url"), ReceiptHandle: aws.String("message-receipt-handle")}).AnyTimes().
 			Return(nil, nil)
+		sqsSvs.EXPECT().DeleteMessage(&sqs.DeleteMessageInput{QueueUrl: aws.String("aws-cluster-3-url"), ReceiptHandle: aws.String("spot-message-receipt-handle")}).AnyTimes().
+			Return(nil, nil)
 
 		g.Expect(testEnv.Manager.GetFieldIndexer().IndexField(context.Background(), &infrav1.AWSMachine{},
 			controllers.InstanceIDIndex,
@@ -167,6 +192,27 @@ func TestAWSInstanceStateController(t *testing.T) {
 			val := labels[Ec2InstanceStateLabelKey]
 			return val == "shutting-down"
 		}, 10*time.Second).Should(Equal(true))
+
+		persistObject(g, &infrav1.AWSMachine{
+			Spec: infrav1.AWSMachineSpec{
+				InstanceID:        pointer.StringPtr("i-spot-instance-1"),
+				InstanceType:      "test",
+				SpotMarketOptions: &infrav1.SpotMarketOptions{},
+			},
+			ObjectMeta: spotMachineMeta,
+		})
+
+		t.Log("Ensuring spot machine is marked as about to be interrupted")
+		g.Eventually(func() bool {
+			m := &infrav1.AWSMachine{}
+			key := types.NamespacedName{
+				Namespace: spotMachineMeta.Namespace,
+				Name:      spotMachineMeta.Name,
+			}
+			g.Expect(k8sClient.Get(context.TODO(), key, m)).NotTo(HaveOccurred())
+			return conditions.IsFalse(m, infrav1.SpotInstanceHealthyCondition) &&
+				conditions.GetReason(m, infrav1.SpotInstanceHealthyCondition) == infrav1.SpotInterruptionWarningReason
+		}, 10*time.Second).Should(Equal(true))
 	})
 }
 
@@ -178,3 +224,12 @@ const messageBodyJSON = `{
 		"state": "shutting-down"
 	}
 }`
+
+const spotInterruptionMessageBodyJSON = `{
+	"source": "aws.ec2",
+	"detail-type": "EC2 Spot Instance Interruption Warning",
+	"detail": {
+		"instance-id": "i-spot-instance-1",
+		"instance-action": "terminate"
+	}
+}`
@@ -228,6 +228,15 @@ func setupReconcilersAndWebhooks(ctx context.Context, mgr ctrl.Manager, awsServi
 		os.Exit(1)
 	}
 
+	if err := (&controllers.AWSMachineTemplateReconciler{
+		Client:           mgr.GetClient(),
+		Endpoints:        awsServiceEndpoints,
+		WatchFilterValue: watchFilterValue,
+	}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: awsMachineConcurrency, RecoverPanic: true}); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AWSMachineTemplate")
+		os.Exit(1)
+	}
+
 	if err := (&controllers.AWSClusterReconciler{
 		Client:             mgr.GetClient(),
 		Recorder:           mgr.GetEventRecorderFor("awscluster-controller"),
@@ -25,6 +25,8 @@ import (
 
 // Error singletons for AWS errors.
 const (
+	AllocationIDNotFound              = "InvalidAllocationID.NotFound"
+	AMINotFound                       = "InvalidAMIID.NotFound"
 	AssociationIDNotFound             = "InvalidAssociationID.NotFound"
 	AuthFailure                       = "AuthFailure"
 	BucketAlreadyOwnedByYou           = "BucketAlreadyOwnedByYou"
@@ -34,6 +36,7 @@ const (
 	InternetGatewayNotFound           = "InvalidInternetGatewayID.NotFound"
 	EgressOnlyInternetGatewayNotFound = "InvalidEgressOnlyInternetGatewayID.NotFound"
 	InUseIPAddress                    = "InvalidIPAddress.InUse"
+	InsufficientInstanceCapacity      = "InsufficientInstanceCapacity"
 	InvalidAccessKeyID                = "InvalidAccessKeyId"
 	InvalidClientTokenID              = "InvalidClientTokenId"
 	InvalidInstanceID                 = "InvalidInstanceID.NotFound"
@@ -45,6 +48,7 @@ const (
 	NoCredentialProviders                   = "NoCredentialProviders"
 	NoSuchKey                               = "NoSuchKey"
 	PermissionNotFound                      = "InvalidPermission.NotFound"
+	ResourceAlreadyAssociated               = "Resource.AlreadyAssociated"
 	ResourceExists                          = "ResourceExistsException"
 	ResourceNotFound                        = "InvalidResourceID.NotFound"
 	RouteTableNotFound                      = "InvalidRouteTableID.NotFound"
@@ -107,6 +111,14 @@ func IsBucketAlreadyOwnedByYou(err error) bool {
 	return false
 }
 
+// IsInsufficientInstanceCapacity checks if AWS does not have sufficient capacity to launch an instance.
+func IsInsufficientInstanceCapacity(err error) bool {
+	if code, ok := Code(err); ok {
+		return code == InsufficientInstanceCapacity
+	}
+	return false
+}
+
 // IsResourceExists checks the state of the resource.
 func IsResourceExists(err error) bool {
 	if code, ok := Code(err); ok {
@@ -167,6 +179,8 @@ func IsInvalidNotFoundError(err error) bool {
 			return true
 		case LaunchTemplateNameNotFound:
 			return true
+		case AMINotFound:
+			return true
 		}
 	}
 
@@ -108,6 +108,17 @@ func MapToSecretsManagerTags(src infrav1.Tags) []*secretsmanager.Tag {
 	return tags
 }
 
+// SecretsManagerTagsToMap converts a []*secretsmanager.Tag into a infrav1.Tags.
+func SecretsManagerTagsToMap(src []*secretsmanager.Tag) infrav1.Tags {
+	tags := make(infrav1.Tags, len(src))
+
+	for _, t := range src {
+		tags[*t.Key] = *t.Value
+	}
+
+	return tags
+}
+
 // MapToSSMTags converts a infrav1.Tags to a []*ssm.Tag.
 func MapToSSMTags(src infrav1.Tags) []*ssm.Tag {
 	tags := make([]*ssm.Tag, 0, len(src))
@@ -41,6 +41,9 @@ const (
 	metricControllerLabel    = "controller"
 	metricStatusCodeLabel    = "status_code"
 	metricErrorCodeLabel     = "error_code"
+
+	metricOrphanedSecretsDeletedKey = "orphaned_bootstrap_secrets_deleted_total"
+	metricSecretBackendLabel        = "backend"
 )
 
 var (
@@ -60,12 +63,23 @@ var (
 		Help:      "Number of retries made against an AWS API",
 		Buckets:   []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
 	}, []string{metricControllerLabel, metricServiceLabel, metricRegionLabel, metricOperationLabel})
+	orphanedSecretsDeleted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: metricAWSSubsystem,
+		Name:      metricOrphanedSecretsDeletedKey,
+		Help:      "Total number of orphaned bootstrap secrets deleted",
+	}, []string{metricSecretBackendLabel})
 )
 
 func init() {
 	metrics.Registry.MustRegister(awsRequestCount)
 	metrics.Registry.MustRegister(awsRequestDurationSeconds)
 	metrics.Registry.MustRegister(awsCallRetries)
+	metrics.Registry.MustRegister(orphanedSecretsDeleted)
+}
+
+// RecordOrphanedSecretsDeleted records the number of orphaned bootstrap secrets deleted from the given secret backend.
+func RecordOrphanedSecretsDeleted(backend string, count int) {
+	orphanedSecretsDeleted.WithLabelValues(backend).Add(float64(count))
 }
 
 // CaptureRequestMetrics will monitor and capture request metrics.
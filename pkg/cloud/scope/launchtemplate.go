@@ -36,6 +36,8 @@ type LaunchTemplateScope interface {
 	SetLaunchTemplateIDStatus(id string)
 	GetLaunchTemplateLatestVersionStatus() string
 	SetLaunchTemplateLatestVersionStatus(version string)
+	GetResolvedAMIStatus() *infrav1.ResolvedAMI
+	SetResolvedAMIStatus(ami *infrav1.ResolvedAMI)
 	GetRawBootstrapData() ([]byte, error)
 
 	IsEKSManaged() bool
@@ -100,6 +100,10 @@ type MachineScope struct {
 	Machine      *clusterv1.Machine
 	InfraCluster EC2Scope
 	AWSMachine   *infrav1.AWSMachine
+
+	// AWSMachineTemplate is the template the AWSMachine was cloned from, if any, which pins the AMI
+	// looked up for its machines. It is only set while the instance is created.
+	AWSMachineTemplate *infrav1.AWSMachineTemplate
 }
 
 // Name returns the AWSMachine name.
@@ -153,6 +157,21 @@ func (m *MachineScope) SetInstanceID(instanceID string) {
 	m.AWSMachine.Spec.InstanceID = pointer.StringPtr(instanceID)
 }
 
+// SetInstanceType sets the AWSMachine status instance type.
+func (m *MachineScope) SetInstanceType(instanceType string) {
+	m.AWSMachine.Status.InstanceType = instanceType
+}
+
+// SetInstanceStoreVolumes sets the AWSMachine status instance store volumes.
+func (m *MachineScope) SetInstanceStoreVolumes(volumes []infrav1.InstanceStoreVolume) {
+	m.AWSMachine.Status.InstanceStoreVolumes = volumes
+}
+
+// SetFailureDomain sets the AWSMachine failure domain, which Cluster API copies to the Machine.
+func (m *MachineScope) SetFailureDomain(failureDomain string) {
+	m.AWSMachine.Spec.FailureDomain = pointer.StringPtr(failureDomain)
+}
+
 // GetInstanceState returns the AWSMachine instance state from the status.
 func (m *MachineScope) GetInstanceState() *infrav1.InstanceState {
 	return m.AWSMachine.Status.InstanceState
@@ -206,6 +225,16 @@ func (m *MachineScope) SecureSecretsBackend() infrav1.SecretBackend {
 	return m.AWSMachine.Spec.CloudInit.SecureSecretsBackend
 }
 
+// SecureSecretsKMSKeyID returns the KMS key used to encrypt the userdata in the secret backend.
+func (m *MachineScope) SecureSecretsKMSKeyID() string {
+	return m.AWSMachine.Spec.CloudInit.KMSKeyID
+}
+
+// SSMParameterTier returns the tier of the SSM parameters used to store the userdata.
+func (m *MachineScope) SSMParameterTier() infrav1.SSMParameterTier {
+	return m.AWSMachine.Spec.CloudInit.SSMParameterTier
+}
+
 // CompressUserData returns the computed value of whether or not
 // userdata should be compressed using gzip.
 func (m *MachineScope) CompressUserData(userDataFormat string) bool {
@@ -299,6 +328,15 @@ func (m *MachineScope) PatchObject() error {
 		applicableConditions = append(applicableConditions, infrav1.ELBAttachedCondition)
 	}
 
+	// The status checks are only reported once they are conclusive, so that a failed check turns the machine
+	// not ready while a machine that is still initializing isn't held back. The controller also flags the Machine
+	// of an instance failing its status checks for remediation, as machine health checks only watch the Node.
+	for _, condition := range []clusterv1.ConditionType{infrav1.InstanceStatusCheckPassedCondition, infrav1.SystemStatusCheckPassedCondition} {
+		if conditions.Has(m.AWSMachine, condition) {
+			applicableConditions = append(applicableConditions, condition)
+		}
+	}
+
 	conditions.SetSummary(m.AWSMachine,
 		conditions.WithConditions(applicableConditions...),
 		conditions.WithStepCounterIf(m.AWSMachine.ObjectMeta.DeletionTimestamp.IsZero()),
@@ -316,6 +354,18 @@ func (m *MachineScope) PatchObject() error {
 		}})
 }
 
+// PatchAWSMachineTemplate persists the changes made to the AWSMachineTemplate since base. It fails with a
+// conflict if the AWSMachineTemplate was changed in the meantime.
+func (m *MachineScope) PatchAWSMachineTemplate(base *infrav1.AWSMachineTemplate) error {
+	// AWSMachineTemplate has no status subresource, so its annotations and status are patched together.
+	return m.client.Patch(context.TODO(), m.AWSMachineTemplate, client.MergeFromWithOptions(base, client.MergeFromWithOptimisticLock{}))
+}
+
+// ReloadAWSMachineTemplate reads the AWSMachineTemplate again, discarding any changes made to it.
+func (m *MachineScope) ReloadAWSMachineTemplate() error {
+	return m.client.Get(context.TODO(), client.ObjectKeyFromObject(m.AWSMachineTemplate), m.AWSMachineTemplate)
+}
+
 // Close the MachineScope by updating the machine spec, machine status.
 func (m *MachineScope) Close() error {
 	return m.PatchObject()
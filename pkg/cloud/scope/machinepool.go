@@ -285,6 +285,30 @@ func (m *MachinePoolScope) SetLaunchTemplateLatestVersionStatus(version string)
 	m.AWSMachinePool.Status.LaunchTemplateVersion = &version
 }
 
+func (m *MachinePoolScope) GetResolvedAMIStatus() *infrav1.ResolvedAMI {
+	return m.AWSMachinePool.Status.ResolvedAMI
+}
+
+func (m *MachinePoolScope) SetResolvedAMIStatus(ami *infrav1.ResolvedAMI) {
+	m.AWSMachinePool.Status.ResolvedAMI = ami
+}
+
+// MinSize returns the minimum size of the ASG, set by a scheduled action or else by the AWSMachinePool.
+func (m *MachinePoolScope) MinSize() int32 {
+	if scheduled := m.AWSMachinePool.Status.ScheduledCapacity; scheduled != nil && scheduled.MinSize != nil {
+		return *scheduled.MinSize
+	}
+	return m.AWSMachinePool.Spec.MinSize
+}
+
+// MaxSize returns the maximum size of the ASG, set by a scheduled action or else by the AWSMachinePool.
+func (m *MachinePoolScope) MaxSize() int32 {
+	if scheduled := m.AWSMachinePool.Status.ScheduledCapacity; scheduled != nil && scheduled.MaxSize != nil {
+		return *scheduled.MaxSize
+	}
+	return m.AWSMachinePool.Spec.MaxSize
+}
+
 // IsEKSManaged checks if the AWSMachinePool is EKS managed.
 func (m *MachinePoolScope) IsEKSManaged() bool {
 	return m.InfraCluster.InfraCluster().GetObjectKind().GroupVersionKind().Kind == ekscontrolplanev1.AWSManagedControlPlaneKind
@@ -345,6 +369,19 @@ func (m *MachinePoolScope) UpdateInstanceStatuses(ctx context.Context, instances
 	return nil
 }
 
+// UpdateWarmPoolInstanceStatuses updates the AWSMachinePool status with the instances in the warm pool of the ASG.
+func (m *MachinePoolScope) UpdateWarmPoolInstanceStatuses(instances []infrav1.Instance) {
+	var instanceStatuses []expinfrav1.AWSMachinePoolWarmPoolInstanceStatus
+	for _, instance := range instances {
+		instanceStatuses = append(instanceStatuses, expinfrav1.AWSMachinePoolWarmPoolInstanceStatus{
+			InstanceID:       instance.ID,
+			LifecycleState:   string(instance.State),
+			AvailabilityZone: instance.AvailabilityZone,
+		})
+	}
+	m.AWSMachinePool.Status.WarmPoolInstances = instanceStatuses
+}
+
 func (m *MachinePoolScope) getNodeStatusByProviderID(ctx context.Context, providerIDList []string) (map[string]*NodeStatus, error) {
 	nodeStatusMap := map[string]*NodeStatus{}
 	for _, id := range providerIDList {
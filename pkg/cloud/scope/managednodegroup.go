@@ -361,6 +361,14 @@ func (s *ManagedMachinePoolScope) SetLaunchTemplateLatestVersionStatus(version s
 	s.ManagedMachinePool.Status.LaunchTemplateVersion = &version
 }
 
+func (s *ManagedMachinePoolScope) GetResolvedAMIStatus() *infrav1.ResolvedAMI {
+	return s.ManagedMachinePool.Status.ResolvedAMI
+}
+
+func (s *ManagedMachinePoolScope) SetResolvedAMIStatus(ami *infrav1.ResolvedAMI) {
+	s.ManagedMachinePool.Status.ResolvedAMI = ami
+}
+
 func (s *ManagedMachinePoolScope) GetLaunchTemplate() *expinfrav1.AWSLaunchTemplate {
 	return s.ManagedMachinePool.Spec.AWSLaunchTemplate
 }
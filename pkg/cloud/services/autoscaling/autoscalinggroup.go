@@ -19,11 +19,13 @@ package asg
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
@@ -72,6 +74,22 @@ func (s *Service) SDKToAutoScalingGroup(v *autoscaling.Group) (*expinfrav1.AutoS
 		}
 	}
 
+	if v.WarmPoolConfiguration != nil && aws.StringValue(v.WarmPoolConfiguration.Status) != autoscaling.WarmPoolStatusPendingDelete {
+		i.WarmPool = &expinfrav1.WarmPool{
+			MinSize:   aws.Int32(int32(aws.Int64Value(v.WarmPoolConfiguration.MinSize))),
+			PoolState: expinfrav1.WarmPoolState(aws.StringValue(v.WarmPoolConfiguration.PoolState)),
+		}
+
+		// A maximum prepared capacity of -1 means the maximum size of the ASG is used.
+		if maxPrepared := aws.Int64Value(v.WarmPoolConfiguration.MaxGroupPreparedCapacity); v.WarmPoolConfiguration.MaxGroupPreparedCapacity != nil && maxPrepared >= 0 {
+			i.WarmPool.MaxGroupPreparedCapacity = aws.Int32(int32(maxPrepared))
+		}
+
+		if v.WarmPoolConfiguration.InstanceReusePolicy != nil {
+			i.WarmPool.ReuseOnScaleIn = aws.BoolValue(v.WarmPoolConfiguration.InstanceReusePolicy.ReuseOnScaleIn)
+		}
+	}
+
 	if v.Status != nil {
 		i.Status = expinfrav1.ASGStatus(*v.Status)
 	}
@@ -149,7 +167,48 @@ func (s *Service) GetASGByName(scope *scope.MachinePoolScope) (*expinfrav1.AutoS
 		return nil, nil
 	}
 
-	return s.SDKToAutoScalingGroup(out.AutoScalingGroups[0])
+	asg, err := s.SDKToAutoScalingGroup(out.AutoScalingGroups[0])
+	if err != nil {
+		return nil, err
+	}
+
+	if asg.WarmPool != nil {
+		if asg.WarmPoolInstances, err = s.describeWarmPoolInstances(asg.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	return asg, nil
+}
+
+// describeWarmPoolInstances returns the instances in the warm pool of an ASG.
+func (s *Service) describeWarmPoolInstances(name string) ([]infrav1.Instance, error) {
+	var instances []infrav1.Instance
+
+	input := &autoscaling.DescribeWarmPoolInput{
+		AutoScalingGroupName: aws.String(name),
+	}
+
+	for {
+		out, err := s.ASGClient.DescribeWarmPool(input)
+		if err != nil {
+			record.Eventf(s.scope.InfraCluster(), "FailedDescribeWarmPool", "Failed to describe warm pool of ASG %q: %v", name, err)
+			return nil, errors.Wrapf(err, "failed to describe warm pool of ASG %q", name)
+		}
+
+		for _, instance := range out.Instances {
+			instances = append(instances, infrav1.Instance{
+				ID:               aws.StringValue(instance.InstanceId),
+				State:            infrav1.InstanceState(aws.StringValue(instance.LifecycleState)),
+				AvailabilityZone: aws.StringValue(instance.AvailabilityZone),
+			})
+		}
+
+		if aws.StringValue(out.NextToken) == "" {
+			return instances, nil
+		}
+		input.NextToken = out.NextToken
+	}
 }
 
 // CreateASG runs an autoscaling group.
@@ -167,6 +226,7 @@ func (s *Service) CreateASG(scope *scope.MachinePoolScope) (*expinfrav1.AutoScal
 		DefaultCoolDown:      scope.AWSMachinePool.Spec.DefaultCoolDown,
 		CapacityRebalance:    scope.AWSMachinePool.Spec.CapacityRebalance,
 		MixedInstancesPolicy: scope.AWSMachinePool.Spec.MixedInstancesPolicy,
+		LifecycleHooks:       scope.AWSMachinePool.Spec.LifecycleHooks,
 	}
 
 	if scope.MachinePool.Spec.Replicas != nil {
@@ -228,6 +288,10 @@ func (s *Service) runPool(i *expinfrav1.AutoScalingGroup, launchTemplateID strin
 		}
 	}
 
+	if len(i.LifecycleHooks) > 0 {
+		input.LifecycleHookSpecificationList = createSDKLifecycleHookSpecificationList(i.LifecycleHooks)
+	}
+
 	if i.Tags != nil {
 		input.Tags = BuildTagsFromMap(i.Name, i.Tags)
 	}
@@ -284,8 +348,8 @@ func (s *Service) UpdateASG(scope *scope.MachinePoolScope) error {
 
 	input := &autoscaling.UpdateAutoScalingGroupInput{
 		AutoScalingGroupName: aws.String(scope.Name()), //TODO: define dynamically - borrow logic from ec2
-		MaxSize:              aws.Int64(int64(scope.AWSMachinePool.Spec.MaxSize)),
-		MinSize:              aws.Int64(int64(scope.AWSMachinePool.Spec.MinSize)),
+		MaxSize:              aws.Int64(int64(scope.MaxSize())),
+		MinSize:              aws.Int64(int64(scope.MinSize())),
 		VPCZoneIdentifier:    aws.String(strings.Join(subnetIDs, ", ")),
 		CapacityRebalance:    aws.Bool(scope.AWSMachinePool.Spec.CapacityRebalance),
 	}
@@ -310,6 +374,176 @@ func (s *Service) UpdateASG(scope *scope.MachinePoolScope) error {
 	return nil
 }
 
+// PutWarmPool creates or updates the warm pool of an ASG.
+func (s *Service) PutWarmPool(name string, warmPool *expinfrav1.WarmPool) error {
+	s.scope.Debug("Attempting to put warm pool", "name", name)
+
+	input := &autoscaling.PutWarmPoolInput{
+		AutoScalingGroupName: aws.String(name),
+		// A maximum prepared capacity of -1 means the maximum size of the ASG is used.
+		MaxGroupPreparedCapacity: aws.Int64(-1),
+		MinSize:                  aws.Int64(0),
+		PoolState:                aws.String(string(expinfrav1.WarmPoolStateStopped)),
+		InstanceReusePolicy: &autoscaling.InstanceReusePolicy{
+			ReuseOnScaleIn: aws.Bool(warmPool.ReuseOnScaleIn),
+		},
+	}
+
+	if warmPool.MaxGroupPreparedCapacity != nil {
+		input.MaxGroupPreparedCapacity = aws.Int64(int64(*warmPool.MaxGroupPreparedCapacity))
+	}
+
+	if warmPool.MinSize != nil {
+		input.MinSize = aws.Int64(int64(*warmPool.MinSize))
+	}
+
+	if warmPool.PoolState != "" {
+		input.PoolState = aws.String(string(warmPool.PoolState))
+	}
+
+	if _, err := s.ASGClient.PutWarmPool(input); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedPutWarmPool", "Failed to put warm pool for ASG %q: %v", name, err)
+		return errors.Wrapf(err, "failed to put warm pool for ASG %q", name)
+	}
+
+	s.scope.Debug("Put warm pool", "name", name)
+	return nil
+}
+
+// DeleteWarmPool deletes the warm pool of an ASG, terminating its instances.
+func (s *Service) DeleteWarmPool(name string) error {
+	s.scope.Debug("Attempting to delete warm pool", "name", name)
+
+	input := &autoscaling.DeleteWarmPoolInput{
+		AutoScalingGroupName: aws.String(name),
+		ForceDelete:          aws.Bool(true),
+	}
+
+	if _, err := s.ASGClient.DeleteWarmPool(input); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedDeleteWarmPool", "Failed to delete warm pool for ASG %q: %v", name, err)
+		return errors.Wrapf(err, "failed to delete warm pool for ASG %q", name)
+	}
+
+	s.scope.Debug("Deleted warm pool", "name", name)
+	return nil
+}
+
+// SDKToScheduledAction converts an AWS SDK ScheduledUpdateGroupAction to the CAPA AWSScheduledAction type.
+func SDKToScheduledAction(v *autoscaling.ScheduledUpdateGroupAction) *expinfrav1.AWSScheduledAction {
+	action := &expinfrav1.AWSScheduledAction{
+		Name:       aws.StringValue(v.ScheduledActionName),
+		Recurrence: v.Recurrence,
+		TimeZone:   v.TimeZone,
+	}
+
+	if v.StartTime != nil {
+		action.StartTime = &metav1.Time{Time: *v.StartTime}
+	}
+
+	if v.EndTime != nil {
+		action.EndTime = &metav1.Time{Time: *v.EndTime}
+	}
+
+	if v.MinSize != nil {
+		action.MinSize = aws.Int32(int32(*v.MinSize))
+	}
+
+	if v.MaxSize != nil {
+		action.MaxSize = aws.Int32(int32(*v.MaxSize))
+	}
+
+	if v.DesiredCapacity != nil {
+		action.DesiredCapacity = aws.Int32(int32(*v.DesiredCapacity))
+	}
+
+	return action
+}
+
+// DescribeScheduledActions returns the scheduled actions of an ASG.
+func (s *Service) DescribeScheduledActions(asgName string) ([]*expinfrav1.AWSScheduledAction, error) {
+	var actions []*expinfrav1.AWSScheduledAction
+
+	input := &autoscaling.DescribeScheduledActionsInput{
+		AutoScalingGroupName: aws.String(asgName),
+	}
+
+	for {
+		out, err := s.ASGClient.DescribeScheduledActions(input)
+		if err != nil {
+			record.Eventf(s.scope.InfraCluster(), "FailedDescribeScheduledActions", "Failed to describe scheduled actions of ASG %q: %v", asgName, err)
+			return nil, errors.Wrapf(err, "failed to describe scheduled actions of ASG %q", asgName)
+		}
+
+		for _, action := range out.ScheduledUpdateGroupActions {
+			actions = append(actions, SDKToScheduledAction(action))
+		}
+
+		if aws.StringValue(out.NextToken) == "" {
+			return actions, nil
+		}
+		input.NextToken = out.NextToken
+	}
+}
+
+// PutScheduledAction creates or updates a scheduled action of an ASG.
+func (s *Service) PutScheduledAction(asgName string, action *expinfrav1.AWSScheduledAction) error {
+	s.scope.Debug("Attempting to put scheduled action", "asg", asgName, "name", action.Name)
+
+	input := &autoscaling.PutScheduledUpdateGroupActionInput{
+		AutoScalingGroupName: aws.String(asgName),
+		ScheduledActionName:  aws.String(action.Name),
+		Recurrence:           action.Recurrence,
+		TimeZone:             action.TimeZone,
+	}
+
+	// AWS rejects start times in the past. A recurring action that started in the past is started now instead.
+	if action.StartTime != nil && (action.Recurrence == nil || action.StartTime.Time.After(time.Now())) {
+		input.StartTime = aws.Time(action.StartTime.Time)
+	}
+
+	if action.EndTime != nil {
+		input.EndTime = aws.Time(action.EndTime.Time)
+	}
+
+	if action.MinSize != nil {
+		input.MinSize = aws.Int64(int64(*action.MinSize))
+	}
+
+	if action.MaxSize != nil {
+		input.MaxSize = aws.Int64(int64(*action.MaxSize))
+	}
+
+	if action.DesiredCapacity != nil {
+		input.DesiredCapacity = aws.Int64(int64(*action.DesiredCapacity))
+	}
+
+	if _, err := s.ASGClient.PutScheduledUpdateGroupAction(input); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedPutScheduledAction", "Failed to put scheduled action %q for ASG %q: %v", action.Name, asgName, err)
+		return errors.Wrapf(err, "failed to put scheduled action %q for ASG %q", action.Name, asgName)
+	}
+
+	s.scope.Debug("Put scheduled action", "asg", asgName, "name", action.Name)
+	return nil
+}
+
+// DeleteScheduledAction deletes a scheduled action of an ASG.
+func (s *Service) DeleteScheduledAction(asgName, actionName string) error {
+	s.scope.Debug("Attempting to delete scheduled action", "asg", asgName, "name", actionName)
+
+	input := &autoscaling.DeleteScheduledActionInput{
+		AutoScalingGroupName: aws.String(asgName),
+		ScheduledActionName:  aws.String(actionName),
+	}
+
+	if _, err := s.ASGClient.DeleteScheduledAction(input); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedDeleteScheduledAction", "Failed to delete scheduled action %q for ASG %q: %v", actionName, asgName, err)
+		return errors.Wrapf(err, "failed to delete scheduled action %q for ASG %q", actionName, asgName)
+	}
+
+	s.scope.Debug("Deleted scheduled action", "asg", asgName, "name", actionName)
+	return nil
+}
+
 // CanStartASGInstanceRefresh will start an ASG instance with refresh.
 func (s *Service) CanStartASGInstanceRefresh(scope *scope.MachinePoolScope) (bool, error) {
 	describeInput := &autoscaling.DescribeInstanceRefreshesInput{AutoScalingGroupName: aws.String(scope.Name())}
@@ -336,7 +570,9 @@ func (s *Service) CanStartASGInstanceRefresh(scope *scope.MachinePoolScope) (boo
 // StartASGInstanceRefresh will start an ASG instance with refresh.
 func (s *Service) StartASGInstanceRefresh(scope *scope.MachinePoolScope) error {
 	strategy := pointer.StringPtr(autoscaling.RefreshStrategyRolling)
-	var minHealthyPercentage, instanceWarmup *int64
+	var minHealthyPercentage, instanceWarmup, checkpointDelay *int64
+	var checkpointPercentages []*int64
+	var skipMatching *bool
 	if scope.AWSMachinePool.Spec.RefreshPreferences != nil {
 		if scope.AWSMachinePool.Spec.RefreshPreferences.Strategy != nil {
 			strategy = scope.AWSMachinePool.Spec.RefreshPreferences.Strategy
@@ -347,14 +583,22 @@ func (s *Service) StartASGInstanceRefresh(scope *scope.MachinePoolScope) error {
 		if scope.AWSMachinePool.Spec.RefreshPreferences.MinHealthyPercentage != nil {
 			minHealthyPercentage = scope.AWSMachinePool.Spec.RefreshPreferences.MinHealthyPercentage
 		}
+		if len(scope.AWSMachinePool.Spec.RefreshPreferences.CheckpointPercentages) > 0 {
+			checkpointPercentages = aws.Int64Slice(scope.AWSMachinePool.Spec.RefreshPreferences.CheckpointPercentages)
+			checkpointDelay = scope.AWSMachinePool.Spec.RefreshPreferences.CheckpointDelay
+		}
+		skipMatching = scope.AWSMachinePool.Spec.RefreshPreferences.SkipMatching
 	}
 
 	input := &autoscaling.StartInstanceRefreshInput{
 		AutoScalingGroupName: aws.String(scope.Name()),
 		Strategy:             strategy,
 		Preferences: &autoscaling.RefreshPreferences{
-			InstanceWarmup:       instanceWarmup,
-			MinHealthyPercentage: minHealthyPercentage,
+			InstanceWarmup:        instanceWarmup,
+			MinHealthyPercentage:  minHealthyPercentage,
+			CheckpointPercentages: checkpointPercentages,
+			CheckpointDelay:       checkpointDelay,
+			SkipMatching:          skipMatching,
 		},
 	}
 
@@ -365,6 +609,67 @@ func (s *Service) StartASGInstanceRefresh(scope *scope.MachinePoolScope) error {
 	return nil
 }
 
+// GetLatestASGInstanceRefresh returns the status of the most recent instance refresh of an ASG,
+// or nil if the ASG was never refreshed.
+func (s *Service) GetLatestASGInstanceRefresh(name string) (*expinfrav1.AWSMachinePoolInstanceRefreshStatus, error) {
+	input := &autoscaling.DescribeInstanceRefreshesInput{
+		AutoScalingGroupName: aws.String(name),
+		MaxRecords:           aws.Int64(1),
+	}
+
+	out, err := s.ASGClient.DescribeInstanceRefreshes(input)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe instance refreshes of ASG %q", name)
+	}
+
+	// Instance refreshes are returned most recent first.
+	if len(out.InstanceRefreshes) == 0 {
+		return nil, nil
+	}
+
+	return SDKToInstanceRefreshStatus(out.InstanceRefreshes[0]), nil
+}
+
+// SDKToInstanceRefreshStatus converts an AWS SDK InstanceRefresh to the CAPA AWSMachinePoolInstanceRefreshStatus type.
+func SDKToInstanceRefreshStatus(v *autoscaling.InstanceRefresh) *expinfrav1.AWSMachinePoolInstanceRefreshStatus {
+	status := &expinfrav1.AWSMachinePoolInstanceRefreshStatus{
+		ID:                 aws.StringValue(v.InstanceRefreshId),
+		Status:             aws.StringValue(v.Status),
+		StatusReason:       aws.StringValue(v.StatusReason),
+		PercentageComplete: v.PercentageComplete,
+		InstancesToUpdate:  v.InstancesToUpdate,
+	}
+
+	if v.StartTime != nil {
+		status.StartTime = &metav1.Time{Time: *v.StartTime}
+	}
+
+	if v.EndTime != nil {
+		status.EndTime = &metav1.Time{Time: *v.EndTime}
+	}
+
+	return status
+}
+
+// CancelASGInstanceRefresh cancels the active instance refresh of an ASG, if there is one.
+func (s *Service) CancelASGInstanceRefresh(name string) error {
+	input := &autoscaling.CancelInstanceRefreshInput{
+		AutoScalingGroupName: aws.String(name),
+	}
+
+	_, err := s.ASGClient.CancelInstanceRefresh(input)
+	if code, _ := awserrors.Code(err); code == autoscaling.ErrCodeActiveInstanceRefreshNotFoundFault {
+		return nil
+	}
+	if err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedCancelInstanceRefresh", "Failed to cancel instance refresh of ASG %q: %v", name, err)
+		return errors.Wrapf(err, "failed to cancel instance refresh of ASG %q", name)
+	}
+
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulCancelInstanceRefresh", "Cancelled instance refresh of ASG %q", name)
+	return nil
+}
+
 func createSDKMixedInstancesPolicy(name string, i *expinfrav1.MixedInstancesPolicy) *autoscaling.MixedInstancesPolicy {
 	mixedInstancesPolicy := &autoscaling.MixedInstancesPolicy{
 		LaunchTemplate: &autoscaling.LaunchTemplate{
@@ -19,8 +19,10 @@ package asg
 import (
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/golang/mock/gomock"
@@ -45,11 +47,12 @@ func TestServiceGetASGByName(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 	tests := []struct {
-		name            string
-		machinePoolName string
-		wantErr         bool
-		wantASG         bool
-		expect          func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+		name                  string
+		machinePoolName       string
+		wantErr               bool
+		wantASG               bool
+		wantWarmPoolInstances []infrav1.Instance
+		expect                func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
 	}{
 		{
 			name:            "should return nil if ASG is not found",
@@ -104,6 +107,64 @@ func TestServiceGetASGByName(t *testing.T) {
 						}}, nil)
 			},
 		},
+		{
+			name:            "should return ASG with the instances in its warm pool",
+			machinePoolName: "test-group-with-warm-pool",
+			wantErr:         false,
+			wantASG:         true,
+			wantWarmPoolInstances: []infrav1.Instance{
+				{
+					ID:               "instance-1",
+					State:            "Warmed:Stopped",
+					AvailabilityZone: "us-east-1a",
+				},
+				{
+					ID:               "instance-2",
+					State:            "Warmed:Pending",
+					AvailabilityZone: "us-east-1b",
+				},
+			},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DescribeAutoScalingGroups(gomock.Eq(&autoscaling.DescribeAutoScalingGroupsInput{
+					AutoScalingGroupNames: []*string{
+						aws.String("test-group-with-warm-pool"),
+					},
+				})).
+					Return(&autoscaling.DescribeAutoScalingGroupsOutput{
+						AutoScalingGroups: []*autoscaling.Group{
+							{
+								AutoScalingGroupName:  aws.String("test-group-with-warm-pool"),
+								WarmPoolConfiguration: &autoscaling.WarmPoolConfiguration{},
+							},
+						}}, nil)
+				m.DescribeWarmPool(gomock.Eq(&autoscaling.DescribeWarmPoolInput{
+					AutoScalingGroupName: aws.String("test-group-with-warm-pool"),
+				})).
+					Return(&autoscaling.DescribeWarmPoolOutput{
+						Instances: []*autoscaling.Instance{
+							{
+								InstanceId:       aws.String("instance-1"),
+								LifecycleState:   aws.String("Warmed:Stopped"),
+								AvailabilityZone: aws.String("us-east-1a"),
+							},
+						},
+						NextToken: aws.String("next"),
+					}, nil)
+				m.DescribeWarmPool(gomock.Eq(&autoscaling.DescribeWarmPoolInput{
+					AutoScalingGroupName: aws.String("test-group-with-warm-pool"),
+					NextToken:            aws.String("next"),
+				})).
+					Return(&autoscaling.DescribeWarmPoolOutput{
+						Instances: []*autoscaling.Instance{
+							{
+								InstanceId:       aws.String("instance-2"),
+								LifecycleState:   aws.String("Warmed:Pending"),
+								AvailabilityZone: aws.String("us-east-1b"),
+							},
+						},
+					}, nil)
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -124,6 +185,9 @@ func TestServiceGetASGByName(t *testing.T) {
 			asg, err := s.GetASGByName(mps)
 			checkErr(tt.wantErr, err, g)
 			checkASG(tt.wantASG, asg, g)
+			if asg != nil {
+				g.Expect(asg.WarmPoolInstances).To(Equal(tt.wantWarmPoolInstances))
+			}
 		})
 	}
 }
@@ -279,6 +343,53 @@ func TestServiceSDKToAutoScalingGroup(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid input - warm pool",
+			input: &autoscaling.Group{
+				DesiredCapacity: aws.Int64(1234),
+				MaxSize:         aws.Int64(1234),
+				MinSize:         aws.Int64(1234),
+				WarmPoolConfiguration: &autoscaling.WarmPoolConfiguration{
+					MaxGroupPreparedCapacity: aws.Int64(-1),
+					MinSize:                  aws.Int64(2),
+					PoolState:                aws.String("Running"),
+					InstanceReusePolicy: &autoscaling.InstanceReusePolicy{
+						ReuseOnScaleIn: aws.Bool(true),
+					},
+				},
+			},
+			want: &expinfrav1.AutoScalingGroup{
+				DesiredCapacity: aws.Int32(1234),
+				MaxSize:         int32(1234),
+				MinSize:         int32(1234),
+				WarmPool: &expinfrav1.WarmPool{
+					MinSize:        aws.Int32(2),
+					PoolState:      expinfrav1.WarmPoolStateRunning,
+					ReuseOnScaleIn: true,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid input - warm pool pending deletion",
+			input: &autoscaling.Group{
+				DesiredCapacity: aws.Int64(1234),
+				MaxSize:         aws.Int64(1234),
+				MinSize:         aws.Int64(1234),
+				WarmPoolConfiguration: &autoscaling.WarmPoolConfiguration{
+					MaxGroupPreparedCapacity: aws.Int64(5),
+					MinSize:                  aws.Int64(2),
+					PoolState:                aws.String("Stopped"),
+					Status:                   aws.String("PendingDelete"),
+				},
+			},
+			want: &expinfrav1.AutoScalingGroup{
+				DesiredCapacity: aws.Int32(1234),
+				MaxSize:         int32(1234),
+				MinSize:         int32(1234),
+			},
+			wantErr: false,
+		},
 		{
 			name: "valid input - without mixedInstancesPolicy",
 			input: &autoscaling.Group{
@@ -852,6 +963,135 @@ func TestServiceDeleteASG(t *testing.T) {
 	}
 }
 
+func TestServicePutWarmPool(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name     string
+		warmPool *expinfrav1.WarmPool
+		wantErr  bool
+		expect   func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+	}{
+		{
+			name:     "should use the AWS defaults for unset fields",
+			warmPool: &expinfrav1.WarmPool{},
+			wantErr:  false,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.PutWarmPool(gomock.Eq(&autoscaling.PutWarmPoolInput{
+					AutoScalingGroupName:     aws.String("asgName"),
+					MaxGroupPreparedCapacity: aws.Int64(-1),
+					MinSize:                  aws.Int64(0),
+					PoolState:                aws.String("Stopped"),
+					InstanceReusePolicy: &autoscaling.InstanceReusePolicy{
+						ReuseOnScaleIn: aws.Bool(false),
+					},
+				})).
+					Return(&autoscaling.PutWarmPoolOutput{}, nil)
+			},
+		},
+		{
+			name: "should put the warm pool with all fields set",
+			warmPool: &expinfrav1.WarmPool{
+				MinSize:                  aws.Int32(1),
+				MaxGroupPreparedCapacity: aws.Int32(4),
+				PoolState:                expinfrav1.WarmPoolStateRunning,
+				ReuseOnScaleIn:           true,
+			},
+			wantErr: false,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.PutWarmPool(gomock.Eq(&autoscaling.PutWarmPoolInput{
+					AutoScalingGroupName:     aws.String("asgName"),
+					MaxGroupPreparedCapacity: aws.Int64(4),
+					MinSize:                  aws.Int64(1),
+					PoolState:                aws.String("Running"),
+					InstanceReusePolicy: &autoscaling.InstanceReusePolicy{
+						ReuseOnScaleIn: aws.Bool(true),
+					},
+				})).
+					Return(&autoscaling.PutWarmPoolOutput{}, nil)
+			},
+		},
+		{
+			name:     "should return error if put warm pool failed",
+			warmPool: &expinfrav1.WarmPool{},
+			wantErr:  true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.PutWarmPool(gomock.Any()).
+					Return(nil, awserrors.NewFailedDependency("dependency failure"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			tt.expect(asgMock.EXPECT())
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			err = s.PutWarmPool("asgName", tt.warmPool)
+			checkErr(tt.wantErr, err, g)
+		})
+	}
+}
+
+func TestServiceDeleteWarmPool(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name    string
+		wantErr bool
+		expect  func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+	}{
+		{
+			name:    "Delete warm pool successful",
+			wantErr: false,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DeleteWarmPool(gomock.Eq(&autoscaling.DeleteWarmPoolInput{
+					AutoScalingGroupName: aws.String("asgName"),
+					ForceDelete:          aws.Bool(true),
+				})).
+					Return(&autoscaling.DeleteWarmPoolOutput{}, nil)
+			},
+		},
+		{
+			name:    "Delete warm pool should fail when deletion fails",
+			wantErr: true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DeleteWarmPool(gomock.Eq(&autoscaling.DeleteWarmPoolInput{
+					AutoScalingGroupName: aws.String("asgName"),
+					ForceDelete:          aws.Bool(true),
+				})).
+					Return(nil, awserrors.NewFailedDependency("dependency failure"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			tt.expect(asgMock.EXPECT())
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			err = s.DeleteWarmPool("asgName")
+			checkErr(tt.wantErr, err, g)
+		})
+	}
+}
+
 func TestServiceDeleteASGAndWait(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -1005,9 +1245,10 @@ func TestServiceStartASGInstanceRefresh(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	tests := []struct {
-		name    string
-		wantErr bool
-		expect  func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+		name               string
+		refreshPreferences *expinfrav1.RefreshPreferences
+		wantErr            bool
+		expect             func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
 	}{
 		{
 			name:    "should return error if start instance refresh failed",
@@ -1039,6 +1280,27 @@ func TestServiceStartASGInstanceRefresh(t *testing.T) {
 					Return(&autoscaling.StartInstanceRefreshOutput{}, nil)
 			},
 		},
+		{
+			name: "should pass checkpoints and skip matching to the instance refresh",
+			refreshPreferences: &expinfrav1.RefreshPreferences{
+				CheckpointPercentages: []int64{20, 100},
+				CheckpointDelay:       aws.Int64(600),
+				SkipMatching:          aws.Bool(true),
+			},
+			wantErr: false,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.StartInstanceRefresh(gomock.Eq(&autoscaling.StartInstanceRefreshInput{
+					AutoScalingGroupName: aws.String("mpn"),
+					Strategy:             aws.String("Rolling"),
+					Preferences: &autoscaling.RefreshPreferences{
+						CheckpointPercentages: aws.Int64Slice([]int64{20, 100}),
+						CheckpointDelay:       aws.Int64(600),
+						SkipMatching:          aws.Bool(true),
+					},
+				})).
+					Return(&autoscaling.StartInstanceRefreshOutput{}, nil)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1056,6 +1318,9 @@ func TestServiceStartASGInstanceRefresh(t *testing.T) {
 			mps, err := getMachinePoolScope(fakeClient, clusterScope)
 			g.Expect(err).ToNot(HaveOccurred())
 			mps.AWSMachinePool.Name = "mpn"
+			if tt.refreshPreferences != nil {
+				mps.AWSMachinePool.Spec.RefreshPreferences = tt.refreshPreferences
+			}
 
 			err = s.StartASGInstanceRefresh(mps)
 			checkErr(tt.wantErr, err, g)
@@ -1063,6 +1328,372 @@ func TestServiceStartASGInstanceRefresh(t *testing.T) {
 	}
 }
 
+func TestServiceGetLatestASGInstanceRefresh(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	startTime := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		want    *expinfrav1.AWSMachinePoolInstanceRefreshStatus
+		wantErr bool
+		expect  func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+	}{
+		{
+			name: "should return the most recent instance refresh",
+			want: &expinfrav1.AWSMachinePoolInstanceRefreshStatus{
+				ID:                 "refresh-2",
+				Status:             "InProgress",
+				StatusReason:       "Waiting for checkpoint",
+				PercentageComplete: aws.Int64(20),
+				InstancesToUpdate:  aws.Int64(4),
+				StartTime:          &metav1.Time{Time: startTime},
+			},
+			wantErr: false,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DescribeInstanceRefreshes(gomock.Eq(&autoscaling.DescribeInstanceRefreshesInput{
+					AutoScalingGroupName: aws.String("asgName"),
+					MaxRecords:           aws.Int64(1),
+				})).
+					Return(&autoscaling.DescribeInstanceRefreshesOutput{
+						InstanceRefreshes: []*autoscaling.InstanceRefresh{
+							{
+								InstanceRefreshId:  aws.String("refresh-2"),
+								Status:             aws.String("InProgress"),
+								StatusReason:       aws.String("Waiting for checkpoint"),
+								PercentageComplete: aws.Int64(20),
+								InstancesToUpdate:  aws.Int64(4),
+								StartTime:          aws.Time(startTime),
+							},
+						},
+					}, nil)
+			},
+		},
+		{
+			name:    "should return nil if the ASG was never refreshed",
+			want:    nil,
+			wantErr: false,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DescribeInstanceRefreshes(gomock.Any()).
+					Return(&autoscaling.DescribeInstanceRefreshesOutput{}, nil)
+			},
+		},
+		{
+			name:    "should return error if describe instance refreshes failed",
+			wantErr: true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DescribeInstanceRefreshes(gomock.Any()).
+					Return(nil, awserrors.NewFailedDependency("dependency failure"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			tt.expect(asgMock.EXPECT())
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			refresh, err := s.GetLatestASGInstanceRefresh("asgName")
+			checkErr(tt.wantErr, err, g)
+			g.Expect(refresh).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestServiceCancelASGInstanceRefresh(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	input := &autoscaling.CancelInstanceRefreshInput{
+		AutoScalingGroupName: aws.String("asgName"),
+	}
+	tests := []struct {
+		name    string
+		wantErr bool
+		expect  func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+	}{
+		{
+			name:    "should cancel the active instance refresh",
+			wantErr: false,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.CancelInstanceRefresh(gomock.Eq(input)).
+					Return(&autoscaling.CancelInstanceRefreshOutput{InstanceRefreshId: aws.String("refresh-1")}, nil)
+			},
+		},
+		{
+			name:    "should return nil if there is no active instance refresh",
+			wantErr: false,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.CancelInstanceRefresh(gomock.Eq(input)).
+					Return(nil, awserr.New(autoscaling.ErrCodeActiveInstanceRefreshNotFoundFault, "not found", nil))
+			},
+		},
+		{
+			name:    "should return error if cancel instance refresh failed",
+			wantErr: true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.CancelInstanceRefresh(gomock.Eq(input)).
+					Return(nil, awserrors.NewFailedDependency("dependency failure"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			tt.expect(asgMock.EXPECT())
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			err = s.CancelASGInstanceRefresh("asgName")
+			checkErr(tt.wantErr, err, g)
+		})
+	}
+}
+
+func TestServiceDescribeScheduledActions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	startTime := time.Date(2022, 10, 3, 18, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		want    []*expinfrav1.AWSScheduledAction
+		wantErr bool
+		expect  func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+	}{
+		{
+			name: "should return the scheduled actions of all pages",
+			want: []*expinfrav1.AWSScheduledAction{
+				{
+					Name:            "scale-down",
+					Recurrence:      aws.String("0 20 * * 1-5"),
+					StartTime:       &metav1.Time{Time: startTime},
+					TimeZone:        aws.String("Europe/Berlin"),
+					MinSize:         aws.Int32(0),
+					DesiredCapacity: aws.Int32(0),
+				},
+				{
+					Name:            "scale-up",
+					Recurrence:      aws.String("0 8 * * 1-5"),
+					DesiredCapacity: aws.Int32(3),
+				},
+			},
+			wantErr: false,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DescribeScheduledActions(gomock.Eq(&autoscaling.DescribeScheduledActionsInput{
+					AutoScalingGroupName: aws.String("asgName"),
+				})).
+					Return(&autoscaling.DescribeScheduledActionsOutput{
+						ScheduledUpdateGroupActions: []*autoscaling.ScheduledUpdateGroupAction{
+							{
+								AutoScalingGroupName: aws.String("asgName"),
+								ScheduledActionName:  aws.String("scale-down"),
+								Recurrence:           aws.String("0 20 * * 1-5"),
+								StartTime:            aws.Time(startTime),
+								TimeZone:             aws.String("Europe/Berlin"),
+								MinSize:              aws.Int64(0),
+								DesiredCapacity:      aws.Int64(0),
+							},
+						},
+						NextToken: aws.String("next"),
+					}, nil)
+				m.DescribeScheduledActions(gomock.Eq(&autoscaling.DescribeScheduledActionsInput{
+					AutoScalingGroupName: aws.String("asgName"),
+					NextToken:            aws.String("next"),
+				})).
+					Return(&autoscaling.DescribeScheduledActionsOutput{
+						ScheduledUpdateGroupActions: []*autoscaling.ScheduledUpdateGroupAction{
+							{
+								AutoScalingGroupName: aws.String("asgName"),
+								ScheduledActionName:  aws.String("scale-up"),
+								Recurrence:           aws.String("0 8 * * 1-5"),
+								DesiredCapacity:      aws.Int64(3),
+							},
+						},
+					}, nil)
+			},
+		},
+		{
+			name:    "should return error if describe scheduled actions failed",
+			wantErr: true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DescribeScheduledActions(gomock.Any()).
+					Return(nil, awserrors.NewFailedDependency("dependency failure"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			tt.expect(asgMock.EXPECT())
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			actions, err := s.DescribeScheduledActions("asgName")
+			checkErr(tt.wantErr, err, g)
+			g.Expect(actions).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestServicePutScheduledAction(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	pastTime := time.Now().Add(-24 * time.Hour).Truncate(time.Second)
+	futureTime := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	tests := []struct {
+		name    string
+		action  *expinfrav1.AWSScheduledAction
+		wantErr bool
+		expect  func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+	}{
+		{
+			name: "should put a one-time scheduled action",
+			action: &expinfrav1.AWSScheduledAction{
+				Name:            "scale-up",
+				StartTime:       &metav1.Time{Time: futureTime},
+				MinSize:         aws.Int32(1),
+				MaxSize:         aws.Int32(5),
+				DesiredCapacity: aws.Int32(3),
+			},
+			wantErr: false,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.PutScheduledUpdateGroupAction(gomock.Eq(&autoscaling.PutScheduledUpdateGroupActionInput{
+					AutoScalingGroupName: aws.String("asgName"),
+					ScheduledActionName:  aws.String("scale-up"),
+					StartTime:            aws.Time(futureTime),
+					MinSize:              aws.Int64(1),
+					MaxSize:              aws.Int64(5),
+					DesiredCapacity:      aws.Int64(3),
+				})).
+					Return(&autoscaling.PutScheduledUpdateGroupActionOutput{}, nil)
+			},
+		},
+		{
+			name: "should not pass the start time of a recurring scheduled action that already started",
+			action: &expinfrav1.AWSScheduledAction{
+				Name:            "scale-down",
+				Recurrence:      aws.String("0 20 * * 1-5"),
+				StartTime:       &metav1.Time{Time: pastTime},
+				EndTime:         &metav1.Time{Time: futureTime},
+				TimeZone:        aws.String("Europe/Berlin"),
+				DesiredCapacity: aws.Int32(0),
+			},
+			wantErr: false,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.PutScheduledUpdateGroupAction(gomock.Eq(&autoscaling.PutScheduledUpdateGroupActionInput{
+					AutoScalingGroupName: aws.String("asgName"),
+					ScheduledActionName:  aws.String("scale-down"),
+					Recurrence:           aws.String("0 20 * * 1-5"),
+					EndTime:              aws.Time(futureTime),
+					TimeZone:             aws.String("Europe/Berlin"),
+					DesiredCapacity:      aws.Int64(0),
+				})).
+					Return(&autoscaling.PutScheduledUpdateGroupActionOutput{}, nil)
+			},
+		},
+		{
+			name: "should return error if put scheduled action failed",
+			action: &expinfrav1.AWSScheduledAction{
+				Name:            "scale-down",
+				Recurrence:      aws.String("0 20 * * 1-5"),
+				DesiredCapacity: aws.Int32(0),
+			},
+			wantErr: true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.PutScheduledUpdateGroupAction(gomock.Any()).
+					Return(nil, awserrors.NewFailedDependency("dependency failure"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			tt.expect(asgMock.EXPECT())
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			err = s.PutScheduledAction("asgName", tt.action)
+			checkErr(tt.wantErr, err, g)
+		})
+	}
+}
+
+func TestServiceDeleteScheduledAction(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	input := &autoscaling.DeleteScheduledActionInput{
+		AutoScalingGroupName: aws.String("asgName"),
+		ScheduledActionName:  aws.String("scale-down"),
+	}
+	tests := []struct {
+		name    string
+		wantErr bool
+		expect  func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+	}{
+		{
+			name:    "should delete the scheduled action",
+			wantErr: false,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DeleteScheduledAction(gomock.Eq(input)).
+					Return(&autoscaling.DeleteScheduledActionOutput{}, nil)
+			},
+		},
+		{
+			name:    "should return error if delete scheduled action failed",
+			wantErr: true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DeleteScheduledAction(gomock.Eq(input)).
+					Return(nil, awserrors.NewFailedDependency("dependency failure"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			tt.expect(asgMock.EXPECT())
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			err = s.DeleteScheduledAction("asgName", "scale-down")
+			checkErr(tt.wantErr, err, g)
+		})
+	}
+}
+
 func getFakeClient() client.Client {
 	scheme := runtime.NewScheme()
 	_ = infrav1.AddToScheme(scheme)
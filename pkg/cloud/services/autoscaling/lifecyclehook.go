@@ -0,0 +1,167 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package asg
+
+import (
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+// SDKToLifecycleHook converts an AWS SDK LifecycleHook to the CAPA AWSLifecycleHook type.
+func SDKToLifecycleHook(v *autoscaling.LifecycleHook) *expinfrav1.AWSLifecycleHook {
+	hook := &expinfrav1.AWSLifecycleHook{
+		Name:                  aws.StringValue(v.LifecycleHookName),
+		LifecycleTransition:   expinfrav1.LifecycleTransition(aws.StringValue(v.LifecycleTransition)),
+		NotificationTargetARN: v.NotificationTargetARN,
+		RoleARN:               v.RoleARN,
+		NotificationMetadata:  v.NotificationMetadata,
+	}
+
+	if v.HeartbeatTimeout != nil {
+		hook.HeartbeatTimeout = &metav1.Duration{Duration: time.Duration(*v.HeartbeatTimeout) * time.Second}
+	}
+
+	if v.DefaultResult != nil {
+		defaultResult := expinfrav1.LifecycleHookDefaultResult(*v.DefaultResult)
+		hook.DefaultResult = &defaultResult
+	}
+
+	return hook
+}
+
+// DescribeLifecycleHooks returns the lifecycle hooks of an ASG.
+func (s *Service) DescribeLifecycleHooks(asgName string) ([]*expinfrav1.AWSLifecycleHook, error) {
+	input := &autoscaling.DescribeLifecycleHooksInput{
+		AutoScalingGroupName: aws.String(asgName),
+	}
+
+	out, err := s.ASGClient.DescribeLifecycleHooks(input)
+	if err != nil {
+		record.Eventf(s.scope.InfraCluster(), "FailedDescribeLifecycleHooks", "Failed to describe lifecycle hooks of ASG %q: %v", asgName, err)
+		return nil, errors.Wrapf(err, "failed to describe lifecycle hooks of ASG %q", asgName)
+	}
+
+	hooks := make([]*expinfrav1.AWSLifecycleHook, len(out.LifecycleHooks))
+	for i, hook := range out.LifecycleHooks {
+		hooks[i] = SDKToLifecycleHook(hook)
+	}
+
+	return hooks, nil
+}
+
+// PutLifecycleHook creates or updates a lifecycle hook of an ASG.
+func (s *Service) PutLifecycleHook(asgName string, hook *expinfrav1.AWSLifecycleHook) error {
+	s.scope.Debug("Attempting to put lifecycle hook", "asg", asgName, "name", hook.Name)
+
+	input := &autoscaling.PutLifecycleHookInput{
+		AutoScalingGroupName:  aws.String(asgName),
+		LifecycleHookName:     aws.String(hook.Name),
+		LifecycleTransition:   aws.String(string(hook.LifecycleTransition)),
+		NotificationTargetARN: hook.NotificationTargetARN,
+		RoleARN:               hook.RoleARN,
+		NotificationMetadata:  hook.NotificationMetadata,
+	}
+
+	if hook.HeartbeatTimeout != nil {
+		input.HeartbeatTimeout = aws.Int64(int64(hook.HeartbeatTimeout.Duration.Seconds()))
+	}
+
+	if hook.DefaultResult != nil {
+		input.DefaultResult = aws.String(string(*hook.DefaultResult))
+	}
+
+	if _, err := s.ASGClient.PutLifecycleHook(input); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedPutLifecycleHook", "Failed to put lifecycle hook %q for ASG %q: %v", hook.Name, asgName, err)
+		return errors.Wrapf(err, "failed to put lifecycle hook %q for ASG %q", hook.Name, asgName)
+	}
+
+	s.scope.Debug("Put lifecycle hook", "asg", asgName, "name", hook.Name)
+	return nil
+}
+
+// DeleteLifecycleHook deletes a lifecycle hook of an ASG.
+func (s *Service) DeleteLifecycleHook(asgName, hookName string) error {
+	s.scope.Debug("Attempting to delete lifecycle hook", "asg", asgName, "name", hookName)
+
+	input := &autoscaling.DeleteLifecycleHookInput{
+		AutoScalingGroupName: aws.String(asgName),
+		LifecycleHookName:    aws.String(hookName),
+	}
+
+	if _, err := s.ASGClient.DeleteLifecycleHook(input); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedDeleteLifecycleHook", "Failed to delete lifecycle hook %q for ASG %q: %v", hookName, asgName, err)
+		return errors.Wrapf(err, "failed to delete lifecycle hook %q for ASG %q", hookName, asgName)
+	}
+
+	s.scope.Debug("Deleted lifecycle hook", "asg", asgName, "name", hookName)
+	return nil
+}
+
+// CompleteLifecycleAction continues the transition of an instance waiting on a lifecycle hook of an ASG.
+func (s *Service) CompleteLifecycleAction(asgName, hookName, instanceID string) error {
+	input := &autoscaling.CompleteLifecycleActionInput{
+		AutoScalingGroupName:  aws.String(asgName),
+		LifecycleHookName:     aws.String(hookName),
+		InstanceId:            aws.String(instanceID),
+		LifecycleActionResult: aws.String(string(expinfrav1.LifecycleHookDefaultResultContinue)),
+	}
+
+	_, err := s.ASGClient.CompleteLifecycleAction(input)
+	if code, _ := awserrors.Code(err); code == "ValidationError" && strings.Contains(awserrors.Message(err), "No active Lifecycle Action found") {
+		// The instance isn't waiting on the lifecycle hook, e.g. because the hook was added after the instance
+		// started terminating, so there is no lifecycle action to complete.
+		return nil
+	}
+	if err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedCompleteLifecycleAction", "Failed to complete lifecycle action of hook %q for instance %q: %v", hookName, instanceID, err)
+		return errors.Wrapf(err, "failed to complete lifecycle action of hook %q for instance %q", hookName, instanceID)
+	}
+
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulCompleteLifecycleAction", "Completed lifecycle action of hook %q for instance %q", hookName, instanceID)
+	return nil
+}
+
+func createSDKLifecycleHookSpecificationList(hooks []expinfrav1.AWSLifecycleHook) []*autoscaling.LifecycleHookSpecification {
+	specifications := make([]*autoscaling.LifecycleHookSpecification, len(hooks))
+	for i, hook := range hooks {
+		specifications[i] = &autoscaling.LifecycleHookSpecification{
+			LifecycleHookName:     aws.String(hook.Name),
+			LifecycleTransition:   aws.String(string(hook.LifecycleTransition)),
+			NotificationTargetARN: hook.NotificationTargetARN,
+			RoleARN:               hook.RoleARN,
+			NotificationMetadata:  hook.NotificationMetadata,
+		}
+
+		if hook.HeartbeatTimeout != nil {
+			specifications[i].HeartbeatTimeout = aws.Int64(int64(hook.HeartbeatTimeout.Duration.Seconds()))
+		}
+
+		if hook.DefaultResult != nil {
+			specifications[i].DefaultResult = aws.String(string(*hook.DefaultResult))
+		}
+	}
+	return specifications
+}
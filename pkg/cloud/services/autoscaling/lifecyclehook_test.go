@@ -0,0 +1,249 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package asg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/autoscaling/mock_autoscalingiface"
+)
+
+func TestServiceDescribeLifecycleHooks(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	defaultResult := expinfrav1.LifecycleHookDefaultResultContinue
+	tests := []struct {
+		name    string
+		want    []*expinfrav1.AWSLifecycleHook
+		wantErr bool
+		expect  func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+	}{
+		{
+			name: "should return the lifecycle hooks of the ASG",
+			want: []*expinfrav1.AWSLifecycleHook{
+				{
+					Name:                  "launching",
+					LifecycleTransition:   expinfrav1.LifecycleTransitionInstanceLaunching,
+					NotificationTargetARN: aws.String("arn:aws:sns:us-east-1:123456789012:topic"),
+					RoleARN:               aws.String("arn:aws:iam::123456789012:role/hooks"),
+					HeartbeatTimeout:      &metav1.Duration{Duration: 5 * time.Minute},
+					DefaultResult:         &defaultResult,
+				},
+			},
+			wantErr: false,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DescribeLifecycleHooks(gomock.Eq(&autoscaling.DescribeLifecycleHooksInput{
+					AutoScalingGroupName: aws.String("asgName"),
+				})).
+					Return(&autoscaling.DescribeLifecycleHooksOutput{
+						LifecycleHooks: []*autoscaling.LifecycleHook{
+							{
+								AutoScalingGroupName:  aws.String("asgName"),
+								LifecycleHookName:     aws.String("launching"),
+								LifecycleTransition:   aws.String("autoscaling:EC2_INSTANCE_LAUNCHING"),
+								NotificationTargetARN: aws.String("arn:aws:sns:us-east-1:123456789012:topic"),
+								RoleARN:               aws.String("arn:aws:iam::123456789012:role/hooks"),
+								HeartbeatTimeout:      aws.Int64(300),
+								DefaultResult:         aws.String("CONTINUE"),
+							},
+						},
+					}, nil)
+			},
+		},
+		{
+			name:    "should return error if describe lifecycle hooks failed",
+			wantErr: true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DescribeLifecycleHooks(gomock.Any()).
+					Return(nil, awserrors.NewFailedDependency("dependency failure"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			tt.expect(asgMock.EXPECT())
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			hooks, err := s.DescribeLifecycleHooks("asgName")
+			checkErr(tt.wantErr, err, g)
+			g.Expect(hooks).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestServicePutLifecycleHook(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	defaultResult := expinfrav1.LifecycleHookDefaultResultContinue
+	tests := []struct {
+		name    string
+		hook    *expinfrav1.AWSLifecycleHook
+		wantErr bool
+		expect  func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+	}{
+		{
+			name: "should put a lifecycle hook with only the required fields",
+			hook: &expinfrav1.AWSLifecycleHook{
+				Name:                "terminating",
+				LifecycleTransition: expinfrav1.LifecycleTransitionInstanceTerminating,
+			},
+			wantErr: false,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.PutLifecycleHook(gomock.Eq(&autoscaling.PutLifecycleHookInput{
+					AutoScalingGroupName: aws.String("asgName"),
+					LifecycleHookName:    aws.String("terminating"),
+					LifecycleTransition:  aws.String("autoscaling:EC2_INSTANCE_TERMINATING"),
+				})).
+					Return(&autoscaling.PutLifecycleHookOutput{}, nil)
+			},
+		},
+		{
+			name: "should put a lifecycle hook with all fields set",
+			hook: &expinfrav1.AWSLifecycleHook{
+				Name:                  "launching",
+				LifecycleTransition:   expinfrav1.LifecycleTransitionInstanceLaunching,
+				NotificationTargetARN: aws.String("arn:aws:sns:us-east-1:123456789012:topic"),
+				RoleARN:               aws.String("arn:aws:iam::123456789012:role/hooks"),
+				HeartbeatTimeout:      &metav1.Duration{Duration: 5 * time.Minute},
+				DefaultResult:         &defaultResult,
+				NotificationMetadata:  aws.String("metadata"),
+			},
+			wantErr: false,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.PutLifecycleHook(gomock.Eq(&autoscaling.PutLifecycleHookInput{
+					AutoScalingGroupName:  aws.String("asgName"),
+					LifecycleHookName:     aws.String("launching"),
+					LifecycleTransition:   aws.String("autoscaling:EC2_INSTANCE_LAUNCHING"),
+					NotificationTargetARN: aws.String("arn:aws:sns:us-east-1:123456789012:topic"),
+					RoleARN:               aws.String("arn:aws:iam::123456789012:role/hooks"),
+					HeartbeatTimeout:      aws.Int64(300),
+					DefaultResult:         aws.String("CONTINUE"),
+					NotificationMetadata:  aws.String("metadata"),
+				})).
+					Return(&autoscaling.PutLifecycleHookOutput{}, nil)
+			},
+		},
+		{
+			name: "should return error if put lifecycle hook failed",
+			hook: &expinfrav1.AWSLifecycleHook{
+				Name:                "terminating",
+				LifecycleTransition: expinfrav1.LifecycleTransitionInstanceTerminating,
+			},
+			wantErr: true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.PutLifecycleHook(gomock.Any()).
+					Return(nil, awserrors.NewFailedDependency("dependency failure"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			tt.expect(asgMock.EXPECT())
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			err = s.PutLifecycleHook("asgName", tt.hook)
+			checkErr(tt.wantErr, err, g)
+		})
+	}
+}
+
+func TestServiceCompleteLifecycleAction(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	input := &autoscaling.CompleteLifecycleActionInput{
+		AutoScalingGroupName:  aws.String("asgName"),
+		LifecycleHookName:     aws.String("terminating"),
+		InstanceId:            aws.String("i-1234567890"),
+		LifecycleActionResult: aws.String("CONTINUE"),
+	}
+	tests := []struct {
+		name    string
+		wantErr bool
+		expect  func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+	}{
+		{
+			name:    "should continue the lifecycle action",
+			wantErr: false,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.CompleteLifecycleAction(gomock.Eq(input)).
+					Return(&autoscaling.CompleteLifecycleActionOutput{}, nil)
+			},
+		},
+		{
+			name:    "should ignore instances not waiting on the lifecycle hook",
+			wantErr: false,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.CompleteLifecycleAction(gomock.Eq(input)).
+					Return(nil, awserr.New("ValidationError", "No active Lifecycle Action found with instance ID i-1234567890", nil))
+			},
+		},
+		{
+			name:    "should return error if complete lifecycle action failed",
+			wantErr: true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.CompleteLifecycleAction(gomock.Eq(input)).
+					Return(nil, awserrors.NewFailedDependency("dependency failure"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			tt.expect(asgMock.EXPECT())
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			err = s.CompleteLifecycleAction("asgName", "terminating", "i-1234567890")
+			checkErr(tt.wantErr, err, g)
+		})
+	}
+}
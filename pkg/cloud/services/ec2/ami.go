@@ -30,9 +30,15 @@ import (
 	"github.com/aws/aws-sdk-go/service/ssm"
 	"github.com/blang/semver"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
 const (
@@ -67,14 +73,67 @@ const (
 
 	// EKS GPU AMI ID SSM Parameter name.
 	eksGPUAmiSSMParameterFormat = "/aws/service/eks/optimized-ami/%s/amazon-linux-2-gpu/recommended/image_id"
+
+	// amiSSMParameterCacheTTL is how long an AMI ID resolved from an SSM parameter is cached.
+	amiSSMParameterCacheTTL = 5 * time.Minute
+
+	// amiStateCacheTTL is how long the deprecation time and state of an AMI are cached, which sets how often
+	// the AMIs in use are checked for deprecation.
+	amiStateCacheTTL = time.Hour
 )
 
+// amiSSMParameterCache caches the AMI IDs resolved from SSM parameters, so that the machines and launch templates
+// referencing the same parameter don't each get it on every reconciliation.
+var amiSSMParameterCache = cache.NewTTLStore(func(obj interface{}) (string, error) {
+	return obj.(*amiSSMParameterCacheEntry).key, nil
+}, amiSSMParameterCacheTTL)
+
+type amiSSMParameterCacheEntry struct {
+	key     string
+	imageID string
+}
+
 // AMILookup contains the parameters used to template AMI names used for lookup.
 type AMILookup struct {
 	BaseOS     string
 	K8sVersion string
 }
 
+// SSMParameterAMILookup contains the parameters used to template the names of SSM parameters holding AMI IDs.
+type SSMParameterAMILookup struct {
+	K8sVersion           string
+	K8sMajorMinorVersion string
+}
+
+// GenerateSSMParameterName will generate the name of an SSM parameter holding an AMI ID.
+func GenerateSSMParameterName(nameFormat string, kubernetesVersion *string) (string, error) {
+	if !strings.Contains(nameFormat, "{{") {
+		return nameFormat, nil
+	}
+	if kubernetesVersion == nil {
+		return "", errors.Errorf("a Kubernetes version is required to substitute SSM parameter name: %q", nameFormat)
+	}
+
+	parsed, err := semver.ParseTolerant(*kubernetesVersion)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to parse Kubernetes version %q", *kubernetesVersion)
+	}
+	parameters := SSMParameterAMILookup{
+		K8sVersion:           strings.TrimPrefix(*kubernetesVersion, "v"),
+		K8sMajorMinorVersion: fmt.Sprintf("%d.%d", parsed.Major, parsed.Minor),
+	}
+
+	var nameBytes bytes.Buffer
+	template, err := template.New("ssmParameter").Parse(nameFormat)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed create template from string: %q", nameFormat)
+	}
+	if err := template.Execute(&nameBytes, parameters); err != nil {
+		return "", errors.Wrapf(err, "failed to substitute string: %q", nameFormat)
+	}
+	return nameBytes.String(), nil
+}
+
 // GenerateAmiName will generate an AMI name.
 func GenerateAmiName(amiNameFormat, baseOS, kubernetesVersion string) (string, error) {
 	amiNameParameters := AMILookup{baseOS, strings.TrimPrefix(kubernetesVersion, "v")}
@@ -275,6 +334,181 @@ func (s *Service) eksAMILookup(kubernetesVersion string, amiType *infrav1.EKSAMI
 	return id, nil
 }
 
+// ssmParameterAMILookup returns the ID of the AMI held by the SSM parameter with the given templated name.
+func (s *Service) ssmParameterAMILookup(nameFormat string, kubernetesVersion *string) (string, error) {
+	paramName, err := GenerateSSMParameterName(nameFormat, kubernetesVersion)
+	if err != nil {
+		return "", err
+	}
+
+	key := s.amiCacheKey(paramName)
+	if obj, exists, _ := amiSSMParameterCache.GetByKey(key); exists {
+		return obj.(*amiSSMParameterCacheEntry).imageID, nil
+	}
+
+	out, err := s.SSMClient.GetParameter(&ssm.GetParameterInput{
+		Name: aws.String(paramName),
+	})
+	if err != nil {
+		record.Eventf(s.scope.InfraCluster(), "FailedGetParameter", "Failed to get ami SSM parameter %q: %v", paramName, err)
+
+		return "", errors.Wrapf(err, "failed to get ami SSM parameter: %q", paramName)
+	}
+
+	if out.Parameter == nil || out.Parameter.Value == nil {
+		return "", errors.Errorf("SSM parameter returned with nil value: %q", paramName)
+	}
+
+	id := aws.StringValue(out.Parameter.Value)
+	if err := amiSSMParameterCache.Add(&amiSSMParameterCacheEntry{key: key, imageID: id}); err != nil {
+		return "", errors.Wrapf(err, "failed to cache ami SSM parameter: %q", paramName)
+	}
+	s.scope.Info("found AMI", "id", id, "ssm-parameter", paramName)
+
+	return id, nil
+}
+
+// amiCacheKey returns the key under which the lookup of the given AMI or SSM parameter is cached. It includes
+// the identity and the region of the cluster, as parameters and AMIs with the same name differ between accounts
+// and regions.
+func (s *Service) amiCacheKey(name string) string {
+	identity := ""
+	if ref := s.scope.IdentityRef(); ref != nil {
+		identity = string(ref.Kind) + "/" + ref.Name
+	}
+
+	return strings.Join([]string{identity, s.scope.Region(), name}, ":")
+}
+
+// amiStateCache caches the AMIs described to check their deprecation, so that the machines, templates and
+// machine pools using the same AMI don't each describe it on every reconciliation.
+var amiStateCache = cache.NewTTLStore(func(obj interface{}) (string, error) {
+	return obj.(*amiStateCacheEntry).key, nil
+}, amiStateCacheTTL)
+
+type amiStateCacheEntry struct {
+	key string
+	// image is nil if the AMI could not be found.
+	image *ec2.Image
+}
+
+// ReconcileAMIDeprecation checks whether the AMI with the given ID is deprecated or no longer available, and
+// reports it on the given object with the AMIDeprecated condition and a warning event. If the AMI can't be
+// described, the condition is set to unknown and the error is returned.
+func (s *Service) ReconcileAMIDeprecation(obj conditions.Setter, imageID string) error {
+	image, err := s.describeAMIState(imageID)
+	if err != nil {
+		if existing := conditions.Get(obj, infrav1.AMIDeprecatedCondition); existing == nil || existing.Reason != infrav1.AMIDeprecationCheckFailedReason {
+			record.Warnf(obj, infrav1.AMIDeprecationCheckFailedReason, "Failed to check whether AMI %q is deprecated: %v", imageID, err)
+		}
+		conditions.MarkUnknown(obj, infrav1.AMIDeprecatedCondition, infrav1.AMIDeprecationCheckFailedReason, "%s", err.Error())
+		return err
+	}
+
+	var reason, message string
+	switch {
+	case image == nil:
+		reason = infrav1.AMIUnavailableReason
+		message = fmt.Sprintf("AMI %q can no longer be found", imageID)
+	case aws.StringValue(image.State) != ec2.ImageStateAvailable && aws.StringValue(image.State) != ec2.ImageStatePending:
+		reason = infrav1.AMIUnavailableReason
+		message = fmt.Sprintf("AMI %q is %s", imageID, aws.StringValue(image.State))
+	default:
+		deprecationTime, err := time.Parse(time.RFC3339, aws.StringValue(image.DeprecationTime))
+		if err != nil || deprecationTime.After(time.Now()) {
+			conditions.Delete(obj, infrav1.AMIDeprecatedCondition)
+			return nil
+		}
+		reason = infrav1.AMIDeprecatedReason
+		message = fmt.Sprintf("AMI %q was deprecated at %s", imageID, deprecationTime.Format(time.RFC3339))
+	}
+
+	if existing := conditions.Get(obj, infrav1.AMIDeprecatedCondition); existing == nil || existing.Reason != reason {
+		record.Warnf(obj, reason, message)
+	}
+	conditions.Set(obj, &clusterv1.Condition{
+		Type:     infrav1.AMIDeprecatedCondition,
+		Status:   corev1.ConditionTrue,
+		Severity: clusterv1.ConditionSeverityWarning,
+		Reason:   reason,
+		Message:  message,
+	})
+
+	return nil
+}
+
+// describeAMIState describes the AMI with the given ID, including if it is deprecated. It returns nil if the AMI
+// could not be found.
+func (s *Service) describeAMIState(imageID string) (*ec2.Image, error) {
+	key := s.amiCacheKey(imageID)
+	if obj, exists, _ := amiStateCache.GetByKey(key); exists {
+		return obj.(*amiStateCacheEntry).image, nil
+	}
+
+	out, err := s.EC2Client.DescribeImages(&ec2.DescribeImagesInput{
+		ImageIds:          aws.StringSlice([]string{imageID}),
+		IncludeDeprecated: aws.Bool(true),
+	})
+	if err != nil && !awserrors.IsNotFound(err) {
+		return nil, errors.Wrapf(err, "failed to describe AMI %q", imageID)
+	}
+
+	entry := &amiStateCacheEntry{key: key}
+	if out != nil && len(out.Images) > 0 {
+		entry.image = out.Images[0]
+	}
+	if err := amiStateCache.Add(entry); err != nil {
+		return nil, errors.Wrapf(err, "failed to cache AMI %q", imageID)
+	}
+
+	return entry.image, nil
+}
+
+// pinnedAMI returns the AMI resolved earlier for the given object, if it is still valid: the object's spec and
+// the Kubernetes version must not have changed since it was resolved, and no refresh must have been requested.
+func pinnedAMI(resolved *infrav1.ResolvedAMI, obj metav1.Object, kubernetesVersion *string) *infrav1.ResolvedAMI {
+	if resolved == nil || resolved.ID == "" {
+		return nil
+	}
+	if _, ok := obj.GetAnnotations()[infrav1.RefreshAMIAnnotation]; ok {
+		return nil
+	}
+	if resolved.ObservedGeneration != obj.GetGeneration() || resolved.KubernetesVersion != aws.StringValue(kubernetesVersion) {
+		return nil
+	}
+	return resolved
+}
+
+// pinAMI describes the resolved AMI so it can be recorded on the given object, and clears any refresh request
+// from the object's annotations.
+func (s *Service) pinAMI(imageID string, obj metav1.Object, kubernetesVersion *string) (*infrav1.ResolvedAMI, error) {
+	out, err := s.EC2Client.DescribeImages(&ec2.DescribeImagesInput{
+		ImageIds: aws.StringSlice([]string{imageID}),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe AMI %q", imageID)
+	}
+
+	resolved := &infrav1.ResolvedAMI{
+		ID:                 imageID,
+		KubernetesVersion:  aws.StringValue(kubernetesVersion),
+		ObservedGeneration: obj.GetGeneration(),
+	}
+	if len(out.Images) > 0 {
+		resolved.Name = aws.StringValue(out.Images[0].Name)
+		if creationDate, err := time.Parse(createDateTimestampFormat, aws.StringValue(out.Images[0].CreationDate)); err == nil {
+			resolved.CreationDate = &metav1.Time{Time: creationDate}
+		}
+	}
+
+	if annotations := obj.GetAnnotations(); annotations != nil {
+		delete(annotations, infrav1.RefreshAMIAnnotation)
+		obj.SetAnnotations(annotations)
+	}
+
+	return resolved, nil
+}
+
 func formatVersionForEKS(version string) (string, error) {
 	parsed, err := semver.ParseTolerant(version)
 	if err != nil {
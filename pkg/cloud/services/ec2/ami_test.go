@@ -17,19 +17,27 @@ limitations under the License.
 package ec2
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ssm"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ssm/mock_ssmiface"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
 func TestDefaultAMILookup(t *testing.T) {
@@ -474,3 +482,344 @@ func TestEKSAMILookUp(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateSSMParameterName(t *testing.T) {
+	tests := []struct {
+		name              string
+		nameFormat        string
+		kubernetesVersion *string
+		want              string
+		wantErr           bool
+	}{
+		{
+			name:       "Should return the name as is if it is not templated",
+			nameFormat: "/aws/service/canonical/ubuntu/server/22.04/stable/current/amd64/hvm/ebs-gp2/ami-id",
+			want:       "/aws/service/canonical/ubuntu/server/22.04/stable/current/amd64/hvm/ebs-gp2/ami-id",
+		},
+		{
+			name:              "Should substitute the Kubernetes version",
+			nameFormat:        "/golden-ami/k8s/{{.K8sVersion}}",
+			kubernetesVersion: aws.String("v1.27.3"),
+			want:              "/golden-ami/k8s/1.27.3",
+		},
+		{
+			name:              "Should substitute the Kubernetes major and minor version",
+			nameFormat:        "/golden-ami/k8s/{{.K8sMajorMinorVersion}}",
+			kubernetesVersion: aws.String("v1.27.3"),
+			want:              "/golden-ami/k8s/1.27",
+		},
+		{
+			name:       "Should return an error if the name is templated and no Kubernetes version is passed",
+			nameFormat: "/golden-ami/k8s/{{.K8sVersion}}",
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			got, err := GenerateSSMParameterName(tt.nameFormat, tt.kubernetesVersion)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).Should(Equal(tt.want))
+		})
+	}
+}
+
+func TestPinnedAMI(t *testing.T) {
+	resolved := &infrav1.ResolvedAMI{ID: "ami-1", KubernetesVersion: "v1.24.4", ObservedGeneration: 2}
+
+	tests := []struct {
+		name              string
+		resolved          *infrav1.ResolvedAMI
+		template          *infrav1.AWSMachineTemplate
+		kubernetesVersion *string
+		want              *infrav1.ResolvedAMI
+	}{
+		{
+			name:              "Should return the pinned AMI if the template is unchanged",
+			resolved:          resolved,
+			template:          &infrav1.AWSMachineTemplate{ObjectMeta: metav1.ObjectMeta{Generation: 2}},
+			kubernetesVersion: aws.String("v1.24.4"),
+			want:              resolved,
+		},
+		{
+			name:              "Should return nil if nothing was pinned yet",
+			template:          &infrav1.AWSMachineTemplate{ObjectMeta: metav1.ObjectMeta{Generation: 2}},
+			kubernetesVersion: aws.String("v1.24.4"),
+		},
+		{
+			name:              "Should return nil if the template changed",
+			resolved:          resolved,
+			template:          &infrav1.AWSMachineTemplate{ObjectMeta: metav1.ObjectMeta{Generation: 3}},
+			kubernetesVersion: aws.String("v1.24.4"),
+		},
+		{
+			name:              "Should return nil if the Kubernetes version changed",
+			resolved:          resolved,
+			template:          &infrav1.AWSMachineTemplate{ObjectMeta: metav1.ObjectMeta{Generation: 2}},
+			kubernetesVersion: aws.String("v1.25.0"),
+		},
+		{
+			name:     "Should return nil if a refresh is requested",
+			resolved: resolved,
+			template: &infrav1.AWSMachineTemplate{ObjectMeta: metav1.ObjectMeta{
+				Generation:  2,
+				Annotations: map[string]string{infrav1.RefreshAMIAnnotation: "true"},
+			}},
+			kubernetesVersion: aws.String("v1.24.4"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(pinnedAMI(tt.resolved, tt.template, tt.kubernetesVersion)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestPinTemplateAMI(t *testing.T) {
+	tests := []struct {
+		name       string
+		concurrent *infrav1.ResolvedAMI
+		want       string
+	}{
+		{
+			name: "Should pin the AMI on the template",
+			want: "ami-1",
+		},
+		{
+			name:       "Should use the AMI pinned concurrently on the template",
+			concurrent: &infrav1.ResolvedAMI{ID: "ami-2", KubernetesVersion: "v1.24.4", ObservedGeneration: 1},
+			want:       "ami-2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			template := &infrav1.AWSMachineTemplate{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", Generation: 1}}
+			client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(template).Build()
+
+			clusterScope, err := setupClusterScope(client)
+			g.Expect(err).NotTo(HaveOccurred())
+			machineScope, err := setupMachineScope(client, clusterScope)
+			g.Expect(err).NotTo(HaveOccurred())
+			machineScope.Machine.Spec.Version = aws.String("v1.24.4")
+			machineScope.AWSMachineTemplate = &infrav1.AWSMachineTemplate{}
+			g.Expect(client.Get(context.TODO(), crclient.ObjectKeyFromObject(template), machineScope.AWSMachineTemplate)).To(Succeed())
+
+			if tt.concurrent != nil {
+				// Another machine pins an AMI after this one read the template.
+				concurrent := machineScope.AWSMachineTemplate.DeepCopy()
+				concurrent.Status.ResolvedAMI = tt.concurrent
+				g.Expect(client.Update(context.TODO(), concurrent)).To(Succeed())
+			}
+
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			ec2Mock.EXPECT().DescribeImages(gomock.Any()).Return(&ec2.DescribeImagesOutput{}, nil)
+
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			got, err := s.pinTemplateAMI(machineScope, "ami-1")
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+
+			stored := &infrav1.AWSMachineTemplate{}
+			g.Expect(client.Get(context.TODO(), crclient.ObjectKeyFromObject(template), stored)).To(Succeed())
+			g.Expect(stored.Status.ResolvedAMI).NotTo(BeNil())
+			g.Expect(stored.Status.ResolvedAMI.ID).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestSSMParameterAMILookup(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	scheme, err := setupScheme()
+	g.Expect(err).NotTo(HaveOccurred())
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	clusterScope, err := setupClusterScope(client)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	ssmMock := mock_ssmiface.NewMockSSMAPI(mockCtrl)
+	ssmMock.EXPECT().GetParameter(gomock.Eq(&ssm.GetParameterInput{
+		Name: aws.String("/test-ssm-parameter-ami-lookup/k8s/1.24"),
+	})).Return(&ssm.GetParameterOutput{
+		Parameter: &ssm.Parameter{
+			Value: aws.String("ami-1"),
+		},
+	}, nil).Times(1)
+
+	s := NewService(clusterScope)
+	s.SSMClient = ssmMock
+
+	// The second lookup is served from the cache.
+	for i := 0; i < 2; i++ {
+		got, err := s.ssmParameterAMILookup("/test-ssm-parameter-ami-lookup/k8s/{{.K8sMajorMinorVersion}}", aws.String("v1.24.4"))
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(got).To(Equal("ami-1"))
+	}
+
+	// Clusters using another identity don't share the cached AMI.
+	ssmMock.EXPECT().GetParameter(gomock.Eq(&ssm.GetParameterInput{
+		Name: aws.String("/test-ssm-parameter-ami-lookup/k8s/1.24"),
+	})).Return(&ssm.GetParameterOutput{
+		Parameter: &ssm.Parameter{
+			Value: aws.String("ami-2"),
+		},
+	}, nil).Times(1)
+
+	clusterScope.AWSCluster.Spec.IdentityRef = &infrav1.AWSIdentityReference{
+		Kind: infrav1.ClusterRoleIdentityKind,
+		Name: "other-account",
+	}
+	got, err := s.ssmParameterAMILookup("/test-ssm-parameter-ami-lookup/k8s/{{.K8sMajorMinorVersion}}", aws.String("v1.24.4"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got).To(Equal("ami-2"))
+}
+
+func TestReconcileAMIDeprecation(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name       string
+		imageID    string
+		existing   *clusterv1.Condition
+		images     []*ec2.Image
+		err        error
+		wantReason string
+	}{
+		{
+			name:    "Should not set the condition if the AMI is available",
+			imageID: "ami-available",
+			images:  []*ec2.Image{{State: aws.String(ec2.ImageStateAvailable)}},
+		},
+		{
+			name:    "Should not set the condition if the AMI is deprecated in the future",
+			imageID: "ami-deprecated-later",
+			images: []*ec2.Image{{
+				State:           aws.String(ec2.ImageStateAvailable),
+				DeprecationTime: aws.String(time.Now().Add(24 * time.Hour).UTC().Format(createDateTimestampFormat)),
+			}},
+		},
+		{
+			name:     "Should remove the condition if the AMI is available again",
+			imageID:  "ami-restored",
+			existing: &clusterv1.Condition{Type: infrav1.AMIDeprecatedCondition, Status: corev1.ConditionTrue, Reason: infrav1.AMIDeprecatedReason},
+			images:   []*ec2.Image{{State: aws.String(ec2.ImageStateAvailable)}},
+		},
+		{
+			name:    "Should set the condition if the AMI is deprecated",
+			imageID: "ami-deprecated",
+			images: []*ec2.Image{{
+				State:           aws.String(ec2.ImageStateAvailable),
+				DeprecationTime: aws.String("2022-01-01T00:00:00.000Z"),
+			}},
+			wantReason: infrav1.AMIDeprecatedReason,
+		},
+		{
+			name:       "Should set the condition if the AMI is deregistered",
+			imageID:    "ami-deregistered",
+			images:     []*ec2.Image{{State: aws.String(ec2.ImageStateDeregistered)}},
+			wantReason: infrav1.AMIUnavailableReason,
+		},
+		{
+			name:       "Should set the condition if the AMI can no longer be found",
+			imageID:    "ami-not-found",
+			err:        awserr.New(awserrors.AMINotFound, "not found", nil),
+			wantReason: infrav1.AMIUnavailableReason,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+			clusterScope, err := setupClusterScope(client)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			ec2Mock.EXPECT().DescribeImages(gomock.Eq(&ec2.DescribeImagesInput{
+				ImageIds:          aws.StringSlice([]string{tt.imageID}),
+				IncludeDeprecated: aws.Bool(true),
+			})).Return(&ec2.DescribeImagesOutput{Images: tt.images}, tt.err).Times(1)
+
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			awsMachine := &infrav1.AWSMachine{}
+			if tt.existing != nil {
+				conditions.Set(awsMachine, tt.existing)
+			}
+
+			// The second check is served from the cache.
+			for i := 0; i < 2; i++ {
+				g.Expect(s.ReconcileAMIDeprecation(awsMachine, tt.imageID)).To(Succeed())
+			}
+
+			condition := conditions.Get(awsMachine, infrav1.AMIDeprecatedCondition)
+			if tt.wantReason == "" {
+				g.Expect(condition).To(BeNil())
+				return
+			}
+			g.Expect(condition.Status).To(Equal(corev1.ConditionTrue))
+			g.Expect(condition.Severity).To(Equal(clusterv1.ConditionSeverityWarning))
+			g.Expect(condition.Reason).To(Equal(tt.wantReason))
+		})
+	}
+}
+
+func TestReconcileAMIDeprecationFailure(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	g := NewWithT(t)
+
+	scheme, err := setupScheme()
+	g.Expect(err).NotTo(HaveOccurred())
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	clusterScope, err := setupClusterScope(client)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	input := &ec2.DescribeImagesInput{
+		ImageIds:          aws.StringSlice([]string{"ami-throttled"}),
+		IncludeDeprecated: aws.Bool(true),
+	}
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+	gomock.InOrder(
+		ec2Mock.EXPECT().DescribeImages(gomock.Eq(input)).Return(nil, awserr.New("Throttling", "rate exceeded", nil)),
+		ec2Mock.EXPECT().DescribeImages(gomock.Eq(input)).Return(&ec2.DescribeImagesOutput{
+			Images: []*ec2.Image{{State: aws.String(ec2.ImageStateAvailable)}},
+		}, nil),
+	)
+
+	s := NewService(clusterScope)
+	s.EC2Client = ec2Mock
+
+	awsMachine := &infrav1.AWSMachine{}
+
+	// A failed check is reported as unknown and isn't cached.
+	g.Expect(s.ReconcileAMIDeprecation(awsMachine, "ami-throttled")).NotTo(Succeed())
+	condition := conditions.Get(awsMachine, infrav1.AMIDeprecatedCondition)
+	g.Expect(condition).NotTo(BeNil())
+	g.Expect(condition.Status).To(Equal(corev1.ConditionUnknown))
+	g.Expect(condition.Reason).To(Equal(infrav1.AMIDeprecationCheckFailedReason))
+
+	g.Expect(s.ReconcileAMIDeprecation(awsMachine, "ami-throttled")).To(Succeed())
+	g.Expect(conditions.Get(awsMachine, infrav1.AMIDeprecatedCondition)).To(BeNil())
+}
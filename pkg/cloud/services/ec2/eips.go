@@ -0,0 +1,226 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/wait"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/tags"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+// ReconcileElasticIP makes sure the Elastic IP requested by the AWSMachine is associated with the instance.
+// The allocation ID is recorded in the AWSMachine status once the address is associated, so that it can be
+// released or returned to the pool on deletion.
+func (s *Service) ReconcileElasticIP(scope *scope.MachineScope, instance *infrav1.Instance) error {
+	if scope.AWSMachine.Spec.ElasticIP == nil {
+		return nil
+	}
+
+	out, err := s.EC2Client.DescribeAddresses(&ec2.DescribeAddressesInput{
+		Filters: []*ec2.Filter{{Name: aws.String("instance-id"), Values: aws.StringSlice([]string{instance.ID})}},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to describe Elastic IPs of instance %q", instance.ID)
+	}
+	for _, address := range out.Addresses {
+		if address.AllocationId != nil {
+			scope.AWSMachine.Status.ElasticIPAllocationID = address.AllocationId
+			return nil
+		}
+	}
+
+	// The address associated earlier was disassociated from the instance, associate it again unless
+	// another instance took it in the meantime.
+	if allocationID := aws.StringValue(scope.AWSMachine.Status.ElasticIPAllocationID); allocationID != "" {
+		err := s.associateAddress(scope, allocationID, instance)
+		if err == nil || !isAlreadyAssociated(err) {
+			return err
+		}
+		scope.AWSMachine.Status.ElasticIPAllocationID = nil
+	}
+
+	if scope.AWSMachine.Spec.ElasticIP.Pool != nil {
+		return s.associatePoolAddress(scope, instance)
+	}
+
+	allocationID, err := s.allocateMachineAddress(scope)
+	if err != nil {
+		return err
+	}
+	if err := s.associateAddress(scope, allocationID, instance); err != nil {
+		// The address isn't recorded before it's associated, release it so that it doesn't leak.
+		if _, releaseErr := s.EC2Client.ReleaseAddress(&ec2.ReleaseAddressInput{AllocationId: aws.String(allocationID)}); releaseErr != nil {
+			s.scope.Error(releaseErr, "failed to release unassociated Elastic IP", "allocation-id", allocationID)
+		}
+		return err
+	}
+	scope.AWSMachine.Status.ElasticIPAllocationID = aws.String(allocationID)
+
+	return nil
+}
+
+// ReleaseElasticIP releases the Elastic IP allocated for the AWSMachine, or returns it to the pool it was taken from.
+// An address that was associated with another instance in the meantime is left untouched.
+func (s *Service) ReleaseElasticIP(scope *scope.MachineScope) error {
+	allocationID := scope.AWSMachine.Status.ElasticIPAllocationID
+	if allocationID == nil {
+		return nil
+	}
+
+	out, err := s.EC2Client.DescribeAddresses(&ec2.DescribeAddressesInput{
+		AllocationIds: []*string{allocationID},
+	})
+	if err != nil {
+		if code, _ := awserrors.Code(errors.Cause(err)); code == awserrors.AllocationIDNotFound {
+			scope.AWSMachine.Status.ElasticIPAllocationID = nil
+			return nil
+		}
+		return errors.Wrapf(err, "failed to describe Elastic IP %q", *allocationID)
+	}
+
+	instanceID := aws.StringValue(scope.GetInstanceID())
+	for _, address := range out.Addresses {
+		if address.AssociationId == nil {
+			continue
+		}
+		if instanceID == "" || aws.StringValue(address.InstanceId) != instanceID {
+			s.scope.Info("Elastic IP is associated with another instance, leaving it", "allocation-id", *allocationID, "instance-id", aws.StringValue(address.InstanceId))
+			scope.AWSMachine.Status.ElasticIPAllocationID = nil
+			return nil
+		}
+		if _, err := s.EC2Client.DisassociateAddress(&ec2.DisassociateAddressInput{
+			AssociationId: address.AssociationId,
+		}); err != nil {
+			if code, _ := awserrors.Code(errors.Cause(err)); code != awserrors.AssociationIDNotFound {
+				record.Warnf(scope.AWSMachine, "FailedDisassociateEIP", "Failed to disassociate Elastic IP %q: %v", *allocationID, err)
+				return errors.Wrapf(err, "failed to disassociate Elastic IP %q", *allocationID)
+			}
+		}
+	}
+
+	if scope.AWSMachine.Spec.ElasticIP == nil || scope.AWSMachine.Spec.ElasticIP.Pool == nil {
+		if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
+			if _, err := s.EC2Client.ReleaseAddress(&ec2.ReleaseAddressInput{AllocationId: allocationID}); err != nil {
+				return false, err
+			}
+			return true, nil
+		}, awserrors.AuthFailure, awserrors.InUseIPAddress); err != nil {
+			record.Warnf(scope.AWSMachine, "FailedReleaseEIP", "Failed to release Elastic IP %q: %v", *allocationID, err)
+			return errors.Wrapf(err, "failed to release Elastic IP %q", *allocationID)
+		}
+		s.scope.Info("Released Elastic IP", "allocation-id", *allocationID)
+	}
+
+	scope.AWSMachine.Status.ElasticIPAllocationID = nil
+	return nil
+}
+
+// associateAddress associates the Elastic IP with the given allocation ID with the instance. It fails if the
+// address is already associated with another instance.
+func (s *Service) associateAddress(scope *scope.MachineScope, allocationID string, instance *infrav1.Instance) error {
+	if _, err := s.EC2Client.AssociateAddress(&ec2.AssociateAddressInput{
+		AllocationId:       aws.String(allocationID),
+		InstanceId:         aws.String(instance.ID),
+		AllowReassociation: aws.Bool(false),
+	}); err != nil {
+		record.Warnf(scope.AWSMachine, "FailedAssociateEIP", "Failed to associate Elastic IP %q with instance %q: %v", allocationID, instance.ID, err)
+		return errors.Wrapf(err, "failed to associate Elastic IP %q with instance %q", allocationID, instance.ID)
+	}
+
+	record.Eventf(scope.AWSMachine, "SuccessfulAssociateEIP", "Associated Elastic IP %q with instance %q", allocationID, instance.ID)
+	return nil
+}
+
+// associatePoolAddress associates an unassociated Elastic IP of the pool with the instance. Addresses taken by
+// another instance since the pool was described are skipped.
+func (s *Service) associatePoolAddress(scope *scope.MachineScope, instance *infrav1.Instance) error {
+	allocationIDs, err := s.getPoolAddresses(scope)
+	if err != nil {
+		return err
+	}
+
+	for _, allocationID := range allocationIDs {
+		err := s.associateAddress(scope, allocationID, instance)
+		if err == nil {
+			scope.AWSMachine.Status.ElasticIPAllocationID = aws.String(allocationID)
+			return nil
+		}
+		if !isAlreadyAssociated(err) {
+			return err
+		}
+	}
+
+	record.Warnf(scope.AWSMachine, "FailedAllocateEIP", "No unassociated Elastic IP available in pool")
+	return awserrors.NewFailedDependency("no unassociated Elastic IP available in pool")
+}
+
+func isAlreadyAssociated(err error) bool {
+	code, _ := awserrors.Code(errors.Cause(err))
+	return code == awserrors.ResourceAlreadyAssociated
+}
+
+// getPoolAddresses returns the allocation IDs of the unassociated Elastic IPs of the pool.
+func (s *Service) getPoolAddresses(scope *scope.MachineScope) ([]string, error) {
+	filters := make([]*ec2.Filter, 0, len(scope.AWSMachine.Spec.ElasticIP.Pool.Filters))
+	for _, f := range scope.AWSMachine.Spec.ElasticIP.Pool.Filters {
+		filters = append(filters, &ec2.Filter{Name: aws.String(f.Name), Values: aws.StringSlice(f.Values)})
+	}
+
+	out, err := s.EC2Client.DescribeAddresses(&ec2.DescribeAddressesInput{Filters: filters})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to describe Elastic IP pool")
+	}
+
+	allocationIDs := []string{}
+	for _, address := range out.Addresses {
+		if address.AssociationId == nil && address.AllocationId != nil {
+			allocationIDs = append(allocationIDs, aws.StringValue(address.AllocationId))
+		}
+	}
+
+	return allocationIDs, nil
+}
+
+func (s *Service) allocateMachineAddress(scope *scope.MachineScope) (string, error) {
+	tagSpecification := tags.BuildParamsToTagSpecification(ec2.ResourceTypeElasticIp, infrav1.BuildParams{
+		ClusterName: s.scope.KubernetesClusterName(),
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Name:        aws.String(fmt.Sprintf("%s-eip", scope.Name())),
+		Role:        aws.String(scope.Role()),
+		Additional:  scope.AdditionalTags(),
+	})
+
+	out, err := s.EC2Client.AllocateAddress(&ec2.AllocateAddressInput{
+		Domain:            aws.String("vpc"),
+		TagSpecifications: []*ec2.TagSpecification{tagSpecification},
+	})
+	if err != nil {
+		record.Warnf(scope.AWSMachine, "FailedAllocateEIP", "Failed to allocate Elastic IP: %v", err)
+		return "", errors.Wrap(err, "failed to allocate Elastic IP")
+	}
+
+	return aws.StringValue(out.AllocationId), nil
+}
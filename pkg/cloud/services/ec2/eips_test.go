@@ -0,0 +1,298 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestReconcileElasticIP(t *testing.T) {
+	instance := &infrav1.Instance{ID: "i-1", State: infrav1.InstanceStateRunning}
+	describeByInstance := &ec2.DescribeAddressesInput{
+		Filters: []*ec2.Filter{{Name: aws.String("instance-id"), Values: aws.StringSlice([]string{"i-1"})}},
+	}
+	poolFilters := []infrav1.Filter{{Name: "tag:eip-pool", Values: []string{"ingress"}}}
+	alreadyAssociated := awserr.New(awserrors.ResourceAlreadyAssociated, "already associated", nil)
+
+	tests := []struct {
+		name             string
+		elasticIP        *infrav1.ElasticIPSpec
+		allocationID     *string
+		expect           func(m *mocks.MockEC2APIMockRecorder)
+		wantAllocationID *string
+		wantErr          bool
+	}{
+		{
+			name:   "does nothing when no Elastic IP is requested",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {},
+		},
+		{
+			name:      "records the Elastic IP already associated with the instance",
+			elasticIP: &infrav1.ElasticIPSpec{},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeAddresses(gomock.Eq(describeByInstance)).
+					Return(&ec2.DescribeAddressesOutput{Addresses: []*ec2.Address{{AllocationId: aws.String("eipalloc-1"), AssociationId: aws.String("eipassoc-1")}}}, nil)
+			},
+			wantAllocationID: aws.String("eipalloc-1"),
+		},
+		{
+			name:      "allocates and associates an Elastic IP for the machine",
+			elasticIP: &infrav1.ElasticIPSpec{},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeAddresses(gomock.Eq(describeByInstance)).
+					Return(&ec2.DescribeAddressesOutput{}, nil)
+				m.AllocateAddress(gomock.AssignableToTypeOf(&ec2.AllocateAddressInput{})).
+					Return(&ec2.AllocateAddressOutput{AllocationId: aws.String("eipalloc-1")}, nil)
+				m.AssociateAddress(gomock.Eq(&ec2.AssociateAddressInput{
+					AllocationId:       aws.String("eipalloc-1"),
+					InstanceId:         aws.String("i-1"),
+					AllowReassociation: aws.Bool(false),
+				})).Return(&ec2.AssociateAddressOutput{}, nil)
+			},
+			wantAllocationID: aws.String("eipalloc-1"),
+		},
+		{
+			name:         "associates again an Elastic IP disassociated from the instance",
+			elasticIP:    &infrav1.ElasticIPSpec{},
+			allocationID: aws.String("eipalloc-1"),
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeAddresses(gomock.Eq(describeByInstance)).
+					Return(&ec2.DescribeAddressesOutput{}, nil)
+				m.AssociateAddress(gomock.Eq(&ec2.AssociateAddressInput{
+					AllocationId:       aws.String("eipalloc-1"),
+					InstanceId:         aws.String("i-1"),
+					AllowReassociation: aws.Bool(false),
+				})).Return(&ec2.AssociateAddressOutput{}, nil)
+			},
+			wantAllocationID: aws.String("eipalloc-1"),
+		},
+		{
+			name:         "allocates another Elastic IP when the previous one was taken by another instance",
+			elasticIP:    &infrav1.ElasticIPSpec{},
+			allocationID: aws.String("eipalloc-1"),
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeAddresses(gomock.Eq(describeByInstance)).
+					Return(&ec2.DescribeAddressesOutput{}, nil)
+				m.AssociateAddress(gomock.Eq(&ec2.AssociateAddressInput{
+					AllocationId:       aws.String("eipalloc-1"),
+					InstanceId:         aws.String("i-1"),
+					AllowReassociation: aws.Bool(false),
+				})).Return(nil, alreadyAssociated)
+				m.AllocateAddress(gomock.AssignableToTypeOf(&ec2.AllocateAddressInput{})).
+					Return(&ec2.AllocateAddressOutput{AllocationId: aws.String("eipalloc-2")}, nil)
+				m.AssociateAddress(gomock.Eq(&ec2.AssociateAddressInput{
+					AllocationId:       aws.String("eipalloc-2"),
+					InstanceId:         aws.String("i-1"),
+					AllowReassociation: aws.Bool(false),
+				})).Return(&ec2.AssociateAddressOutput{}, nil)
+			},
+			wantAllocationID: aws.String("eipalloc-2"),
+		},
+		{
+			name:      "releases an allocated Elastic IP that could not be associated",
+			elasticIP: &infrav1.ElasticIPSpec{},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeAddresses(gomock.Eq(describeByInstance)).
+					Return(&ec2.DescribeAddressesOutput{}, nil)
+				m.AllocateAddress(gomock.AssignableToTypeOf(&ec2.AllocateAddressInput{})).
+					Return(&ec2.AllocateAddressOutput{AllocationId: aws.String("eipalloc-1")}, nil)
+				m.AssociateAddress(gomock.AssignableToTypeOf(&ec2.AssociateAddressInput{})).
+					Return(nil, awserr.New("InvalidInstanceID", "not running", nil))
+				m.ReleaseAddress(gomock.Eq(&ec2.ReleaseAddressInput{AllocationId: aws.String("eipalloc-1")})).
+					Return(&ec2.ReleaseAddressOutput{}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:      "associates an unassociated Elastic IP from the pool",
+			elasticIP: &infrav1.ElasticIPSpec{Pool: &infrav1.ElasticIPPool{Filters: poolFilters}},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeAddresses(gomock.Eq(describeByInstance)).
+					Return(&ec2.DescribeAddressesOutput{}, nil)
+				m.DescribeAddresses(gomock.Eq(&ec2.DescribeAddressesInput{
+					Filters: []*ec2.Filter{{Name: aws.String("tag:eip-pool"), Values: aws.StringSlice([]string{"ingress"})}},
+				})).Return(&ec2.DescribeAddressesOutput{Addresses: []*ec2.Address{
+					{AllocationId: aws.String("eipalloc-1"), AssociationId: aws.String("eipassoc-1")},
+					{AllocationId: aws.String("eipalloc-2")},
+				}}, nil)
+				m.AssociateAddress(gomock.Eq(&ec2.AssociateAddressInput{
+					AllocationId:       aws.String("eipalloc-2"),
+					InstanceId:         aws.String("i-1"),
+					AllowReassociation: aws.Bool(false),
+				})).Return(&ec2.AssociateAddressOutput{}, nil)
+			},
+			wantAllocationID: aws.String("eipalloc-2"),
+		},
+		{
+			name:      "skips Elastic IPs of the pool taken by another instance",
+			elasticIP: &infrav1.ElasticIPSpec{Pool: &infrav1.ElasticIPPool{Filters: poolFilters}},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeAddresses(gomock.Eq(describeByInstance)).
+					Return(&ec2.DescribeAddressesOutput{}, nil)
+				m.DescribeAddresses(gomock.Any()).
+					Return(&ec2.DescribeAddressesOutput{Addresses: []*ec2.Address{
+						{AllocationId: aws.String("eipalloc-1")},
+						{AllocationId: aws.String("eipalloc-2")},
+					}}, nil)
+				m.AssociateAddress(gomock.Eq(&ec2.AssociateAddressInput{
+					AllocationId:       aws.String("eipalloc-1"),
+					InstanceId:         aws.String("i-1"),
+					AllowReassociation: aws.Bool(false),
+				})).Return(nil, alreadyAssociated)
+				m.AssociateAddress(gomock.Eq(&ec2.AssociateAddressInput{
+					AllocationId:       aws.String("eipalloc-2"),
+					InstanceId:         aws.String("i-1"),
+					AllowReassociation: aws.Bool(false),
+				})).Return(&ec2.AssociateAddressOutput{}, nil)
+			},
+			wantAllocationID: aws.String("eipalloc-2"),
+		},
+		{
+			name:      "fails when the pool has no unassociated Elastic IP",
+			elasticIP: &infrav1.ElasticIPSpec{Pool: &infrav1.ElasticIPPool{Filters: poolFilters}},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeAddresses(gomock.Eq(describeByInstance)).
+					Return(&ec2.DescribeAddressesOutput{}, nil)
+				m.DescribeAddresses(gomock.Any()).
+					Return(&ec2.DescribeAddressesOutput{Addresses: []*ec2.Address{
+						{AllocationId: aws.String("eipalloc-1"), AssociationId: aws.String("eipassoc-1")},
+					}}, nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			machineScope, err := newElasticIPTestMachineScope()
+			g.Expect(err).NotTo(HaveOccurred())
+			machineScope.AWSMachine.Spec.ElasticIP = tc.elasticIP
+			machineScope.AWSMachine.Status.ElasticIPAllocationID = tc.allocationID
+
+			tc.expect(ec2Mock.EXPECT())
+			s := NewService(machineScope.InfraCluster)
+			s.EC2Client = ec2Mock
+
+			err = s.ReconcileElasticIP(machineScope, instance)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(machineScope.AWSMachine.Status.ElasticIPAllocationID).To(Equal(tc.wantAllocationID))
+		})
+	}
+}
+
+func TestReleaseElasticIP(t *testing.T) {
+	describeByAllocation := &ec2.DescribeAddressesInput{AllocationIds: aws.StringSlice([]string{"eipalloc-1"})}
+
+	tests := []struct {
+		name      string
+		elasticIP *infrav1.ElasticIPSpec
+		expect    func(m *mocks.MockEC2APIMockRecorder)
+	}{
+		{
+			name:      "releases an Elastic IP allocated for the machine",
+			elasticIP: &infrav1.ElasticIPSpec{},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeAddresses(gomock.Eq(describeByAllocation)).
+					Return(&ec2.DescribeAddressesOutput{Addresses: []*ec2.Address{{AllocationId: aws.String("eipalloc-1")}}}, nil)
+				m.ReleaseAddress(gomock.Eq(&ec2.ReleaseAddressInput{AllocationId: aws.String("eipalloc-1")})).
+					Return(&ec2.ReleaseAddressOutput{}, nil)
+			},
+		},
+		{
+			name:      "returns an Elastic IP to its pool",
+			elasticIP: &infrav1.ElasticIPSpec{Pool: &infrav1.ElasticIPPool{Filters: []infrav1.Filter{{Name: "tag:eip-pool", Values: []string{"ingress"}}}}},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeAddresses(gomock.Eq(describeByAllocation)).
+					Return(&ec2.DescribeAddressesOutput{Addresses: []*ec2.Address{{AllocationId: aws.String("eipalloc-1"), AssociationId: aws.String("eipassoc-1"), InstanceId: aws.String("i-1")}}}, nil)
+				m.DisassociateAddress(gomock.Eq(&ec2.DisassociateAddressInput{AssociationId: aws.String("eipassoc-1")})).
+					Return(&ec2.DisassociateAddressOutput{}, nil)
+			},
+		},
+		{
+			name:      "leaves an Elastic IP of the pool associated with another instance",
+			elasticIP: &infrav1.ElasticIPSpec{Pool: &infrav1.ElasticIPPool{Filters: []infrav1.Filter{{Name: "tag:eip-pool", Values: []string{"ingress"}}}}},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeAddresses(gomock.Eq(describeByAllocation)).
+					Return(&ec2.DescribeAddressesOutput{Addresses: []*ec2.Address{{AllocationId: aws.String("eipalloc-1"), AssociationId: aws.String("eipassoc-2"), InstanceId: aws.String("i-2")}}}, nil)
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			machineScope, err := newElasticIPTestMachineScope()
+			g.Expect(err).NotTo(HaveOccurred())
+			machineScope.AWSMachine.Spec.ElasticIP = tc.elasticIP
+			machineScope.AWSMachine.Spec.ProviderID = aws.String("aws:///us-east-1a/i-1")
+			machineScope.AWSMachine.Status.ElasticIPAllocationID = aws.String("eipalloc-1")
+
+			tc.expect(ec2Mock.EXPECT())
+			s := NewService(machineScope.InfraCluster)
+			s.EC2Client = ec2Mock
+
+			g.Expect(s.ReleaseElasticIP(machineScope)).To(Succeed())
+			g.Expect(machineScope.AWSMachine.Status.ElasticIPAllocationID).To(BeNil())
+		})
+	}
+}
+
+func newElasticIPTestMachineScope() (*scope.MachineScope, error) {
+	scheme, err := setupScheme()
+	if err != nil {
+		return nil, err
+	}
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	clusterScope, err := setupClusterScope(client)
+	if err != nil {
+		return nil, err
+	}
+
+	return scope.NewMachineScope(scope.MachineScopeParams{
+		Client:       client,
+		Cluster:      newCluster(),
+		Machine:      &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}},
+		AWSMachine:   &infrav1.AWSMachine{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}},
+		InfraCluster: clusterScope,
+	})
+}
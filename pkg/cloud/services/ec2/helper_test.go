@@ -61,6 +61,16 @@ func setupMachinePoolScope(cl client.Client, ec2Scope scope.EC2Scope) (*scope.Ma
 	})
 }
 
+func setupMachineScope(cl client.Client, ec2Scope scope.EC2Scope) (*scope.MachineScope, error) {
+	return scope.NewMachineScope(scope.MachineScopeParams{
+		Client:       cl,
+		InfraCluster: ec2Scope,
+		Cluster:      newCluster(),
+		Machine:      &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}},
+		AWSMachine:   &infrav1.AWSMachine{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}},
+	})
+}
+
 func defaultEC2Tags(name, clusterName string) []*ec2.Tag {
 	return []*ec2.Tag{
 		{
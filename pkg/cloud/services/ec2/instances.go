@@ -28,6 +28,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/utils/pointer"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
@@ -42,6 +43,13 @@ import (
 	capierrors "sigs.k8s.io/cluster-api/errors"
 )
 
+const (
+	// launchTemplateIDTag is the tag EC2 sets on instances to the ID of the launch template they were launched from.
+	launchTemplateIDTag = "aws:ec2launchtemplate:id"
+	// launchTemplateVersionTag is the tag EC2 sets on instances to the version of the launch template they were launched from.
+	launchTemplateVersionTag = "aws:ec2launchtemplate:version"
+)
+
 // GetRunningInstanceByTags returns the existing instance or nothing if it doesn't exist.
 func (s *Service) GetRunningInstanceByTags(scope *scope.MachineScope) (*infrav1.Instance, error) {
 	s.scope.Debug("Looking for existing machine instance by tags")
@@ -119,6 +127,8 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 		RootVolume:        scope.AWSMachine.Spec.RootVolume.DeepCopy(),
 		NonRootVolumes:    scope.AWSMachine.Spec.NonRootVolumes,
 		NetworkInterfaces: scope.AWSMachine.Spec.NetworkInterfaces,
+
+		AdditionalNetworkInterfaces: scope.AWSMachine.Spec.AdditionalNetworkInterfaces,
 	}
 
 	// Make sure to use the MachineScope here to get the merger of AWSCluster and AWSMachine tags
@@ -132,9 +142,27 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 	}.WithCloudProvider(s.scope.KubernetesClusterName()).WithMachineName(scope.Machine))
 
 	var err error
+	var pinned *infrav1.ResolvedAMI
+	if scope.AWSMachineTemplate != nil {
+		pinned = pinnedAMI(scope.AWSMachineTemplate.Status.ResolvedAMI, scope.AWSMachineTemplate, scope.Machine.Spec.Version)
+	}
+	useLaunchTemplateAMI := usesLaunchTemplateAMI(scope.AWSMachine)
+
 	// Pick image from the machine configuration, or use a default one.
 	if scope.AWSMachine.Spec.AMI.ID != nil { //nolint:nestif
 		input.ImageID = *scope.AWSMachine.Spec.AMI.ID
+	} else if useLaunchTemplateAMI {
+		input.ImageID, err = s.getLaunchTemplateImageID(scope.AWSMachine.Spec.LaunchTemplate)
+		if err != nil {
+			return nil, err
+		}
+	} else if pinned != nil {
+		input.ImageID = pinned.ID
+	} else if scope.AWSMachine.Spec.AMI.SSMParameter != nil {
+		input.ImageID, err = s.ssmParameterAMILookup(*scope.AWSMachine.Spec.AMI.SSMParameter, scope.Machine.Spec.Version)
+		if err != nil {
+			return nil, err
+		}
 	} else {
 		if scope.Machine.Spec.Version == nil {
 			err := errors.New("Either AWSMachine's spec.ami.id or Machine's spec.version must be defined")
@@ -171,11 +199,26 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 		}
 	}
 
-	subnetID, err := s.findSubnet(scope)
+	// Pin the looked up AMI on the template, so that the machines created from it keep booting the same image.
+	if scope.AWSMachine.Spec.AMI.ID == nil && !useLaunchTemplateAMI && pinned == nil && scope.AWSMachineTemplate != nil {
+		input.ImageID, err = s.pinTemplateAMI(scope, input.ImageID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The network configuration of a launch template is kept unless the machine selects a subnet itself.
+	launchTemplateNetworking, err := s.usesLaunchTemplateNetworking(scope)
 	if err != nil {
 		return nil, err
 	}
-	input.SubnetID = subnetID
+	if !launchTemplateNetworking {
+		subnetID, err := s.findSubnet(scope)
+		if err != nil {
+			return nil, err
+		}
+		input.SubnetID = subnetID
+	}
 
 	if !scope.IsExternallyManaged() && !scope.IsEKSManaged() && s.scope.Network().APIServerELB.DNSName == "" {
 		record.Eventf(s.scope.InfraCluster(), "FailedCreateInstance", "Failed to run controlplane, APIServer ELB not available")
@@ -192,15 +235,19 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 
 	input.UserData = pointer.StringPtr(base64.StdEncoding.EncodeToString(userData))
 
-	// Set security groups.
-	ids, err := s.GetCoreSecurityGroups(scope)
-	if err != nil {
-		return nil, err
+	// Set security groups. When the network configuration is left to the launch template, the core security
+	// groups are attached once the instance is running.
+	if !launchTemplateNetworking {
+		ids, err := s.GetCoreSecurityGroups(scope)
+		if err != nil {
+			return nil, err
+		}
+		input.SecurityGroupIDs = append(input.SecurityGroupIDs, ids...)
 	}
-	input.SecurityGroupIDs = append(input.SecurityGroupIDs, ids...)
 
 	// If SSHKeyName WAS NOT provided in the AWSMachine Spec, fallback to the value provided in the AWSCluster Spec.
-	// If a value was not provided in the AWSCluster Spec, then use the defaultSSHKeyName
+	// If a value was not provided in the AWSCluster Spec, then use the defaultSSHKeyName, unless the instance is
+	// launched from a launch template, which is left to pick its own key.
 	// Note that:
 	// - a nil AWSMachine.Spec.SSHKeyName value means use the AWSCluster.Spec.SSHKeyName SSH key name value
 	// - nil values for both AWSCluster.Spec.SSHKeyName and AWSMachine.Spec.SSHKeyName means use the default SSH key name value
@@ -215,7 +262,7 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 		// fallback to AWSCluster.Spec.SSHKeyName if it is defined
 		prioritizedSSHKeyName = *scope.InfraCluster.SSHKeyName()
 	default:
-		if !scope.IsExternallyManaged() {
+		if !scope.IsExternallyManaged() && scope.AWSMachine.Spec.LaunchTemplate == nil {
 			prioritizedSSHKeyName = defaultSSHKeyName
 		}
 	}
@@ -228,9 +275,28 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 	input.SpotMarketOptions = scope.AWSMachine.Spec.SpotMarketOptions
 
 	input.Tenancy = scope.AWSMachine.Spec.Tenancy
+	input.HostID = scope.AWSMachine.Spec.HostID
+	input.HostResourceGroupArn = scope.AWSMachine.Spec.HostResourceGroupArn
+	input.HostAffinity = scope.AWSMachine.Spec.HostAffinity
+
+	input.InstanceMetadataOptions = scope.AWSMachine.Spec.InstanceMetadataOptions
+
+	input.CapacityReservationSpecification = scope.AWSMachine.Spec.CapacityReservationSpecification
+
+	if err := s.validateCPUOptions(input.Type, scope.AWSMachine.Spec.CPUOptions); err != nil {
+		record.Warnf(scope.AWSMachine, "FailedCreate", "Failed to create instance: %v", err)
+		return nil, err
+	}
+	input.CPUOptions = scope.AWSMachine.Spec.CPUOptions
+
+	input.CreditSpecification = scope.AWSMachine.Spec.CreditSpecification
+
+	input.LaunchTemplate = scope.AWSMachine.Spec.LaunchTemplate
+
+	input.PrivateDNSNameOptions = scope.AWSMachine.Spec.PrivateDNSNameOptions
 
 	s.scope.Debug("Running instance", "machine-role", scope.Role())
-	out, err := s.runInstance(scope.Role(), input)
+	out, err := s.runInstanceWithFallbacks(scope, input)
 	if err != nil {
 		// Only record the failure event if the error is not related to failed dependencies.
 		// This is to avoid spamming failure events since the machine will be requeued by the actuator.
@@ -249,10 +315,236 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 		}
 	}
 
+	// DescribeInstances doesn't report the credit option of burstable performance instances, so it is read back
+	// separately when one was requested. Failing to do so doesn't fail the creation of the instance.
+	if scope.AWSMachine.Spec.CreditSpecification != nil && infrav1.IsBurstableInstanceType(out.Type) {
+		creditSpecification, err := s.getInstanceCreditSpecification(out.ID)
+		if err != nil {
+			s.scope.Error(err, "failed to read back the credit specification of the instance", "instance-id", out.ID)
+		} else {
+			out.CreditSpecification = creditSpecification
+		}
+	}
+
 	record.Eventf(scope.AWSMachine, "SuccessfulCreate", "Created new %s instance with id %q", scope.Role(), out.ID)
 	return out, nil
 }
 
+// pinTemplateAMI records the looked up AMI on the template the machine was cloned from before the instance is
+// launched. When another machine pinned an AMI on the template in the meantime, that AMI is returned instead, so
+// that all the machines created from the template boot the same image.
+func (s *Service) pinTemplateAMI(scope *scope.MachineScope, imageID string) (string, error) {
+	template := scope.AWSMachineTemplate
+	base := template.DeepCopy()
+
+	resolved, err := s.pinAMI(imageID, template, scope.Machine.Spec.Version)
+	if err != nil {
+		return "", err
+	}
+	template.Status.ResolvedAMI = resolved
+
+	err = scope.PatchAWSMachineTemplate(base)
+	if err == nil {
+		return imageID, nil
+	}
+	if !apierrors.IsConflict(err) {
+		return "", errors.Wrapf(err, "failed to pin AMI %q on AWSMachineTemplate %q", imageID, template.Name)
+	}
+
+	if err := scope.ReloadAWSMachineTemplate(); err != nil {
+		return "", errors.Wrapf(err, "failed to get AWSMachineTemplate %q", template.Name)
+	}
+	if pinned := pinnedAMI(template.Status.ResolvedAMI, template, scope.Machine.Spec.Version); pinned != nil {
+		s.scope.Debug("Using the AMI pinned concurrently on AWSMachineTemplate", "template", template.Name, "ami", pinned.ID)
+		return pinned.ID, nil
+	}
+	return "", errors.Errorf("AWSMachineTemplate %q changed while pinning AMI %q", template.Name, imageID)
+}
+
+// getInstanceCreditSpecification returns the credit option for CPU usage of a burstable performance instance.
+func (s *Service) getInstanceCreditSpecification(instanceID string) (*infrav1.CreditSpecification, error) {
+	out, err := s.EC2Client.DescribeInstanceCreditSpecifications(&ec2.DescribeInstanceCreditSpecificationsInput{
+		InstanceIds: aws.StringSlice([]string{instanceID}),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe credit specification of instance %q", instanceID)
+	}
+	if len(out.InstanceCreditSpecifications) == 0 {
+		return nil, nil
+	}
+
+	return &infrav1.CreditSpecification{
+		CPUCredits: infrav1.CPUCredits(aws.StringValue(out.InstanceCreditSpecifications[0].CpuCredits)),
+	}, nil
+}
+
+// usesLaunchTemplateNetworking returns whether the machine is launched from a launch template and leaves the
+// subnet and the security groups of the instance to it, which is the case unless the machine selects a subnet or
+// network interfaces, or a failure domain other than the one of the subnet of the launch template.
+func (s *Service) usesLaunchTemplateNetworking(scope *scope.MachineScope) (bool, error) {
+	spec := scope.AWSMachine.Spec
+	if spec.LaunchTemplate == nil ||
+		(spec.Subnet != nil && (spec.Subnet.ID != nil || spec.Subnet.Filters != nil)) ||
+		len(spec.NetworkInterfaces) > 0 || len(spec.AdditionalNetworkInterfaces) > 0 ||
+		spec.PublicIP != nil {
+		return false, nil
+	}
+
+	failureDomain := scope.Machine.Spec.FailureDomain
+	if failureDomain == nil {
+		failureDomain = spec.FailureDomain
+	}
+	if failureDomain == nil {
+		return true, nil
+	}
+
+	// Machines of a MachineDeployment always have a failure domain, which is kept by the subnet of the launch
+	// template when it is in that failure domain.
+	data, err := s.describeLaunchTemplateData(spec.LaunchTemplate)
+	if err != nil {
+		return false, err
+	}
+	var subnetID string
+	for _, eni := range data.NetworkInterfaces {
+		if aws.Int64Value(eni.DeviceIndex) == 0 && eni.SubnetId != nil {
+			subnetID = *eni.SubnetId
+		}
+	}
+	if subnetID == "" {
+		return false, nil
+	}
+
+	if subnet := s.scope.Subnets().FindByID(subnetID); subnet != nil {
+		return subnet.AvailabilityZone == *failureDomain, nil
+	}
+	subnets, err := s.getFilteredSubnets(&ec2.Filter{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{subnetID})})
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to describe subnet %q of launch template", subnetID)
+	}
+	return len(subnets) > 0 && aws.StringValue(subnets[0].AvailabilityZone) == *failureDomain, nil
+}
+
+// runInstanceWithFallbacks runs the instance with the instance type of the machine and, as long as AWS does not have
+// sufficient capacity, with each of its fallback instance types that supports the architecture of the AMI. When
+// falling back to other failure domains is allowed, the same is attempted in a subnet of each of the other failure
+// domains of the cluster, and the failure domain of the machine is updated to the one the instance is launched in.
+func (s *Service) runInstanceWithFallbacks(scope *scope.MachineScope, i *infrav1.Instance) (*infrav1.Instance, error) {
+	instanceTypes := []string{scope.AWSMachine.Spec.InstanceType}
+	if len(scope.AWSMachine.Spec.InstanceTypeFallbacks) > 0 {
+		fallbacks, err := s.getCompatibleInstanceTypeFallbacks(scope, i.ImageID)
+		if err != nil {
+			return nil, err
+		}
+		instanceTypes = append(instanceTypes, fallbacks...)
+	}
+
+	subnets := []*infrav1.SubnetSpec{{ID: i.SubnetID}}
+	if scope.AWSMachine.Spec.FailureDomainFallback && i.SubnetID != "" {
+		subnets = append(subnets, s.getFallbackSubnets(scope, i.SubnetID)...)
+	}
+
+	var err error
+	subnetIDs := make([]string, 0, len(subnets))
+	for idx, subnet := range subnets {
+		subnetIDs = append(subnetIDs, subnet.ID)
+		for _, instanceType := range instanceTypes {
+			i.SubnetID = subnet.ID
+			i.Type = instanceType
+
+			var out *infrav1.Instance
+			out, err = s.runInstance(scope.Role(), i)
+			if !awserrors.IsInsufficientInstanceCapacity(errors.Cause(err)) {
+				if err == nil && idx > 0 {
+					record.Eventf(scope.AWSMachine, "FailureDomainFallback", "Launched instance in failure domain %q instead of the one of subnet %q", subnet.AvailabilityZone, subnets[0].ID)
+					scope.SetFailureDomain(subnet.AvailabilityZone)
+				}
+				return out, err
+			}
+			record.Warnf(scope.AWSMachine, "InsufficientInstanceCapacity", "Insufficient capacity to run instance of type %q in subnet %q", instanceType, subnet.ID)
+		}
+	}
+
+	// There is nothing left to fall back to, so retrying won't succeed until the spec of the machine changes.
+	err = errors.Wrapf(err, "insufficient capacity to run instance of types %v in subnets %v", instanceTypes, subnetIDs)
+	scope.SetFailureReason(capierrors.InsufficientResourcesMachineError)
+	scope.SetFailureMessage(err)
+	return nil, err
+}
+
+// getCompatibleInstanceTypeFallbacks returns the fallback instance types of the machine that support the architecture
+// of the given AMI and the CPU options of the machine. The architecture is not checked if it is unknown.
+func (s *Service) getCompatibleInstanceTypeFallbacks(scope *scope.MachineScope, imageID string) ([]string, error) {
+	fallbacks := scope.AWSMachine.Spec.InstanceTypeFallbacks
+	cpuOptions := scope.AWSMachine.Spec.CPUOptions
+
+	var architecture string
+	if imageID != "" {
+		image, err := s.describeAMIState(imageID)
+		if err != nil {
+			return nil, err
+		}
+		if image != nil {
+			architecture = aws.StringValue(image.Architecture)
+		}
+	}
+	if architecture == "" && cpuOptions == nil {
+		return fallbacks, nil
+	}
+
+	var compatible []string
+	for _, instanceType := range fallbacks {
+		info, err := s.describeInstanceType(instanceType)
+		if err != nil {
+			return nil, err
+		}
+		if architecture != "" && info.ProcessorInfo != nil && !containsString(info.ProcessorInfo.SupportedArchitectures, architecture) {
+			record.Warnf(scope.AWSMachine, "IncompatibleInstanceTypeFallback", "Skipping fallback instance type %q, which does not support the %s architecture of AMI %q", instanceType, architecture, imageID)
+			continue
+		}
+		if err := checkCPUOptions(info, instanceType, cpuOptions); err != nil {
+			record.Warnf(scope.AWSMachine, "IncompatibleInstanceTypeFallback", "Skipping fallback instance type %q: %v", instanceType, err)
+			continue
+		}
+		compatible = append(compatible, instanceType)
+	}
+	return compatible, nil
+}
+
+// getFallbackSubnets returns a subnet of each of the failure domains of the cluster other than the one of the given
+// subnet. Subnets are only picked from the cluster network, and only if neither the machine nor its additional
+// network interfaces select their own.
+func (s *Service) getFallbackSubnets(scope *scope.MachineScope, subnetID string) []*infrav1.SubnetSpec {
+	if (scope.AWSMachine.Spec.Subnet != nil && (scope.AWSMachine.Spec.Subnet.ID != nil || scope.AWSMachine.Spec.Subnet.Filters != nil)) ||
+		len(scope.AWSMachine.Spec.NetworkInterfaces) > 0 {
+		return nil
+	}
+	for _, eni := range scope.AWSMachine.Spec.AdditionalNetworkInterfaces {
+		if eni.SubnetID != "" {
+			return nil
+		}
+	}
+
+	subnets := s.scope.Subnets().FilterPrivate()
+	if scope.AWSMachine.Spec.PublicIP != nil && *scope.AWSMachine.Spec.PublicIP {
+		subnets = s.scope.Subnets().FilterPublic()
+	}
+
+	zones := map[string]bool{}
+	if subnet := s.scope.Subnets().FindByID(subnetID); subnet != nil {
+		zones[subnet.AvailabilityZone] = true
+	}
+
+	var fallbacks []*infrav1.SubnetSpec
+	for _, subnet := range subnets {
+		if zones[subnet.AvailabilityZone] {
+			continue
+		}
+		zones[subnet.AvailabilityZone] = true
+		fallbacks = append(fallbacks, subnet.DeepCopy())
+	}
+	return fallbacks
+}
+
 // findSubnet attempts to retrieve a subnet ID in the following order:
 // - subnetID specified in machine configuration,
 // - subnet based on filters in machine configuration
@@ -494,14 +786,32 @@ func (s *Service) runInstance(role string, i *infrav1.Instance) (*infrav1.Instan
 		}
 
 		input.NetworkInterfaces = netInterfaces
+	} else if len(i.AdditionalNetworkInterfaces) > 0 {
+		// Subnet and security groups can't be set on the request when network interfaces are specified,
+		// so the primary network interface has to be described explicitly.
+		primary := &ec2.InstanceNetworkInterfaceSpecification{
+			DeviceIndex: aws.Int64(0),
+			SubnetId:    aws.String(i.SubnetID),
+		}
+		if len(i.SecurityGroupIDs) > 0 {
+			primary.Groups = aws.StringSlice(i.SecurityGroupIDs)
+		}
+
+		input.NetworkInterfaces = []*ec2.InstanceNetworkInterfaceSpecification{primary}
 	} else {
-		input.SubnetId = aws.String(i.SubnetID)
+		if i.SubnetID != "" {
+			input.SubnetId = aws.String(i.SubnetID)
+		}
 
 		if len(i.SecurityGroupIDs) > 0 {
 			input.SecurityGroupIds = aws.StringSlice(i.SecurityGroupIDs)
 		}
 	}
 
+	for _, eni := range i.AdditionalNetworkInterfaces {
+		input.NetworkInterfaces = append(input.NetworkInterfaces, getAdditionalNetworkInterfaceSpecification(eni, i, int64(len(input.NetworkInterfaces))))
+	}
+
 	if i.IAMProfile != "" {
 		input.IamInstanceProfile = &ec2.IamInstanceProfileSpecification{
 			Name: aws.String(i.IAMProfile),
@@ -558,7 +868,44 @@ func (s *Service) runInstance(role string, i *infrav1.Instance) (*infrav1.Instan
 
 	if i.Tenancy != "" {
 		input.Placement = &ec2.Placement{
-			Tenancy: &i.Tenancy,
+			Tenancy:              &i.Tenancy,
+			HostId:               i.HostID,
+			HostResourceGroupArn: i.HostResourceGroupArn,
+			Affinity:             i.HostAffinity,
+		}
+	}
+
+	input.MetadataOptions = getInstanceMetadataOptionsRequest(i.InstanceMetadataOptions)
+
+	if i.PrivateDNSNameOptions != nil {
+		input.PrivateDnsNameOptions = &ec2.PrivateDnsNameOptionsRequest{
+			HostnameType:                    i.PrivateDNSNameOptions.HostnameType,
+			EnableResourceNameDnsARecord:    i.PrivateDNSNameOptions.EnableResourceNameDNSARecord,
+			EnableResourceNameDnsAAAARecord: i.PrivateDNSNameOptions.EnableResourceNameDNSAAAARecord,
+		}
+	}
+
+	if i.LaunchTemplate != nil {
+		// The parameters set on the request override the ones of the launch template.
+		input.LaunchTemplate = &ec2.LaunchTemplateSpecification{
+			LaunchTemplateId:   i.LaunchTemplate.ID,
+			LaunchTemplateName: i.LaunchTemplate.Name,
+			Version:            i.LaunchTemplate.Version,
+		}
+	}
+
+	input.CapacityReservationSpecification = getCapacityReservationSpecification(i.CapacityReservationSpecification)
+
+	if i.CPUOptions != nil {
+		input.CpuOptions = &ec2.CpuOptionsRequest{
+			CoreCount:      aws.Int64(i.CPUOptions.CoreCount),
+			ThreadsPerCore: aws.Int64(i.CPUOptions.ThreadsPerCore),
+		}
+	}
+
+	if i.CreditSpecification != nil {
+		input.CreditSpecification = &ec2.CreditSpecificationRequest{
+			CpuCredits: aws.String(string(i.CreditSpecification.CPUCredits)),
 		}
 	}
 
@@ -584,14 +931,96 @@ func (s *Service) runInstance(role string, i *infrav1.Instance) (*infrav1.Instan
 		s.scope.Debug("Could not determine if Machine is running. Machine state might be unavailable until next renconciliation.")
 	}
 
-	return s.SDKToInstance(out.Instances[0])
+	instance, err := s.SDKToInstance(out.Instances[0])
+	if err != nil {
+		return nil, err
+	}
+
+	// EC2 only reports the EBS volumes of instances, so the instance store volumes are taken from the request.
+	instance.InstanceStoreVolumes = getInstanceStoreVolumes(input.BlockDeviceMappings)
+
+	return instance, nil
+}
+
+// getInstanceStoreVolumes returns the instance store volumes of the block device mappings.
+func getInstanceStoreVolumes(mappings []*ec2.BlockDeviceMapping) []infrav1.InstanceStoreVolume {
+	var volumes []infrav1.InstanceStoreVolume
+	for _, mapping := range mappings {
+		if mapping.VirtualName != nil {
+			volumes = append(volumes, infrav1.InstanceStoreVolume{
+				DeviceName:  aws.StringValue(mapping.DeviceName),
+				VirtualName: aws.StringValue(mapping.VirtualName),
+			})
+		}
+	}
+	return volumes
+}
+
+// usesLaunchTemplateAMI returns whether the instance of the AWSMachine is launched with the AMI of its launch template,
+// which is the case when neither an AMI nor an image lookup is set in its spec.
+func usesLaunchTemplateAMI(awsMachine *infrav1.AWSMachine) bool {
+	spec := awsMachine.Spec
+	return spec.LaunchTemplate != nil && spec.AMI.ID == nil && spec.AMI.SSMParameter == nil && spec.AMI.EKSOptimizedLookupType == nil &&
+		spec.ImageLookupFormat == "" && spec.ImageLookupOrg == "" && spec.ImageLookupBaseOS == ""
+}
+
+// getLaunchTemplateImageID returns the ID of the AMI of the referenced launch template version.
+func (s *Service) getLaunchTemplateImageID(ref *infrav1.LaunchTemplateReference) (string, error) {
+	data, err := s.describeLaunchTemplateData(ref)
+	if err != nil {
+		return "", err
+	}
+
+	if data.ImageId == nil {
+		return "", errors.Errorf("launch template version %q has no AMI, spec.ami must be set", launchTemplateVersion(ref))
+	}
+
+	return aws.StringValue(data.ImageId), nil
+}
+
+// describeLaunchTemplateData returns the data of the referenced launch template version.
+func (s *Service) describeLaunchTemplateData(ref *infrav1.LaunchTemplateReference) (*ec2.ResponseLaunchTemplateData, error) {
+	version := launchTemplateVersion(ref)
+	out, err := s.EC2Client.DescribeLaunchTemplateVersions(&ec2.DescribeLaunchTemplateVersionsInput{
+		LaunchTemplateId:   ref.ID,
+		LaunchTemplateName: ref.Name,
+		Versions:           aws.StringSlice([]string{version}),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to describe launch template")
+	}
+
+	if len(out.LaunchTemplateVersions) == 0 || out.LaunchTemplateVersions[0].LaunchTemplateData == nil {
+		return nil, errors.Errorf("launch template version %q not found", version)
+	}
+
+	return out.LaunchTemplateVersions[0].LaunchTemplateData, nil
+}
+
+func launchTemplateVersion(ref *infrav1.LaunchTemplateReference) string {
+	if ref.Version != nil {
+		return *ref.Version
+	}
+	return infrav1.LaunchTemplateDefaultVersion
 }
 
 func volumeToBlockDeviceMapping(v *infrav1.Volume) *ec2.BlockDeviceMapping {
+	if v.IsInstanceStore() {
+		return &ec2.BlockDeviceMapping{
+			DeviceName:  aws.String(v.DeviceName),
+			VirtualName: aws.String(v.VirtualName),
+		}
+	}
+
 	ebsDevice := &ec2.EbsBlockDevice{
-		DeleteOnTermination: aws.Bool(true),
-		VolumeSize:          aws.Int64(v.Size),
+		DeleteOnTermination: aws.Bool(v.VolumeDeletionPolicy != infrav1.VolumeDeletionPolicyRetain),
 		Encrypted:           v.Encrypted,
+		SnapshotId:          v.SnapshotID,
+	}
+
+	// Volumes created from a snapshot default to the size of the snapshot.
+	if v.Size != 0 {
+		ebsDevice.VolumeSize = aws.Int64(v.Size)
 	}
 
 	if v.Throughput != nil {
@@ -808,9 +1237,60 @@ func (s *Service) SDKToInstance(v *ec2.Instance) (*infrav1.Instance, error) {
 	i.Addresses = s.getInstanceAddresses(v)
 
 	i.AvailabilityZone = aws.StringValue(v.Placement.AvailabilityZone)
+	i.HostID = v.Placement.HostId
+	i.HostResourceGroupArn = v.Placement.HostResourceGroupArn
+	i.HostAffinity = v.Placement.Affinity
 
 	for _, volume := range v.BlockDeviceMappings {
-		i.VolumeIDs = append(i.VolumeIDs, *volume.Ebs.VolumeId)
+		if volume.Ebs != nil {
+			i.VolumeIDs = append(i.VolumeIDs, aws.StringValue(volume.Ebs.VolumeId))
+		}
+	}
+
+	if v.MetadataOptions != nil {
+		i.InstanceMetadataOptions = &infrav1.InstanceMetadataOptions{
+			HTTPEndpoint:            infrav1.InstanceMetadataState(aws.StringValue(v.MetadataOptions.HttpEndpoint)),
+			HTTPPutResponseHopLimit: aws.Int64Value(v.MetadataOptions.HttpPutResponseHopLimit),
+			HTTPTokens:              infrav1.HTTPTokensState(aws.StringValue(v.MetadataOptions.HttpTokens)),
+			InstanceMetadataTags:    infrav1.InstanceMetadataState(aws.StringValue(v.MetadataOptions.InstanceMetadataTags)),
+		}
+	}
+
+	if v.CapacityReservationSpecification != nil {
+		i.CapacityReservationSpecification = &infrav1.CapacityReservationSpecification{
+			CapacityReservationPreference: infrav1.CapacityReservationPreference(aws.StringValue(v.CapacityReservationSpecification.CapacityReservationPreference)),
+		}
+		if target := v.CapacityReservationSpecification.CapacityReservationTarget; target != nil {
+			i.CapacityReservationSpecification.CapacityReservationTarget = &infrav1.CapacityReservationTarget{
+				CapacityReservationID:               target.CapacityReservationId,
+				CapacityReservationResourceGroupArn: target.CapacityReservationResourceGroupArn,
+			}
+		}
+	}
+
+	i.CapacityReservationID = v.CapacityReservationId
+
+	if v.PrivateDnsNameOptions != nil {
+		i.PrivateDNSNameOptions = &infrav1.PrivateDNSNameOptions{
+			HostnameType:                    v.PrivateDnsNameOptions.HostnameType,
+			EnableResourceNameDNSARecord:    v.PrivateDnsNameOptions.EnableResourceNameDnsARecord,
+			EnableResourceNameDNSAAAARecord: v.PrivateDnsNameOptions.EnableResourceNameDnsAAAARecord,
+		}
+	}
+
+	if v.CpuOptions != nil {
+		i.CPUOptions = &infrav1.CPUOptions{
+			CoreCount:      aws.Int64Value(v.CpuOptions.CoreCount),
+			ThreadsPerCore: aws.Int64Value(v.CpuOptions.ThreadsPerCore),
+		}
+	}
+
+	// EC2 tags instances launched from a launch template with the ID and the version of the template.
+	if id, ok := i.Tags[launchTemplateIDTag]; ok {
+		i.LaunchTemplate = &infrav1.LaunchTemplateReference{ID: aws.String(id)}
+		if version, ok := i.Tags[launchTemplateVersionTag]; ok {
+			i.LaunchTemplate.Version = aws.String(version)
+		}
 	}
 
 	return i, nil
@@ -819,6 +1299,13 @@ func (s *Service) SDKToInstance(v *ec2.Instance) (*infrav1.Instance, error) {
 func (s *Service) getInstanceAddresses(instance *ec2.Instance) []clusterv1.MachineAddress {
 	addresses := []clusterv1.MachineAddress{}
 	for _, eni := range instance.NetworkInterfaces {
+		// The resource-name hostname comes first, as it is the hostname and node name of the instance when set.
+		if hostname := resourceNameHostname(instance, eni); hostname != "" {
+			addresses = append(addresses, clusterv1.MachineAddress{
+				Type:    clusterv1.MachineInternalDNS,
+				Address: hostname,
+			})
+		}
 		privateDNSAddress := clusterv1.MachineAddress{
 			Type:    clusterv1.MachineInternalDNS,
 			Address: aws.StringValue(eni.PrivateDnsName),
@@ -829,6 +1316,16 @@ func (s *Service) getInstanceAddresses(instance *ec2.Instance) []clusterv1.Machi
 		}
 		addresses = append(addresses, privateDNSAddress, privateIPAddress)
 
+		for _, ip := range eni.PrivateIpAddresses {
+			if aws.BoolValue(ip.Primary) {
+				continue
+			}
+			addresses = append(addresses, clusterv1.MachineAddress{
+				Type:    clusterv1.MachineInternalIP,
+				Address: aws.StringValue(ip.PrivateIpAddress),
+			})
+		}
+
 		// An elastic IP is attached if association is non nil pointer
 		if eni.Association != nil {
 			publicDNSAddress := clusterv1.MachineAddress{
@@ -845,6 +1342,22 @@ func (s *Service) getInstanceAddresses(instance *ec2.Instance) []clusterv1.Machi
 	return addresses
 }
 
+// resourceNameHostname returns the resource-name hostname of the instance if it is assigned one and the network
+// interface is its primary one. The hostname shares the domain of the IP-based private DNS name of the interface.
+func resourceNameHostname(instance *ec2.Instance, eni *ec2.InstanceNetworkInterface) string {
+	if instance.PrivateDnsNameOptions == nil || aws.StringValue(instance.PrivateDnsNameOptions.HostnameType) != infrav1.HostnameTypeResourceName {
+		return ""
+	}
+	if eni.Attachment == nil || aws.Int64Value(eni.Attachment.DeviceIndex) != 0 {
+		return ""
+	}
+	_, domain, found := strings.Cut(aws.StringValue(eni.PrivateDnsName), ".")
+	if !found {
+		return ""
+	}
+	return aws.StringValue(instance.InstanceId) + "." + domain
+}
+
 func (s *Service) getNetworkInterfaceSecurityGroups(interfaceID string) ([]string, error) {
 	input := &ec2.DescribeNetworkInterfaceAttributeInput{
 		Attribute:          aws.String("groupSet"),
@@ -990,3 +1503,70 @@ func getInstanceMarketOptionsRequest(spotMarketOptions *infrav1.SpotMarketOption
 
 	return instanceMarketOptionsRequest
 }
+
+func getInstanceMetadataOptionsRequest(metadataOptions *infrav1.InstanceMetadataOptions) *ec2.InstanceMetadataOptionsRequest {
+	if metadataOptions == nil {
+		return nil
+	}
+
+	request := &ec2.InstanceMetadataOptionsRequest{}
+	if metadataOptions.HTTPEndpoint != "" {
+		request.SetHttpEndpoint(string(metadataOptions.HTTPEndpoint))
+	}
+	if metadataOptions.HTTPPutResponseHopLimit != 0 {
+		request.SetHttpPutResponseHopLimit(metadataOptions.HTTPPutResponseHopLimit)
+	}
+	if metadataOptions.HTTPTokens != "" {
+		request.SetHttpTokens(string(metadataOptions.HTTPTokens))
+	}
+	if metadataOptions.InstanceMetadataTags != "" {
+		request.SetInstanceMetadataTags(string(metadataOptions.InstanceMetadataTags))
+	}
+
+	return request
+}
+
+func getCapacityReservationSpecification(spec *infrav1.CapacityReservationSpecification) *ec2.CapacityReservationSpecification {
+	if spec == nil {
+		return nil
+	}
+
+	request := &ec2.CapacityReservationSpecification{}
+	if spec.CapacityReservationPreference != "" {
+		request.SetCapacityReservationPreference(string(spec.CapacityReservationPreference))
+	}
+	if spec.CapacityReservationTarget != nil {
+		request.SetCapacityReservationTarget(&ec2.CapacityReservationTarget{
+			CapacityReservationId:               spec.CapacityReservationTarget.CapacityReservationID,
+			CapacityReservationResourceGroupArn: spec.CapacityReservationTarget.CapacityReservationResourceGroupArn,
+		})
+	}
+
+	return request
+}
+
+// getAdditionalNetworkInterfaceSpecification builds the specification of a network interface created at launch,
+// defaulting the subnet and security groups to the ones of the instance.
+func getAdditionalNetworkInterfaceSpecification(eni infrav1.AdditionalNetworkInterface, i *infrav1.Instance, deviceIndex int64) *ec2.InstanceNetworkInterfaceSpecification {
+	spec := &ec2.InstanceNetworkInterfaceSpecification{
+		DeviceIndex:                    aws.Int64(deviceIndex),
+		DeleteOnTermination:            aws.Bool(true),
+		Description:                    eni.Description,
+		SecondaryPrivateIpAddressCount: eni.SecondaryPrivateIPAddressCount,
+		Ipv4PrefixCount:                eni.IPv4PrefixCount,
+		SubnetId:                       aws.String(i.SubnetID),
+	}
+	if eni.SubnetID != "" {
+		spec.SubnetId = aws.String(eni.SubnetID)
+	}
+
+	groups := eni.SecurityGroupIDs
+	if len(groups) == 0 {
+		groups = i.SecurityGroupIDs
+	}
+	if len(groups) > 0 {
+		spec.Groups = aws.StringSlice(groups)
+	}
+
+	return spec
+}
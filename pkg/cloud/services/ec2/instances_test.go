@@ -40,6 +40,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/userdata"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	capierrors "sigs.k8s.io/cluster-api/errors"
 )
 
 func TestInstanceIfExists(t *testing.T) {
@@ -301,6 +302,10 @@ func TestCreateInstance(t *testing.T) {
 		awsCluster    *infrav1.AWSCluster
 		expect        func(m *mocks.MockEC2APIMockRecorder)
 		check         func(instance *infrav1.Instance, err error)
+		// expectFailureDomain is the failure domain the AWSMachine is expected to be updated to, if any.
+		expectFailureDomain *string
+		// expectFailureReason is the failure reason the AWSMachine is expected to be set to, if any.
+		expectFailureReason *capierrors.MachineStatusError
 	}{
 		{
 			name: "simple",
@@ -2146,7 +2151,7 @@ func TestCreateInstance(t *testing.T) {
 			},
 		},
 		{
-			name: "with dedicated tenancy ignition",
+			name: "with additional network interfaces",
 			machine: clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels:    map[string]string{"set": "node"},
@@ -2163,13 +2168,16 @@ func TestCreateInstance(t *testing.T) {
 				AMI: infrav1.AMIReference{
 					ID: aws.String("abc"),
 				},
-				InstanceType:         "m5.large",
-				Tenancy:              "dedicated",
-				UncompressedUserData: &isUncompressedTrue,
-				Ignition:             &infrav1.Ignition{},
+				InstanceType: "m5.large",
+				AdditionalNetworkInterfaces: []infrav1.AdditionalNetworkInterface{
+					{
+						SubnetID:        "subnet-2",
+						IPv4PrefixCount: aws.Int64(1),
+					},
+				},
+				UncompressedUserData: &isUncompressedFalse,
 			},
 			awsCluster: &infrav1.AWSCluster{
-				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
 					NetworkSpec: infrav1.NetworkSpec{
 						Subnets: infrav1.Subnets{
@@ -2210,11 +2218,20 @@ func TestCreateInstance(t *testing.T) {
 						KeyName:      aws.String("default"),
 						MaxCount:     aws.Int64(1),
 						MinCount:     aws.Int64(1),
-						Placement: &ec2.Placement{
-							Tenancy: &tenancy,
+						NetworkInterfaces: []*ec2.InstanceNetworkInterfaceSpecification{
+							{
+								DeviceIndex: aws.Int64(0),
+								SubnetId:    aws.String("subnet-1"),
+								Groups:      []*string{aws.String("2"), aws.String("3")},
+							},
+							{
+								DeviceIndex:         aws.Int64(1),
+								DeleteOnTermination: aws.Bool(true),
+								Ipv4PrefixCount:     aws.Int64(1),
+								SubnetId:            aws.String("subnet-2"),
+								Groups:              []*string{aws.String("2"), aws.String("3")},
+							},
 						},
-						SecurityGroupIds: []*string{aws.String("2"), aws.String("3")},
-						SubnetId:         aws.String("subnet-1"),
 						TagSpecifications: []*ec2.TagSpecification{
 							{
 								ResourceType: aws.String("instance"),
@@ -2242,7 +2259,7 @@ func TestCreateInstance(t *testing.T) {
 								},
 							},
 						},
-						UserData: aws.String(base64.StdEncoding.EncodeToString(data)),
+						UserData: aws.String(base64.StdEncoding.EncodeToString(userDataCompressed)),
 					})).
 					Return(&ec2.Reservation{
 						Instances: []*ec2.Instance{
@@ -2268,7 +2285,21 @@ func TestCreateInstance(t *testing.T) {
 								},
 								Placement: &ec2.Placement{
 									AvailabilityZone: &az,
-									Tenancy:          &tenancy,
+								},
+								NetworkInterfaces: []*ec2.InstanceNetworkInterface{
+									{
+										PrivateIpAddress: aws.String("10.0.0.10"),
+										PrivateIpAddresses: []*ec2.InstancePrivateIpAddress{
+											{Primary: aws.Bool(true), PrivateIpAddress: aws.String("10.0.0.10")},
+										},
+									},
+									{
+										PrivateIpAddress: aws.String("10.0.1.10"),
+										PrivateIpAddresses: []*ec2.InstancePrivateIpAddress{
+											{Primary: aws.Bool(true), PrivateIpAddress: aws.String("10.0.1.10")},
+											{Primary: aws.Bool(false), PrivateIpAddress: aws.String("10.0.1.11")},
+										},
+									},
 								},
 							},
 						},
@@ -2280,26 +2311,42 @@ func TestCreateInstance(t *testing.T) {
 				if err != nil {
 					t.Fatalf("did not expect error: %v", err)
 				}
+				var internalIPs []string
+				for _, address := range instance.Addresses {
+					if address.Type == clusterv1.MachineInternalIP {
+						internalIPs = append(internalIPs, address.Address)
+					}
+				}
+				if diff := cmp.Diff([]string{"10.0.0.10", "10.0.1.10", "10.0.1.11"}, internalIPs); diff != "" {
+					t.Fatalf("unexpected internal IPs (-want +got):\n%s", diff)
+				}
 			},
 		},
 		{
-			name: "expect the default SSH key when none is provided",
+			name: "with host tenancy, host ID and affinity",
 			machine: clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: map[string]string{"set": "node"},
+					Labels:    map[string]string{"set": "node"},
+					Namespace: "default",
+					Name:      "machine-aws-test1",
 				},
 				Spec: clusterv1.MachineSpec{
 					Bootstrap: clusterv1.Bootstrap{
 						DataSecretName: pointer.StringPtr("bootstrap-data"),
 					},
-					Version: pointer.StringPtr("v1.16.1"),
 				},
 			},
 			machineConfig: &infrav1.AWSMachineSpec{
-				InstanceType: "m5.large",
+				AMI: infrav1.AMIReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType:         "m5.large",
+				Tenancy:              "host",
+				HostID:               aws.String("h-1"),
+				HostAffinity:         aws.String("host"),
+				UncompressedUserData: &isUncompressedFalse,
 			},
 			awsCluster: &infrav1.AWSCluster{
-				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
 					NetworkSpec: infrav1.NetworkSpec{
 						Subnets: infrav1.Subnets{
@@ -2333,54 +2380,80 @@ func TestCreateInstance(t *testing.T) {
 				},
 			},
 			expect: func(m *mocks.MockEC2APIMockRecorder) {
-				m.
-					DescribeImages(gomock.Any()).
-					Return(&ec2.DescribeImagesOutput{
-						Images: []*ec2.Image{
-							{
-								Name:         aws.String("ami-1"),
-								CreationDate: aws.String("2011-02-08T17:02:31.000Z"),
-							},
-						},
-					}, nil)
 				m. // TODO: Restore these parameters, but with the tags as well
-					RunInstances(gomock.Any()).
-					DoAndReturn(func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
-						if input.KeyName == nil {
-							t.Fatal("Expected key name not to be nil")
-						}
-						if *input.KeyName != defaultSSHKeyName {
-							t.Fatalf("Expected SSH key name to be '%s', not '%s'", defaultSSHKeyName, *input.KeyName)
-						}
-						return &ec2.Reservation{
-							Instances: []*ec2.Instance{
-								{
-									State: &ec2.InstanceState{
-										Name: aws.String(ec2.InstanceStateNamePending),
+					RunInstances(gomock.Eq(&ec2.RunInstancesInput{
+						ImageId:      aws.String("abc"),
+						InstanceType: aws.String("m5.large"),
+						KeyName:      aws.String("default"),
+						MaxCount:     aws.Int64(1),
+						MinCount:     aws.Int64(1),
+						Placement: &ec2.Placement{
+							Tenancy:  aws.String("host"),
+							HostId:   aws.String("h-1"),
+							Affinity: aws.String("host"),
+						},
+						SecurityGroupIds: []*string{aws.String("2"), aws.String("3")},
+						SubnetId:         aws.String("subnet-1"),
+						TagSpecifications: []*ec2.TagSpecification{
+							{
+								ResourceType: aws.String("instance"),
+								Tags: []*ec2.Tag{
+									{
+										Key:   aws.String("MachineName"),
+										Value: aws.String("default/machine-aws-test1"),
 									},
-									IamInstanceProfile: &ec2.IamInstanceProfile{
-										Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+									{
+										Key:   aws.String("Name"),
+										Value: aws.String("aws-test1"),
 									},
-									InstanceId:     aws.String("two"),
-									InstanceType:   aws.String("m5.large"),
-									SubnetId:       aws.String("subnet-1"),
-									ImageId:        aws.String("ami-1"),
-									RootDeviceName: aws.String("device-1"),
-									BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
-										{
-											DeviceName: aws.String("device-1"),
-											Ebs: &ec2.EbsInstanceBlockDevice{
-												VolumeId: aws.String("volume-1"),
-											},
-										},
+									{
+										Key:   aws.String("kubernetes.io/cluster/test1"),
+										Value: aws.String("owned"),
 									},
-									Placement: &ec2.Placement{
-										AvailabilityZone: &az,
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/v2/cluster/test1"),
+										Value: aws.String("owned"),
+									},
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/v2/role"),
+										Value: aws.String("node"),
 									},
 								},
 							},
-						}, nil
-					})
+						},
+						UserData: aws.String(base64.StdEncoding.EncodeToString(userDataCompressed)),
+					})).
+					Return(&ec2.Reservation{
+						Instances: []*ec2.Instance{
+							{
+								State: &ec2.InstanceState{
+									Name: aws.String(ec2.InstanceStateNamePending),
+								},
+								IamInstanceProfile: &ec2.IamInstanceProfile{
+									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+								},
+								InstanceId:     aws.String("two"),
+								InstanceType:   aws.String("m5.large"),
+								SubnetId:       aws.String("subnet-1"),
+								ImageId:        aws.String("ami-1"),
+								RootDeviceName: aws.String("device-1"),
+								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+									{
+										DeviceName: aws.String("device-1"),
+										Ebs: &ec2.EbsInstanceBlockDevice{
+											VolumeId: aws.String("volume-1"),
+										},
+									},
+								},
+								Placement: &ec2.Placement{
+									AvailabilityZone: &az,
+									Tenancy:          aws.String("host"),
+									HostId:           aws.String("h-1"),
+									Affinity:         aws.String("host"),
+								},
+							},
+						},
+					}, nil)
 				m.WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil)
 			},
@@ -2388,23 +2461,33 @@ func TestCreateInstance(t *testing.T) {
 				if err != nil {
 					t.Fatalf("did not expect error: %v", err)
 				}
+				if aws.StringValue(instance.HostID) != "h-1" {
+					t.Fatalf("expected host ID h-1, got %v", aws.StringValue(instance.HostID))
+				}
 			},
 		},
 		{
-			name: "expect to use the cluster level ssh key name when no machine key name is provided",
+			name: "with dedicated tenancy ignition",
 			machine: clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: map[string]string{"set": "node"},
+					Labels:    map[string]string{"set": "node"},
+					Namespace: "default",
+					Name:      "machine-aws-test1",
 				},
 				Spec: clusterv1.MachineSpec{
 					Bootstrap: clusterv1.Bootstrap{
 						DataSecretName: pointer.StringPtr("bootstrap-data"),
 					},
-					Version: pointer.StringPtr("v1.16.1"),
 				},
 			},
 			machineConfig: &infrav1.AWSMachineSpec{
-				InstanceType: "m5.large",
+				AMI: infrav1.AMIReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType:         "m5.large",
+				Tenancy:              "dedicated",
+				UncompressedUserData: &isUncompressedTrue,
+				Ignition:             &infrav1.Ignition{},
 			},
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
@@ -2420,7 +2503,6 @@ func TestCreateInstance(t *testing.T) {
 							},
 						},
 					},
-					SSHKeyName: aws.String("specific-cluster-key-name"),
 				},
 				Status: infrav1.AWSClusterStatus{
 					Network: infrav1.NetworkStatus{
@@ -2442,44 +2524,283 @@ func TestCreateInstance(t *testing.T) {
 				},
 			},
 			expect: func(m *mocks.MockEC2APIMockRecorder) {
-				m.
-					DescribeImages(gomock.Any()).
-					Return(&ec2.DescribeImagesOutput{
-						Images: []*ec2.Image{
+				m. // TODO: Restore these parameters, but with the tags as well
+					RunInstances(gomock.Eq(&ec2.RunInstancesInput{
+						ImageId:      aws.String("abc"),
+						InstanceType: aws.String("m5.large"),
+						KeyName:      aws.String("default"),
+						MaxCount:     aws.Int64(1),
+						MinCount:     aws.Int64(1),
+						Placement: &ec2.Placement{
+							Tenancy: &tenancy,
+						},
+						SecurityGroupIds: []*string{aws.String("2"), aws.String("3")},
+						SubnetId:         aws.String("subnet-1"),
+						TagSpecifications: []*ec2.TagSpecification{
 							{
-								Name:         aws.String("ami-1"),
-								CreationDate: aws.String("2011-02-08T17:02:31.000Z"),
+								ResourceType: aws.String("instance"),
+								Tags: []*ec2.Tag{
+									{
+										Key:   aws.String("MachineName"),
+										Value: aws.String("default/machine-aws-test1"),
+									},
+									{
+										Key:   aws.String("Name"),
+										Value: aws.String("aws-test1"),
+									},
+									{
+										Key:   aws.String("kubernetes.io/cluster/test1"),
+										Value: aws.String("owned"),
+									},
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/v2/cluster/test1"),
+										Value: aws.String("owned"),
+									},
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/v2/role"),
+										Value: aws.String("node"),
+									},
+								},
 							},
 						},
-					}, nil)
-				m. // TODO: Restore these parameters, but with the tags as well
-					RunInstances(gomock.Any()).
-					DoAndReturn(func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
-						if input.KeyName == nil {
-							t.Fatal("Expected key name not to be nil")
-						}
-						if *input.KeyName != "specific-cluster-key-name" {
-							t.Fatalf("Expected SSH key name to be '%s', not '%s'", "specific-cluster-key-name", *input.KeyName)
-						}
-						return &ec2.Reservation{
-							Instances: []*ec2.Instance{
-								{
-									State: &ec2.InstanceState{
-										Name: aws.String(ec2.InstanceStateNamePending),
-									},
-									IamInstanceProfile: &ec2.IamInstanceProfile{
-										Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+						UserData: aws.String(base64.StdEncoding.EncodeToString(data)),
+					})).
+					Return(&ec2.Reservation{
+						Instances: []*ec2.Instance{
+							{
+								State: &ec2.InstanceState{
+									Name: aws.String(ec2.InstanceStateNamePending),
+								},
+								IamInstanceProfile: &ec2.IamInstanceProfile{
+									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+								},
+								InstanceId:     aws.String("two"),
+								InstanceType:   aws.String("m5.large"),
+								SubnetId:       aws.String("subnet-1"),
+								ImageId:        aws.String("ami-1"),
+								RootDeviceName: aws.String("device-1"),
+								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+									{
+										DeviceName: aws.String("device-1"),
+										Ebs: &ec2.EbsInstanceBlockDevice{
+											VolumeId: aws.String("volume-1"),
+										},
 									},
-									InstanceId:     aws.String("two"),
-									InstanceType:   aws.String("m5.large"),
-									SubnetId:       aws.String("subnet-1"),
-									ImageId:        aws.String("ami-1"),
-									RootDeviceName: aws.String("device-1"),
-									BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
-										{
-											DeviceName: aws.String("device-1"),
-											Ebs: &ec2.EbsInstanceBlockDevice{
-												VolumeId: aws.String("volume-1"),
+								},
+								Placement: &ec2.Placement{
+									AvailabilityZone: &az,
+									Tenancy:          &tenancy,
+								},
+							},
+						},
+					}, nil)
+				m.WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+			},
+		},
+		{
+			name: "expect the default SSH key when none is provided",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: pointer.StringPtr("bootstrap-data"),
+					},
+					Version: pointer.StringPtr("v1.16.1"),
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				InstanceType: "m5.large",
+			},
+			awsCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{
+							infrav1.SubnetSpec{
+								ID:       "subnet-1",
+								IsPublic: false,
+							},
+							infrav1.SubnetSpec{
+								IsPublic: false,
+							},
+						},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.ClassicELB{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.
+					DescribeImages(gomock.Any()).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								Name:         aws.String("ami-1"),
+								CreationDate: aws.String("2011-02-08T17:02:31.000Z"),
+							},
+						},
+					}, nil)
+				m. // TODO: Restore these parameters, but with the tags as well
+					RunInstances(gomock.Any()).
+					DoAndReturn(func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+						if input.KeyName == nil {
+							t.Fatal("Expected key name not to be nil")
+						}
+						if *input.KeyName != defaultSSHKeyName {
+							t.Fatalf("Expected SSH key name to be '%s', not '%s'", defaultSSHKeyName, *input.KeyName)
+						}
+						return &ec2.Reservation{
+							Instances: []*ec2.Instance{
+								{
+									State: &ec2.InstanceState{
+										Name: aws.String(ec2.InstanceStateNamePending),
+									},
+									IamInstanceProfile: &ec2.IamInstanceProfile{
+										Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+									},
+									InstanceId:     aws.String("two"),
+									InstanceType:   aws.String("m5.large"),
+									SubnetId:       aws.String("subnet-1"),
+									ImageId:        aws.String("ami-1"),
+									RootDeviceName: aws.String("device-1"),
+									BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+										{
+											DeviceName: aws.String("device-1"),
+											Ebs: &ec2.EbsInstanceBlockDevice{
+												VolumeId: aws.String("volume-1"),
+											},
+										},
+									},
+									Placement: &ec2.Placement{
+										AvailabilityZone: &az,
+									},
+								},
+							},
+						}, nil
+					})
+				m.WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+			},
+		},
+		{
+			name: "expect to use the cluster level ssh key name when no machine key name is provided",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: pointer.StringPtr("bootstrap-data"),
+					},
+					Version: pointer.StringPtr("v1.16.1"),
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				InstanceType: "m5.large",
+			},
+			awsCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{
+							infrav1.SubnetSpec{
+								ID:       "subnet-1",
+								IsPublic: false,
+							},
+							infrav1.SubnetSpec{
+								IsPublic: false,
+							},
+						},
+					},
+					SSHKeyName: aws.String("specific-cluster-key-name"),
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.ClassicELB{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.
+					DescribeImages(gomock.Any()).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								Name:         aws.String("ami-1"),
+								CreationDate: aws.String("2011-02-08T17:02:31.000Z"),
+							},
+						},
+					}, nil)
+				m. // TODO: Restore these parameters, but with the tags as well
+					RunInstances(gomock.Any()).
+					DoAndReturn(func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+						if input.KeyName == nil {
+							t.Fatal("Expected key name not to be nil")
+						}
+						if *input.KeyName != "specific-cluster-key-name" {
+							t.Fatalf("Expected SSH key name to be '%s', not '%s'", "specific-cluster-key-name", *input.KeyName)
+						}
+						return &ec2.Reservation{
+							Instances: []*ec2.Instance{
+								{
+									State: &ec2.InstanceState{
+										Name: aws.String(ec2.InstanceStateNamePending),
+									},
+									IamInstanceProfile: &ec2.IamInstanceProfile{
+										Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+									},
+									InstanceId:     aws.String("two"),
+									InstanceType:   aws.String("m5.large"),
+									SubnetId:       aws.String("subnet-1"),
+									ImageId:        aws.String("ami-1"),
+									RootDeviceName: aws.String("device-1"),
+									BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+										{
+											DeviceName: aws.String("device-1"),
+											Ebs: &ec2.EbsInstanceBlockDevice{
+												VolumeId: aws.String("volume-1"),
 											},
 										},
 									},
@@ -2500,7 +2821,1314 @@ func TestCreateInstance(t *testing.T) {
 			},
 		},
 		{
-			name: "expect to use the machine level ssh key name when both cluster and machine key names are provided",
+			name: "expect to use the machine level ssh key name when both cluster and machine key names are provided",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: pointer.StringPtr("bootstrap-data"),
+					},
+					Version: pointer.StringPtr("v1.16.1"),
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				InstanceType: "m5.large",
+				SSHKeyName:   aws.String("specific-machine-ssh-key-name"),
+			},
+			awsCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{
+							infrav1.SubnetSpec{
+								ID:       "subnet-1",
+								IsPublic: false,
+							},
+							infrav1.SubnetSpec{
+								IsPublic: false,
+							},
+						},
+					},
+					SSHKeyName: aws.String("specific-cluster-key-name"),
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.ClassicELB{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.
+					DescribeImages(gomock.Any()).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								Name:         aws.String("ami-1"),
+								CreationDate: aws.String("2011-02-08T17:02:31.000Z"),
+							},
+						},
+					}, nil)
+				m. // TODO: Restore these parameters, but with the tags as well
+					RunInstances(gomock.Any()).
+					DoAndReturn(func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+						if input.KeyName == nil {
+							t.Fatal("Expected key name not to be nil")
+						}
+						if *input.KeyName != "specific-machine-ssh-key-name" {
+							t.Fatalf("Expected SSH key name to be '%s', not '%s'", "specific-machine-ssh-key-name", *input.KeyName)
+						}
+						return &ec2.Reservation{
+							Instances: []*ec2.Instance{
+								{
+									State: &ec2.InstanceState{
+										Name: aws.String(ec2.InstanceStateNamePending),
+									},
+									IamInstanceProfile: &ec2.IamInstanceProfile{
+										Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+									},
+									InstanceId:     aws.String("two"),
+									InstanceType:   aws.String("m5.large"),
+									SubnetId:       aws.String("subnet-1"),
+									ImageId:        aws.String("ami-1"),
+									RootDeviceName: aws.String("device-1"),
+									BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+										{
+											DeviceName: aws.String("device-1"),
+											Ebs: &ec2.EbsInstanceBlockDevice{
+												VolumeId: aws.String("volume-1"),
+											},
+										},
+									},
+									Placement: &ec2.Placement{
+										AvailabilityZone: &az,
+									},
+								},
+							},
+						}, nil
+					})
+				m.WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+			},
+		},
+		{
+			name: "expect ssh key to be unset when cluster key name is empty string and machine key name is nil",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: pointer.StringPtr("bootstrap-data"),
+					},
+					Version: pointer.StringPtr("v1.16.1"),
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				InstanceType: "m5.large",
+				SSHKeyName:   nil,
+			},
+			awsCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{
+							infrav1.SubnetSpec{
+								ID:       "subnet-1",
+								IsPublic: false,
+							},
+							infrav1.SubnetSpec{
+								IsPublic: false,
+							},
+						},
+					},
+					SSHKeyName: aws.String(""),
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.ClassicELB{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.
+					DescribeImages(gomock.Any()).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								Name:         aws.String("ami-1"),
+								CreationDate: aws.String("2011-02-08T17:02:31.000Z"),
+							},
+						},
+					}, nil)
+				m. // TODO: Restore these parameters, but with the tags as well
+					RunInstances(gomock.Any()).
+					DoAndReturn(func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+						if input.KeyName != nil {
+							t.Fatalf("Expected key name to be nil/unspecified, not '%s'", *input.KeyName)
+						}
+						return &ec2.Reservation{
+							Instances: []*ec2.Instance{
+								{
+									State: &ec2.InstanceState{
+										Name: aws.String(ec2.InstanceStateNamePending),
+									},
+									IamInstanceProfile: &ec2.IamInstanceProfile{
+										Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+									},
+									InstanceId:     aws.String("two"),
+									InstanceType:   aws.String("m5.large"),
+									SubnetId:       aws.String("subnet-1"),
+									ImageId:        aws.String("ami-1"),
+									RootDeviceName: aws.String("device-1"),
+									BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+										{
+											DeviceName: aws.String("device-1"),
+											Ebs: &ec2.EbsInstanceBlockDevice{
+												VolumeId: aws.String("volume-1"),
+											},
+										},
+									},
+									Placement: &ec2.Placement{
+										AvailabilityZone: &az,
+									},
+								},
+							},
+						}, nil
+					})
+				m.WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+			},
+		},
+		{
+			name: "expect ssh key to be unset when cluster key name is empty string and machine key name is empty string",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: pointer.StringPtr("bootstrap-data"),
+					},
+					Version: pointer.StringPtr("v1.16.1"),
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				InstanceType: "m5.large",
+				SSHKeyName:   aws.String(""),
+			},
+			awsCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{
+							infrav1.SubnetSpec{
+								ID:       "subnet-1",
+								IsPublic: false,
+							},
+							infrav1.SubnetSpec{
+								IsPublic: false,
+							},
+						},
+					},
+					SSHKeyName: aws.String(""),
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.ClassicELB{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.
+					DescribeImages(gomock.Any()).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								Name:         aws.String("ami-1"),
+								CreationDate: aws.String("2011-02-08T17:02:31.000Z"),
+							},
+						},
+					}, nil)
+				m. // TODO: Restore these parameters, but with the tags as well
+					RunInstances(gomock.Any()).
+					DoAndReturn(func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+						if input.KeyName != nil {
+							t.Fatalf("Expected key name to be nil/unspecified, not '%s'", *input.KeyName)
+						}
+						return &ec2.Reservation{
+							Instances: []*ec2.Instance{
+								{
+									State: &ec2.InstanceState{
+										Name: aws.String(ec2.InstanceStateNamePending),
+									},
+									IamInstanceProfile: &ec2.IamInstanceProfile{
+										Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+									},
+									InstanceId:     aws.String("two"),
+									InstanceType:   aws.String("m5.large"),
+									SubnetId:       aws.String("subnet-1"),
+									ImageId:        aws.String("ami-1"),
+									RootDeviceName: aws.String("device-1"),
+									BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+										{
+											DeviceName: aws.String("device-1"),
+											Ebs: &ec2.EbsInstanceBlockDevice{
+												VolumeId: aws.String("volume-1"),
+											},
+										},
+									},
+									Placement: &ec2.Placement{
+										AvailabilityZone: &az,
+									},
+								},
+							},
+						}, nil
+					})
+				m.WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+			},
+		},
+		{
+			name: "expect ssh key to be unset when cluster key name is nil and machine key name is empty string",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: pointer.StringPtr("bootstrap-data"),
+					},
+					Version: pointer.StringPtr("v1.16.1"),
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				InstanceType: "m5.large",
+				SSHKeyName:   aws.String(""),
+			},
+			awsCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{
+							infrav1.SubnetSpec{
+								ID:       "subnet-1",
+								IsPublic: false,
+							},
+							infrav1.SubnetSpec{
+								IsPublic: false,
+							},
+						},
+					},
+					SSHKeyName: nil,
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.ClassicELB{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.
+					DescribeImages(gomock.Any()).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								Name:         aws.String("ami-1"),
+								CreationDate: aws.String("2011-02-08T17:02:31.000Z"),
+							},
+						},
+					}, nil)
+				m. // TODO: Restore these parameters, but with the tags as well
+					RunInstances(gomock.Any()).
+					DoAndReturn(func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+						if input.KeyName != nil {
+							t.Fatalf("Expected key name to be nil/unspecified, not '%s'", *input.KeyName)
+						}
+						return &ec2.Reservation{
+							Instances: []*ec2.Instance{
+								{
+									State: &ec2.InstanceState{
+										Name: aws.String(ec2.InstanceStateNamePending),
+									},
+									IamInstanceProfile: &ec2.IamInstanceProfile{
+										Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+									},
+									InstanceId:     aws.String("two"),
+									InstanceType:   aws.String("m5.large"),
+									SubnetId:       aws.String("subnet-1"),
+									ImageId:        aws.String("ami-1"),
+									RootDeviceName: aws.String("device-1"),
+									BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+										{
+											DeviceName: aws.String("device-1"),
+											Ebs: &ec2.EbsInstanceBlockDevice{
+												VolumeId: aws.String("volume-1"),
+											},
+										},
+									},
+									Placement: &ec2.Placement{
+										AvailabilityZone: &az,
+									},
+								},
+							},
+						}, nil
+					})
+				m.WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+			},
+		},
+		{
+			name: "launches from a launch template with the AMI of the template",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: pointer.StringPtr("bootstrap-data"),
+					},
+					Version: pointer.StringPtr("v1.16.1"),
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				InstanceType: "m5.large",
+				LaunchTemplate: &infrav1.LaunchTemplateReference{
+					Name: aws.String("hardened"),
+				},
+			},
+			awsCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{
+							infrav1.SubnetSpec{
+								ID:               "subnet-1",
+								AvailabilityZone: "us-east-1a",
+							},
+							infrav1.SubnetSpec{
+								ID:               "subnet-2",
+								AvailabilityZone: "us-east-1b",
+							},
+						},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.ClassicELB{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.
+					DescribeLaunchTemplateVersions(gomock.Eq(&ec2.DescribeLaunchTemplateVersionsInput{
+						LaunchTemplateName: aws.String("hardened"),
+						Versions:           aws.StringSlice([]string{"$Default"}),
+					})).
+					Return(&ec2.DescribeLaunchTemplateVersionsOutput{
+						LaunchTemplateVersions: []*ec2.LaunchTemplateVersion{
+							{
+								LaunchTemplateData: &ec2.ResponseLaunchTemplateData{
+									ImageId: aws.String("ami-hardened"),
+								},
+							},
+						},
+					}, nil)
+				m.
+					RunInstances(gomock.Any()).
+					DoAndReturn(func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+						if aws.StringValue(input.LaunchTemplate.LaunchTemplateName) != "hardened" {
+							t.Fatalf("Expected instance to be launched from launch template %q, got %v", "hardened", input.LaunchTemplate)
+						}
+						if aws.StringValue(input.ImageId) != "ami-hardened" || aws.StringValue(input.InstanceType) != "m5.large" {
+							t.Fatalf("Expected instance of type %q with AMI %q, got %q with %q", "m5.large", "ami-hardened", aws.StringValue(input.InstanceType), aws.StringValue(input.ImageId))
+						}
+						if input.SubnetId != nil || input.SecurityGroupIds != nil || input.KeyName != nil {
+							t.Fatalf("Expected the subnet, the security groups and the SSH key to be left to the launch template, got %v, %v and %v",
+								input.SubnetId, input.SecurityGroupIds, input.KeyName)
+						}
+						return &ec2.Reservation{
+							Instances: []*ec2.Instance{
+								{
+									State: &ec2.InstanceState{
+										Name: aws.String(ec2.InstanceStateNamePending),
+									},
+									InstanceId:   aws.String("two"),
+									InstanceType: input.InstanceType,
+									SubnetId:     aws.String("subnet-template"),
+									ImageId:      input.ImageId,
+									Placement: &ec2.Placement{
+										AvailabilityZone: &az,
+									},
+									Tags: []*ec2.Tag{
+										{Key: aws.String("aws:ec2launchtemplate:id"), Value: aws.String("lt-hardened")},
+										{Key: aws.String("aws:ec2launchtemplate:version"), Value: aws.String("4")},
+									},
+								},
+							},
+						}, nil
+					})
+				m.WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+				if instance.ImageID != "ami-hardened" {
+					t.Fatalf("expected instance with AMI ami-hardened, got %q", instance.ImageID)
+				}
+				expected := &infrav1.LaunchTemplateReference{ID: aws.String("lt-hardened"), Version: aws.String("4")}
+				if !cmp.Equal(instance.LaunchTemplate, expected) {
+					t.Fatalf("expected instance launched from launch template %v, got %v", expected, instance.LaunchTemplate)
+				}
+			},
+		},
+		{
+			name: "launches from a launch template whose subnet is not in the failure domain of the machine",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: pointer.StringPtr("bootstrap-data"),
+					},
+					Version:       pointer.StringPtr("v1.16.1"),
+					FailureDomain: aws.String("us-east-1b"),
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				InstanceType: "m5.large",
+				SSHKeyName:   aws.String("ops"),
+				LaunchTemplate: &infrav1.LaunchTemplateReference{
+					Name: aws.String("hardened"),
+				},
+			},
+			awsCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{
+							infrav1.SubnetSpec{
+								ID:               "subnet-1",
+								AvailabilityZone: "us-east-1a",
+							},
+							infrav1.SubnetSpec{
+								ID:               "subnet-2",
+								AvailabilityZone: "us-east-1b",
+							},
+						},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.ClassicELB{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.
+					DescribeLaunchTemplateVersions(gomock.Any()).
+					Times(2).
+					Return(&ec2.DescribeLaunchTemplateVersionsOutput{
+						LaunchTemplateVersions: []*ec2.LaunchTemplateVersion{
+							{
+								LaunchTemplateData: &ec2.ResponseLaunchTemplateData{
+									ImageId: aws.String("ami-hardened"),
+									NetworkInterfaces: []*ec2.LaunchTemplateInstanceNetworkInterfaceSpecification{
+										{
+											DeviceIndex: aws.Int64(0),
+											SubnetId:    aws.String("subnet-1"),
+										},
+									},
+								},
+							},
+						},
+					}, nil)
+				m.
+					RunInstances(gomock.Any()).
+					DoAndReturn(func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+						if aws.StringValue(input.SubnetId) != "subnet-2" {
+							t.Fatalf("Expected instance to be launched in subnet %q, got %q", "subnet-2", aws.StringValue(input.SubnetId))
+						}
+						if !cmp.Equal(aws.StringValueSlice(input.SecurityGroupIds), []string{"2", "3"}) {
+							t.Fatalf("Expected instance to be launched with the core security groups, got %v", aws.StringValueSlice(input.SecurityGroupIds))
+						}
+						if aws.StringValue(input.KeyName) != "ops" {
+							t.Fatalf("Expected instance to be launched with SSH key %q, got %q", "ops", aws.StringValue(input.KeyName))
+						}
+						return &ec2.Reservation{
+							Instances: []*ec2.Instance{
+								{
+									State: &ec2.InstanceState{
+										Name: aws.String(ec2.InstanceStateNamePending),
+									},
+									InstanceId:   aws.String("two"),
+									InstanceType: input.InstanceType,
+									SubnetId:     input.SubnetId,
+									ImageId:      input.ImageId,
+									Placement: &ec2.Placement{
+										AvailabilityZone: aws.String("us-east-1b"),
+									},
+								},
+							},
+						}, nil
+					})
+				m.WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+			},
+		},
+		{
+			name: "launches from a launch template whose subnet is in the failure domain of the machine",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: pointer.StringPtr("bootstrap-data"),
+					},
+					Version:       pointer.StringPtr("v1.16.1"),
+					FailureDomain: aws.String("us-east-1b"),
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				InstanceType: "m5.large",
+				SSHKeyName:   aws.String("ops"),
+				LaunchTemplate: &infrav1.LaunchTemplateReference{
+					Name: aws.String("hardened"),
+				},
+			},
+			awsCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{
+							infrav1.SubnetSpec{
+								ID:               "subnet-1",
+								AvailabilityZone: "us-east-1a",
+							},
+							infrav1.SubnetSpec{
+								ID:               "subnet-2",
+								AvailabilityZone: "us-east-1b",
+							},
+						},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.ClassicELB{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.
+					DescribeLaunchTemplateVersions(gomock.Any()).
+					Times(2).
+					Return(&ec2.DescribeLaunchTemplateVersionsOutput{
+						LaunchTemplateVersions: []*ec2.LaunchTemplateVersion{
+							{
+								LaunchTemplateData: &ec2.ResponseLaunchTemplateData{
+									ImageId: aws.String("ami-hardened"),
+									NetworkInterfaces: []*ec2.LaunchTemplateInstanceNetworkInterfaceSpecification{
+										{
+											DeviceIndex: aws.Int64(0),
+											SubnetId:    aws.String("subnet-2"),
+										},
+									},
+								},
+							},
+						},
+					}, nil)
+				m.
+					RunInstances(gomock.Any()).
+					DoAndReturn(func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+						if input.SubnetId != nil || len(input.SecurityGroupIds) > 0 {
+							t.Fatalf("Expected the subnet and the security groups to be left to the launch template, got %v and %v",
+								aws.StringValue(input.SubnetId), aws.StringValueSlice(input.SecurityGroupIds))
+						}
+						return &ec2.Reservation{
+							Instances: []*ec2.Instance{
+								{
+									State: &ec2.InstanceState{
+										Name: aws.String(ec2.InstanceStateNamePending),
+									},
+									InstanceId:   aws.String("two"),
+									InstanceType: input.InstanceType,
+									SubnetId:     input.SubnetId,
+									ImageId:      input.ImageId,
+									Placement: &ec2.Placement{
+										AvailabilityZone: aws.String("us-east-1b"),
+									},
+								},
+							},
+						}, nil
+					})
+				m.WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+			},
+		},
+		{
+			name: "fails to launch from a launch template without an AMI",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: pointer.StringPtr("bootstrap-data"),
+					},
+					Version: pointer.StringPtr("v1.16.1"),
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				InstanceType: "m5.large",
+				LaunchTemplate: &infrav1.LaunchTemplateReference{
+					ID:      aws.String("lt-1"),
+					Version: aws.String("3"),
+				},
+			},
+			awsCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{
+							infrav1.SubnetSpec{
+								ID:               "subnet-1",
+								AvailabilityZone: "us-east-1a",
+							},
+							infrav1.SubnetSpec{
+								ID:               "subnet-2",
+								AvailabilityZone: "us-east-1b",
+							},
+						},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.ClassicELB{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.
+					DescribeLaunchTemplateVersions(gomock.Eq(&ec2.DescribeLaunchTemplateVersionsInput{
+						LaunchTemplateId: aws.String("lt-1"),
+						Versions:         aws.StringSlice([]string{"3"}),
+					})).
+					Return(&ec2.DescribeLaunchTemplateVersionsOutput{
+						LaunchTemplateVersions: []*ec2.LaunchTemplateVersion{
+							{
+								LaunchTemplateData: &ec2.ResponseLaunchTemplateData{},
+							},
+						},
+					}, nil)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				if err == nil {
+					t.Fatalf("expected an error")
+				}
+			},
+		},
+		{
+			name: "falls back to the next instance type when there is insufficient capacity",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: pointer.StringPtr("bootstrap-data"),
+					},
+					Version: pointer.StringPtr("v1.16.1"),
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				InstanceType:          "m5.large",
+				InstanceTypeFallbacks: []string{"m5a.large"},
+			},
+			awsCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{
+							infrav1.SubnetSpec{
+								ID:               "subnet-1",
+								AvailabilityZone: "us-east-1a",
+							},
+							infrav1.SubnetSpec{
+								ID:               "subnet-2",
+								AvailabilityZone: "us-east-1b",
+							},
+						},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.ClassicELB{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.
+					DescribeImages(gomock.Any()).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								Name:         aws.String("ami-1"),
+								CreationDate: aws.String("2011-02-08T17:02:31.000Z"),
+							},
+						},
+					}, nil)
+				m.
+					RunInstances(gomock.Any()).
+					Return(nil, awserr.New(awserrors.InsufficientInstanceCapacity, "We currently do not have sufficient capacity in the Availability Zone you requested.", nil))
+				m.
+					RunInstances(gomock.Any()).
+					DoAndReturn(func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+						if aws.StringValue(input.InstanceType) != "m5a.large" || aws.StringValue(input.SubnetId) != "subnet-1" {
+							t.Fatalf("Expected instance of type %q in subnet %q, got %q in %q", "m5a.large", "subnet-1", aws.StringValue(input.InstanceType), aws.StringValue(input.SubnetId))
+						}
+						return &ec2.Reservation{
+							Instances: []*ec2.Instance{
+								{
+									State: &ec2.InstanceState{
+										Name: aws.String(ec2.InstanceStateNamePending),
+									},
+									InstanceId:   aws.String("two"),
+									InstanceType: input.InstanceType,
+									SubnetId:     input.SubnetId,
+									ImageId:      aws.String("ami-1"),
+									Placement: &ec2.Placement{
+										AvailabilityZone: &az,
+									},
+								},
+							},
+						}, nil
+					})
+				m.WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+				if instance.Type != "m5a.large" {
+					t.Fatalf("expected instance of type m5a.large, got %q", instance.Type)
+				}
+			},
+		},
+		{
+			name: "skips fallback instance types that do not support the CPU options of the machine",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: pointer.StringPtr("bootstrap-data"),
+					},
+					Version: pointer.StringPtr("v1.16.1"),
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				InstanceType:          "m5.large",
+				InstanceTypeFallbacks: []string{"c5.large", "m5a.large"},
+				CPUOptions: &infrav1.CPUOptions{
+					CoreCount:      2,
+					ThreadsPerCore: 1,
+				},
+			},
+			awsCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{
+							infrav1.SubnetSpec{
+								ID:               "subnet-1",
+								AvailabilityZone: "us-east-1a",
+							},
+							infrav1.SubnetSpec{
+								ID:               "subnet-2",
+								AvailabilityZone: "us-east-1b",
+							},
+						},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.ClassicELB{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.
+					DescribeImages(gomock.Any()).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								Name:         aws.String("ami-1"),
+								CreationDate: aws.String("2011-02-08T17:02:31.000Z"),
+							},
+						},
+					}, nil)
+				m.
+					DescribeInstanceTypes(&ec2.DescribeInstanceTypesInput{
+						InstanceTypes: aws.StringSlice([]string{"m5.large"}),
+					}).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{
+							{
+								InstanceType: aws.String("m5.large"),
+								VCpuInfo: &ec2.VCpuInfo{
+									ValidCores:          aws.Int64Slice([]int64{1, 2}),
+									ValidThreadsPerCore: aws.Int64Slice([]int64{1, 2}),
+								},
+							},
+						},
+					}, nil)
+				m.
+					DescribeInstanceTypes(&ec2.DescribeInstanceTypesInput{
+						InstanceTypes: aws.StringSlice([]string{"c5.large"}),
+					}).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{
+							{
+								InstanceType: aws.String("c5.large"),
+								VCpuInfo: &ec2.VCpuInfo{
+									ValidCores:          aws.Int64Slice([]int64{1}),
+									ValidThreadsPerCore: aws.Int64Slice([]int64{1, 2}),
+								},
+							},
+						},
+					}, nil)
+				m.
+					DescribeInstanceTypes(&ec2.DescribeInstanceTypesInput{
+						InstanceTypes: aws.StringSlice([]string{"m5a.large"}),
+					}).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{
+							{
+								InstanceType: aws.String("m5a.large"),
+								VCpuInfo: &ec2.VCpuInfo{
+									ValidCores:          aws.Int64Slice([]int64{1, 2}),
+									ValidThreadsPerCore: aws.Int64Slice([]int64{1, 2}),
+								},
+							},
+						},
+					}, nil)
+				m.
+					RunInstances(gomock.Any()).
+					Return(nil, awserr.New(awserrors.InsufficientInstanceCapacity, "We currently do not have sufficient capacity in the Availability Zone you requested.", nil))
+				m.
+					RunInstances(gomock.Any()).
+					DoAndReturn(func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+						if aws.StringValue(input.InstanceType) != "m5a.large" || aws.StringValue(input.SubnetId) != "subnet-1" {
+							t.Fatalf("Expected instance of type %q in subnet %q, got %q in %q", "m5a.large", "subnet-1", aws.StringValue(input.InstanceType), aws.StringValue(input.SubnetId))
+						}
+						return &ec2.Reservation{
+							Instances: []*ec2.Instance{
+								{
+									State: &ec2.InstanceState{
+										Name: aws.String(ec2.InstanceStateNamePending),
+									},
+									InstanceId:   aws.String("two"),
+									InstanceType: input.InstanceType,
+									SubnetId:     input.SubnetId,
+									ImageId:      aws.String("ami-1"),
+									Placement: &ec2.Placement{
+										AvailabilityZone: &az,
+									},
+								},
+							},
+						}, nil
+					})
+				m.WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+				if instance.Type != "m5a.large" {
+					t.Fatalf("expected instance of type m5a.large, got %q", instance.Type)
+				}
+			},
+		},
+		{
+			name: "with a credit specification, reads it back",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: pointer.StringPtr("bootstrap-data"),
+					},
+					Version: pointer.StringPtr("v1.16.1"),
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				InstanceType: "t3.large",
+				CreditSpecification: &infrav1.CreditSpecification{
+					CPUCredits: infrav1.CPUCreditsUnlimited,
+				},
+			},
+			awsCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{
+							infrav1.SubnetSpec{
+								ID:               "subnet-1",
+								AvailabilityZone: "us-east-1a",
+							},
+							infrav1.SubnetSpec{
+								ID:               "subnet-2",
+								AvailabilityZone: "us-east-1b",
+							},
+						},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.ClassicELB{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.
+					DescribeImages(gomock.Any()).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								Name:         aws.String("ami-1"),
+								CreationDate: aws.String("2011-02-08T17:02:31.000Z"),
+							},
+						},
+					}, nil)
+				m.
+					RunInstances(gomock.Any()).
+					DoAndReturn(func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+						if aws.StringValue(input.CreditSpecification.CpuCredits) != "unlimited" {
+							t.Fatalf("Expected the unlimited credit specification, got %v", input.CreditSpecification)
+						}
+						return &ec2.Reservation{
+							Instances: []*ec2.Instance{
+								{
+									State: &ec2.InstanceState{
+										Name: aws.String(ec2.InstanceStateNamePending),
+									},
+									InstanceId:   aws.String("two"),
+									InstanceType: input.InstanceType,
+									SubnetId:     input.SubnetId,
+									ImageId:      aws.String("ami-1"),
+									Placement: &ec2.Placement{
+										AvailabilityZone: &az,
+									},
+								},
+							},
+						}, nil
+					})
+				m.WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+				m.DescribeInstanceCreditSpecifications(gomock.Eq(&ec2.DescribeInstanceCreditSpecificationsInput{
+					InstanceIds: aws.StringSlice([]string{"two"}),
+				})).
+					Return(&ec2.DescribeInstanceCreditSpecificationsOutput{
+						InstanceCreditSpecifications: []*ec2.InstanceCreditSpecification{
+							{InstanceId: aws.String("two"), CpuCredits: aws.String("unlimited")},
+						},
+					}, nil)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+				if instance.CreditSpecification == nil || instance.CreditSpecification.CPUCredits != infrav1.CPUCreditsUnlimited {
+					t.Fatalf("expected the unlimited credit specification, got %+v", instance.CreditSpecification)
+				}
+			},
+		},
+		{
+			name: "with a credit specification, does not fail when it cannot be read back",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: pointer.StringPtr("bootstrap-data"),
+					},
+					Version: pointer.StringPtr("v1.16.1"),
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				InstanceType: "t3.large",
+				CreditSpecification: &infrav1.CreditSpecification{
+					CPUCredits: infrav1.CPUCreditsUnlimited,
+				},
+			},
+			awsCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{
+							infrav1.SubnetSpec{
+								ID:               "subnet-1",
+								AvailabilityZone: "us-east-1a",
+							},
+							infrav1.SubnetSpec{
+								ID:               "subnet-2",
+								AvailabilityZone: "us-east-1b",
+							},
+						},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.ClassicELB{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.
+					DescribeImages(gomock.Any()).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								Name:         aws.String("ami-1"),
+								CreationDate: aws.String("2011-02-08T17:02:31.000Z"),
+							},
+						},
+					}, nil)
+				m.
+					RunInstances(gomock.Any()).
+					DoAndReturn(func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+						if aws.StringValue(input.CreditSpecification.CpuCredits) != "unlimited" {
+							t.Fatalf("Expected the unlimited credit specification, got %v", input.CreditSpecification)
+						}
+						return &ec2.Reservation{
+							Instances: []*ec2.Instance{
+								{
+									State: &ec2.InstanceState{
+										Name: aws.String(ec2.InstanceStateNamePending),
+									},
+									InstanceId:   aws.String("two"),
+									InstanceType: input.InstanceType,
+									SubnetId:     input.SubnetId,
+									ImageId:      aws.String("ami-1"),
+									Placement: &ec2.Placement{
+										AvailabilityZone: &az,
+									},
+								},
+							},
+						}, nil
+					})
+				m.WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+				m.DescribeInstanceCreditSpecifications(gomock.Any()).
+					Return(nil, awserr.New("UnauthorizedOperation", "You are not authorized to perform this operation.", nil))
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+				if instance.CreditSpecification != nil {
+					t.Fatalf("expected no credit specification, got %+v", instance.CreditSpecification)
+				}
+			},
+		},
+		{
+			name: "falls back to the subnet of another failure domain when there is insufficient capacity for all instance types",
 			machine: clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
@@ -2513,8 +4141,9 @@ func TestCreateInstance(t *testing.T) {
 				},
 			},
 			machineConfig: &infrav1.AWSMachineSpec{
-				InstanceType: "m5.large",
-				SSHKeyName:   aws.String("specific-machine-ssh-key-name"),
+				InstanceType:          "m5.large",
+				InstanceTypeFallbacks: []string{"m5a.large"},
+				FailureDomainFallback: true,
 			},
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
@@ -2522,15 +4151,15 @@ func TestCreateInstance(t *testing.T) {
 					NetworkSpec: infrav1.NetworkSpec{
 						Subnets: infrav1.Subnets{
 							infrav1.SubnetSpec{
-								ID:       "subnet-1",
-								IsPublic: false,
+								ID:               "subnet-1",
+								AvailabilityZone: "us-east-1a",
 							},
 							infrav1.SubnetSpec{
-								IsPublic: false,
+								ID:               "subnet-2",
+								AvailabilityZone: "us-east-1b",
 							},
 						},
 					},
-					SSHKeyName: aws.String("specific-cluster-key-name"),
 				},
 				Status: infrav1.AWSClusterStatus{
 					Network: infrav1.NetworkStatus{
@@ -2562,14 +4191,17 @@ func TestCreateInstance(t *testing.T) {
 							},
 						},
 					}, nil)
-				m. // TODO: Restore these parameters, but with the tags as well
+				m.
+					RunInstances(gomock.Any()).
+					Return(nil, awserr.New(awserrors.InsufficientInstanceCapacity, "We currently do not have sufficient capacity in the Availability Zone you requested.", nil))
+				m.
+					RunInstances(gomock.Any()).
+					Return(nil, awserr.New(awserrors.InsufficientInstanceCapacity, "We currently do not have sufficient capacity in the Availability Zone you requested.", nil))
+				m.
 					RunInstances(gomock.Any()).
 					DoAndReturn(func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
-						if input.KeyName == nil {
-							t.Fatal("Expected key name not to be nil")
-						}
-						if *input.KeyName != "specific-machine-ssh-key-name" {
-							t.Fatalf("Expected SSH key name to be '%s', not '%s'", "specific-machine-ssh-key-name", *input.KeyName)
+						if aws.StringValue(input.InstanceType) != "m5.large" || aws.StringValue(input.SubnetId) != "subnet-2" {
+							t.Fatalf("Expected instance of type %q in subnet %q, got %q in %q", "m5.large", "subnet-2", aws.StringValue(input.InstanceType), aws.StringValue(input.SubnetId))
 						}
 						return &ec2.Reservation{
 							Instances: []*ec2.Instance{
@@ -2577,22 +4209,10 @@ func TestCreateInstance(t *testing.T) {
 									State: &ec2.InstanceState{
 										Name: aws.String(ec2.InstanceStateNamePending),
 									},
-									IamInstanceProfile: &ec2.IamInstanceProfile{
-										Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
-									},
-									InstanceId:     aws.String("two"),
-									InstanceType:   aws.String("m5.large"),
-									SubnetId:       aws.String("subnet-1"),
-									ImageId:        aws.String("ami-1"),
-									RootDeviceName: aws.String("device-1"),
-									BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
-										{
-											DeviceName: aws.String("device-1"),
-											Ebs: &ec2.EbsInstanceBlockDevice{
-												VolumeId: aws.String("volume-1"),
-											},
-										},
-									},
+									InstanceId:   aws.String("two"),
+									InstanceType: input.InstanceType,
+									SubnetId:     input.SubnetId,
+									ImageId:      aws.String("ami-1"),
 									Placement: &ec2.Placement{
 										AvailabilityZone: &az,
 									},
@@ -2607,10 +4227,14 @@ func TestCreateInstance(t *testing.T) {
 				if err != nil {
 					t.Fatalf("did not expect error: %v", err)
 				}
+				if instance.SubnetID != "subnet-2" {
+					t.Fatalf("expected instance in subnet-2, got %q", instance.SubnetID)
+				}
 			},
+			expectFailureDomain: aws.String("us-east-1b"),
 		},
 		{
-			name: "expect ssh key to be unset when cluster key name is empty string and machine key name is nil",
+			name: "does not fall back to another failure domain when an additional network interface selects its subnet",
 			machine: clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
@@ -2623,8 +4247,13 @@ func TestCreateInstance(t *testing.T) {
 				},
 			},
 			machineConfig: &infrav1.AWSMachineSpec{
-				InstanceType: "m5.large",
-				SSHKeyName:   nil,
+				InstanceType:          "m5.large",
+				FailureDomainFallback: true,
+				AdditionalNetworkInterfaces: []infrav1.AdditionalNetworkInterface{
+					{
+						SubnetID: "subnet-1",
+					},
+				},
 			},
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
@@ -2632,15 +4261,15 @@ func TestCreateInstance(t *testing.T) {
 					NetworkSpec: infrav1.NetworkSpec{
 						Subnets: infrav1.Subnets{
 							infrav1.SubnetSpec{
-								ID:       "subnet-1",
-								IsPublic: false,
+								ID:               "subnet-1",
+								AvailabilityZone: "us-east-1a",
 							},
 							infrav1.SubnetSpec{
-								IsPublic: false,
+								ID:               "subnet-2",
+								AvailabilityZone: "us-east-1b",
 							},
 						},
 					},
-					SSHKeyName: aws.String(""),
 				},
 				Status: infrav1.AWSClusterStatus{
 					Network: infrav1.NetworkStatus{
@@ -2672,52 +4301,18 @@ func TestCreateInstance(t *testing.T) {
 							},
 						},
 					}, nil)
-				m. // TODO: Restore these parameters, but with the tags as well
+				m.
 					RunInstances(gomock.Any()).
-					DoAndReturn(func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
-						if input.KeyName != nil {
-							t.Fatalf("Expected key name to be nil/unspecified, not '%s'", *input.KeyName)
-						}
-						return &ec2.Reservation{
-							Instances: []*ec2.Instance{
-								{
-									State: &ec2.InstanceState{
-										Name: aws.String(ec2.InstanceStateNamePending),
-									},
-									IamInstanceProfile: &ec2.IamInstanceProfile{
-										Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
-									},
-									InstanceId:     aws.String("two"),
-									InstanceType:   aws.String("m5.large"),
-									SubnetId:       aws.String("subnet-1"),
-									ImageId:        aws.String("ami-1"),
-									RootDeviceName: aws.String("device-1"),
-									BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
-										{
-											DeviceName: aws.String("device-1"),
-											Ebs: &ec2.EbsInstanceBlockDevice{
-												VolumeId: aws.String("volume-1"),
-											},
-										},
-									},
-									Placement: &ec2.Placement{
-										AvailabilityZone: &az,
-									},
-								},
-							},
-						}, nil
-					})
-				m.WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil)
+					Return(nil, awserr.New(awserrors.InsufficientInstanceCapacity, "We currently do not have sufficient capacity in the Availability Zone you requested.", nil))
 			},
 			check: func(instance *infrav1.Instance, err error) {
-				if err != nil {
-					t.Fatalf("did not expect error: %v", err)
+				if !awserrors.IsInsufficientInstanceCapacity(errors.Cause(err)) {
+					t.Fatalf("expected insufficient instance capacity error, got %v", err)
 				}
 			},
 		},
 		{
-			name: "expect ssh key to be unset when cluster key name is empty string and machine key name is empty string",
+			name: "skips fallback instance types that do not support the architecture of the AMI",
 			machine: clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
@@ -2730,8 +4325,11 @@ func TestCreateInstance(t *testing.T) {
 				},
 			},
 			machineConfig: &infrav1.AWSMachineSpec{
-				InstanceType: "m5.large",
-				SSHKeyName:   aws.String(""),
+				AMI: infrav1.AMIReference{
+					ID: aws.String("ami-arm64-fallback"),
+				},
+				InstanceType:          "m6g.medium",
+				InstanceTypeFallbacks: []string{"m5a.large", "m6g.large"},
 			},
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
@@ -2739,15 +4337,15 @@ func TestCreateInstance(t *testing.T) {
 					NetworkSpec: infrav1.NetworkSpec{
 						Subnets: infrav1.Subnets{
 							infrav1.SubnetSpec{
-								ID:       "subnet-1",
-								IsPublic: false,
+								ID:               "subnet-1",
+								AvailabilityZone: "us-east-1a",
 							},
 							infrav1.SubnetSpec{
-								IsPublic: false,
+								ID:               "subnet-2",
+								AvailabilityZone: "us-east-1b",
 							},
 						},
 					},
-					SSHKeyName: aws.String(""),
 				},
 				Status: infrav1.AWSClusterStatus{
 					Network: infrav1.NetworkStatus{
@@ -2774,16 +4372,47 @@ func TestCreateInstance(t *testing.T) {
 					Return(&ec2.DescribeImagesOutput{
 						Images: []*ec2.Image{
 							{
-								Name:         aws.String("ami-1"),
-								CreationDate: aws.String("2011-02-08T17:02:31.000Z"),
+								ImageId:      aws.String("ami-arm64-fallback"),
+								Architecture: aws.String("arm64"),
 							},
 						},
 					}, nil)
-				m. // TODO: Restore these parameters, but with the tags as well
+				m.
+					DescribeInstanceTypes(&ec2.DescribeInstanceTypesInput{
+						InstanceTypes: aws.StringSlice([]string{"m5a.large"}),
+					}).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{
+							{
+								InstanceType: aws.String("m5a.large"),
+								ProcessorInfo: &ec2.ProcessorInfo{
+									SupportedArchitectures: aws.StringSlice([]string{"x86_64"}),
+								},
+							},
+						},
+					}, nil)
+				m.
+					DescribeInstanceTypes(&ec2.DescribeInstanceTypesInput{
+						InstanceTypes: aws.StringSlice([]string{"m6g.large"}),
+					}).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{
+							{
+								InstanceType: aws.String("m6g.large"),
+								ProcessorInfo: &ec2.ProcessorInfo{
+									SupportedArchitectures: aws.StringSlice([]string{"arm64"}),
+								},
+							},
+						},
+					}, nil)
+				m.
+					RunInstances(gomock.Any()).
+					Return(nil, awserr.New(awserrors.InsufficientInstanceCapacity, "We currently do not have sufficient capacity in the Availability Zone you requested.", nil))
+				m.
 					RunInstances(gomock.Any()).
 					DoAndReturn(func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
-						if input.KeyName != nil {
-							t.Fatalf("Expected key name to be nil/unspecified, not '%s'", *input.KeyName)
+						if aws.StringValue(input.InstanceType) != "m6g.large" {
+							t.Fatalf("Expected instance of type %q, got %q", "m6g.large", aws.StringValue(input.InstanceType))
 						}
 						return &ec2.Reservation{
 							Instances: []*ec2.Instance{
@@ -2791,22 +4420,10 @@ func TestCreateInstance(t *testing.T) {
 									State: &ec2.InstanceState{
 										Name: aws.String(ec2.InstanceStateNamePending),
 									},
-									IamInstanceProfile: &ec2.IamInstanceProfile{
-										Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
-									},
-									InstanceId:     aws.String("two"),
-									InstanceType:   aws.String("m5.large"),
-									SubnetId:       aws.String("subnet-1"),
-									ImageId:        aws.String("ami-1"),
-									RootDeviceName: aws.String("device-1"),
-									BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
-										{
-											DeviceName: aws.String("device-1"),
-											Ebs: &ec2.EbsInstanceBlockDevice{
-												VolumeId: aws.String("volume-1"),
-											},
-										},
-									},
+									InstanceId:   aws.String("two"),
+									InstanceType: input.InstanceType,
+									SubnetId:     input.SubnetId,
+									ImageId:      aws.String("ami-arm64-fallback"),
 									Placement: &ec2.Placement{
 										AvailabilityZone: &az,
 									},
@@ -2821,10 +4438,13 @@ func TestCreateInstance(t *testing.T) {
 				if err != nil {
 					t.Fatalf("did not expect error: %v", err)
 				}
+				if instance.Type != "m6g.large" {
+					t.Fatalf("expected instance of type m6g.large, got %q", instance.Type)
+				}
 			},
 		},
 		{
-			name: "expect ssh key to be unset when cluster key name is nil and machine key name is empty string",
+			name: "fails once there is insufficient capacity for all instance types",
 			machine: clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
@@ -2837,8 +4457,8 @@ func TestCreateInstance(t *testing.T) {
 				},
 			},
 			machineConfig: &infrav1.AWSMachineSpec{
-				InstanceType: "m5.large",
-				SSHKeyName:   aws.String(""),
+				InstanceType:          "m5.large",
+				InstanceTypeFallbacks: []string{"m5a.large"},
 			},
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
@@ -2846,15 +4466,15 @@ func TestCreateInstance(t *testing.T) {
 					NetworkSpec: infrav1.NetworkSpec{
 						Subnets: infrav1.Subnets{
 							infrav1.SubnetSpec{
-								ID:       "subnet-1",
-								IsPublic: false,
+								ID:               "subnet-1",
+								AvailabilityZone: "us-east-1a",
 							},
 							infrav1.SubnetSpec{
-								IsPublic: false,
+								ID:               "subnet-2",
+								AvailabilityZone: "us-east-1b",
 							},
 						},
 					},
-					SSHKeyName: nil,
 				},
 				Status: infrav1.AWSClusterStatus{
 					Network: infrav1.NetworkStatus{
@@ -2886,49 +4506,22 @@ func TestCreateInstance(t *testing.T) {
 							},
 						},
 					}, nil)
-				m. // TODO: Restore these parameters, but with the tags as well
+				m.
 					RunInstances(gomock.Any()).
-					DoAndReturn(func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
-						if input.KeyName != nil {
-							t.Fatalf("Expected key name to be nil/unspecified, not '%s'", *input.KeyName)
-						}
-						return &ec2.Reservation{
-							Instances: []*ec2.Instance{
-								{
-									State: &ec2.InstanceState{
-										Name: aws.String(ec2.InstanceStateNamePending),
-									},
-									IamInstanceProfile: &ec2.IamInstanceProfile{
-										Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
-									},
-									InstanceId:     aws.String("two"),
-									InstanceType:   aws.String("m5.large"),
-									SubnetId:       aws.String("subnet-1"),
-									ImageId:        aws.String("ami-1"),
-									RootDeviceName: aws.String("device-1"),
-									BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
-										{
-											DeviceName: aws.String("device-1"),
-											Ebs: &ec2.EbsInstanceBlockDevice{
-												VolumeId: aws.String("volume-1"),
-											},
-										},
-									},
-									Placement: &ec2.Placement{
-										AvailabilityZone: &az,
-									},
-								},
-							},
-						}, nil
-					})
-				m.WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil)
+					Return(nil, awserr.New(awserrors.InsufficientInstanceCapacity, "We currently do not have sufficient capacity in the Availability Zone you requested.", nil))
+				m.
+					RunInstances(gomock.Any()).
+					Return(nil, awserr.New(awserrors.InsufficientInstanceCapacity, "We currently do not have sufficient capacity in the Availability Zone you requested.", nil))
 			},
 			check: func(instance *infrav1.Instance, err error) {
-				if err != nil {
-					t.Fatalf("did not expect error: %v", err)
+				if !awserrors.IsInsufficientInstanceCapacity(errors.Cause(err)) {
+					t.Fatalf("expected insufficient instance capacity error, got %v", err)
 				}
 			},
+			expectFailureReason: func() *capierrors.MachineStatusError {
+				reason := capierrors.InsufficientResourcesMachineError
+				return &reason
+			}(),
 		},
 	}
 
@@ -3002,6 +4595,12 @@ func TestCreateInstance(t *testing.T) {
 
 			instance, err := s.CreateInstance(machineScope, data, "")
 			tc.check(instance, err)
+			if tc.expectFailureDomain != nil && aws.StringValue(machineScope.AWSMachine.Spec.FailureDomain) != *tc.expectFailureDomain {
+				t.Fatalf("expected failure domain %q, got %q", *tc.expectFailureDomain, aws.StringValue(machineScope.AWSMachine.Spec.FailureDomain))
+			}
+			if tc.expectFailureReason != nil && (machineScope.AWSMachine.Status.FailureReason == nil || *machineScope.AWSMachine.Status.FailureReason != *tc.expectFailureReason) {
+				t.Fatalf("expected failure reason %q, got %v", *tc.expectFailureReason, machineScope.AWSMachine.Status.FailureReason)
+			}
 		})
 	}
 }
@@ -3067,6 +4666,118 @@ func TestGetInstanceMarketOptionsRequest(t *testing.T) {
 	}
 }
 
+func TestVolumeToBlockDeviceMapping(t *testing.T) {
+	testCases := []struct {
+		name     string
+		volume   *infrav1.Volume
+		expected *ec2.BlockDeviceMapping
+	}{
+		{
+			name:   "with an EBS volume",
+			volume: &infrav1.Volume{DeviceName: "/dev/sdb", Size: 50, Type: infrav1.VolumeTypeGP3},
+			expected: &ec2.BlockDeviceMapping{
+				DeviceName: aws.String("/dev/sdb"),
+				Ebs: &ec2.EbsBlockDevice{
+					DeleteOnTermination: aws.Bool(true),
+					VolumeSize:          aws.Int64(50),
+					VolumeType:          aws.String("gp3"),
+				},
+			},
+		},
+		{
+			name:   "with an EBS volume created from a snapshot",
+			volume: &infrav1.Volume{DeviceName: "/dev/sdb", SnapshotID: aws.String("snap-1")},
+			expected: &ec2.BlockDeviceMapping{
+				DeviceName: aws.String("/dev/sdb"),
+				Ebs: &ec2.EbsBlockDevice{
+					DeleteOnTermination: aws.Bool(true),
+					SnapshotId:          aws.String("snap-1"),
+				},
+			},
+		},
+		{
+			name:   "with an instance store volume",
+			volume: &infrav1.Volume{DeviceName: "/dev/sdc", VirtualName: "ephemeral0"},
+			expected: &ec2.BlockDeviceMapping{
+				DeviceName:  aws.String("/dev/sdc"),
+				VirtualName: aws.String("ephemeral0"),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mapping := volumeToBlockDeviceMapping(tc.volume)
+			if !cmp.Equal(mapping, tc.expected) {
+				t.Errorf("Case: %s. Got: %v, expected: %v", tc.name, mapping, tc.expected)
+			}
+		})
+	}
+}
+
+func TestGetInstanceStoreVolumes(t *testing.T) {
+	volumes := getInstanceStoreVolumes([]*ec2.BlockDeviceMapping{
+		{DeviceName: aws.String("/dev/sda1"), Ebs: &ec2.EbsBlockDevice{VolumeSize: aws.Int64(20)}},
+		{DeviceName: aws.String("/dev/sdb"), VirtualName: aws.String("ephemeral0")},
+	})
+	expected := []infrav1.InstanceStoreVolume{{DeviceName: "/dev/sdb", VirtualName: "ephemeral0"}}
+	if !cmp.Equal(volumes, expected) {
+		t.Errorf("Got: %v, expected: %v", volumes, expected)
+	}
+}
+
+func TestResourceNameHostname(t *testing.T) {
+	primaryENI := &ec2.InstanceNetworkInterface{
+		Attachment:     &ec2.InstanceNetworkInterfaceAttachment{DeviceIndex: aws.Int64(0)},
+		PrivateDnsName: aws.String("ip-10-0-0-1.us-east-1.compute.internal"),
+	}
+	testCases := []struct {
+		name         string
+		hostnameType *string
+		eni          *ec2.InstanceNetworkInterface
+		expected     string
+	}{
+		{
+			name:     "with no hostname type specified",
+			eni:      primaryENI,
+			expected: "",
+		},
+		{
+			name:         "with the ip-name hostname type",
+			hostnameType: aws.String(infrav1.HostnameTypeIPName),
+			eni:          primaryENI,
+			expected:     "",
+		},
+		{
+			name:         "with the resource-name hostname type",
+			hostnameType: aws.String(infrav1.HostnameTypeResourceName),
+			eni:          primaryENI,
+			expected:     "i-1.us-east-1.compute.internal",
+		},
+		{
+			name:         "with the resource-name hostname type on a secondary network interface",
+			hostnameType: aws.String(infrav1.HostnameTypeResourceName),
+			eni: &ec2.InstanceNetworkInterface{
+				Attachment:     &ec2.InstanceNetworkInterfaceAttachment{DeviceIndex: aws.Int64(1)},
+				PrivateDnsName: aws.String("ip-10-0-0-2.us-east-1.compute.internal"),
+			},
+			expected: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			instance := &ec2.Instance{
+				InstanceId:            aws.String("i-1"),
+				PrivateDnsNameOptions: &ec2.PrivateDnsNameOptionsResponse{HostnameType: tc.hostnameType},
+			}
+			if hostname := resourceNameHostname(instance, tc.eni); hostname != tc.expected {
+				t.Errorf("Case: %s. Got: %v, expected: %v", tc.name, hostname, tc.expected)
+			}
+		})
+	}
+}
+
 func TestGetFilteredSecurityGroupID(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
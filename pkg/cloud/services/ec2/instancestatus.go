@@ -0,0 +1,112 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// ReconcileInstanceStatus reports the results of the EC2 status checks of the instance as conditions of the
+// AWSMachine, and records the maintenance events scheduled for the instance in the AWSMachine status.
+func (s *Service) ReconcileInstanceStatus(scope *scope.MachineScope, instanceID string) error {
+	out, err := s.EC2Client.DescribeInstanceStatus(&ec2.DescribeInstanceStatusInput{
+		InstanceIds: aws.StringSlice([]string{instanceID}),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to describe status of instance %q", instanceID)
+	}
+	if len(out.InstanceStatuses) == 0 {
+		// The status is only reported for running instances.
+		return nil
+	}
+	status := out.InstanceStatuses[0]
+
+	setStatusCheckCondition(scope.AWSMachine, infrav1.InstanceStatusCheckPassedCondition, infrav1.InstanceStatusCheckFailedReason, status.InstanceStatus)
+	setStatusCheckCondition(scope.AWSMachine, infrav1.SystemStatusCheckPassedCondition, infrav1.SystemStatusCheckFailedReason, status.SystemStatus)
+
+	known := make(map[string]bool, len(scope.AWSMachine.Status.ScheduledEvents))
+	for _, event := range scope.AWSMachine.Status.ScheduledEvents {
+		known[event.ID] = true
+	}
+
+	var events []infrav1.InstanceScheduledEvent
+	for _, event := range status.Events {
+		description := aws.StringValue(event.Description)
+		// Completed and canceled events are still reported for a while, with their description prefixed accordingly.
+		if strings.HasPrefix(description, "[Completed]") || strings.HasPrefix(description, "[Canceled]") {
+			continue
+		}
+
+		scheduledEvent := infrav1.InstanceScheduledEvent{
+			ID:                aws.StringValue(event.InstanceEventId),
+			Code:              aws.StringValue(event.Code),
+			Description:       description,
+			NotBefore:         toMetaTime(event.NotBefore),
+			NotAfter:          toMetaTime(event.NotAfter),
+			NotBeforeDeadline: toMetaTime(event.NotBeforeDeadline),
+		}
+		if !known[scheduledEvent.ID] {
+			record.Warnf(scope.AWSMachine, "InstanceEventScheduled", "AWS scheduled %s of instance %q not before %s: %s",
+				scheduledEvent.Code, instanceID, aws.TimeValue(event.NotBefore).Format(time.RFC3339), description)
+		}
+		events = append(events, scheduledEvent)
+	}
+	scope.AWSMachine.Status.ScheduledEvents = events
+
+	return nil
+}
+
+// setStatusCheckCondition sets the condition reporting a status check from its summary. Initializing,
+// insufficient-data and not-applicable statuses are not conclusive and leave the condition unchanged.
+func setStatusCheckCondition(awsMachine *infrav1.AWSMachine, condition clusterv1.ConditionType, failedReason string, summary *ec2.InstanceStatusSummary) {
+	if summary == nil {
+		return
+	}
+
+	switch aws.StringValue(summary.Status) {
+	case ec2.SummaryStatusOk:
+		conditions.MarkTrue(awsMachine, condition)
+	case ec2.SummaryStatusImpaired:
+		failed := []string{}
+		for _, detail := range summary.Details {
+			if aws.StringValue(detail.Status) == ec2.StatusTypeFailed {
+				failed = append(failed, aws.StringValue(detail.Name))
+			}
+		}
+		conditions.MarkFalse(awsMachine, condition, failedReason, clusterv1.ConditionSeverityError, "failed checks: %s", strings.Join(failed, ", "))
+	}
+}
+
+func toMetaTime(t *time.Time) *metav1.Time {
+	if t == nil {
+		return nil
+	}
+	mt := metav1.NewTime(*t)
+	return &mt
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestReconcileInstanceStatus(t *testing.T) {
+	notBefore := time.Date(2022, 10, 1, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name                    string
+		status                  *ec2.InstanceStatus
+		wantInstanceCheckStatus corev1.ConditionStatus
+		wantSystemCheckStatus   corev1.ConditionStatus
+		wantSystemCheckReason   string
+		wantEventIDs            []string
+	}{
+		{
+			name:                    "status checks passed",
+			status:                  newInstanceStatus(ec2.SummaryStatusOk, ec2.SummaryStatusOk),
+			wantInstanceCheckStatus: corev1.ConditionTrue,
+			wantSystemCheckStatus:   corev1.ConditionTrue,
+		},
+		{
+			name:                    "system status check failed",
+			status:                  newInstanceStatus(ec2.SummaryStatusOk, ec2.SummaryStatusImpaired),
+			wantInstanceCheckStatus: corev1.ConditionTrue,
+			wantSystemCheckStatus:   corev1.ConditionFalse,
+			wantSystemCheckReason:   infrav1.SystemStatusCheckFailedReason,
+		},
+		{
+			name:   "status checks initializing",
+			status: newInstanceStatus(ec2.SummaryStatusInitializing, ec2.SummaryStatusInitializing),
+		},
+		{
+			name: "scheduled events are recorded, completed ones are skipped",
+			status: func() *ec2.InstanceStatus {
+				status := newInstanceStatus(ec2.SummaryStatusOk, ec2.SummaryStatusOk)
+				status.Events = []*ec2.InstanceStatusEvent{
+					{
+						InstanceEventId: aws.String("instance-event-1"),
+						Code:            aws.String(ec2.EventCodeSystemReboot),
+						Description:     aws.String("scheduled reboot"),
+						NotBefore:       aws.Time(notBefore),
+					},
+					{
+						InstanceEventId: aws.String("instance-event-2"),
+						Code:            aws.String(ec2.EventCodeInstanceRetirement),
+						Description:     aws.String("[Completed] The instance is running on degraded hardware"),
+						NotBefore:       aws.Time(notBefore),
+					},
+				}
+				return status
+			}(),
+			wantInstanceCheckStatus: corev1.ConditionTrue,
+			wantSystemCheckStatus:   corev1.ConditionTrue,
+			wantEventIDs:            []string{"instance-event-1"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			cs, err := setupClusterScope(client)
+			g.Expect(err).NotTo(HaveOccurred())
+			machineScope, err := setupMachineScope(client, cs)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			ec2Mock.EXPECT().DescribeInstanceStatus(gomock.Eq(&ec2.DescribeInstanceStatusInput{
+				InstanceIds: aws.StringSlice([]string{"i-1"}),
+			})).Return(&ec2.DescribeInstanceStatusOutput{InstanceStatuses: []*ec2.InstanceStatus{tc.status}}, nil)
+			s := NewService(machineScope.InfraCluster)
+			s.EC2Client = ec2Mock
+
+			g.Expect(s.ReconcileInstanceStatus(machineScope, "i-1")).To(Succeed())
+
+			instanceCheck := conditions.Get(machineScope.AWSMachine, infrav1.InstanceStatusCheckPassedCondition)
+			systemCheck := conditions.Get(machineScope.AWSMachine, infrav1.SystemStatusCheckPassedCondition)
+			if tc.wantInstanceCheckStatus == "" {
+				g.Expect(instanceCheck).To(BeNil())
+			} else {
+				g.Expect(instanceCheck.Status).To(Equal(tc.wantInstanceCheckStatus))
+			}
+			if tc.wantSystemCheckStatus == "" {
+				g.Expect(systemCheck).To(BeNil())
+			} else {
+				g.Expect(systemCheck.Status).To(Equal(tc.wantSystemCheckStatus))
+				g.Expect(systemCheck.Reason).To(Equal(tc.wantSystemCheckReason))
+			}
+
+			eventIDs := []string{}
+			for _, event := range machineScope.AWSMachine.Status.ScheduledEvents {
+				eventIDs = append(eventIDs, event.ID)
+			}
+			if tc.wantEventIDs == nil {
+				g.Expect(eventIDs).To(BeEmpty())
+			} else {
+				g.Expect(eventIDs).To(Equal(tc.wantEventIDs))
+			}
+		})
+	}
+}
+
+func newInstanceStatus(instanceStatus, systemStatus string) *ec2.InstanceStatus {
+	summary := func(status string) *ec2.InstanceStatusSummary {
+		reachability := ec2.StatusTypePassed
+		if status == ec2.SummaryStatusImpaired {
+			reachability = ec2.StatusTypeFailed
+		}
+		return &ec2.InstanceStatusSummary{
+			Status:  aws.String(status),
+			Details: []*ec2.InstanceStatusDetails{{Name: aws.String(ec2.StatusNameReachability), Status: aws.String(reachability)}},
+		}
+	}
+	return &ec2.InstanceStatus{
+		InstanceId:     aws.String("i-1"),
+		InstanceStatus: summary(instanceStatus),
+		SystemStatus:   summary(systemStatus),
+	}
+}
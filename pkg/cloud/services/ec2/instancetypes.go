@@ -0,0 +1,93 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+)
+
+// describeInstanceType returns the description of the instance type.
+func (s *Service) describeInstanceType(instanceType string) (*ec2.InstanceTypeInfo, error) {
+	out, err := s.EC2Client.DescribeInstanceTypes(&ec2.DescribeInstanceTypesInput{
+		InstanceTypes: aws.StringSlice([]string{instanceType}),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe instance type %q", instanceType)
+	}
+	if len(out.InstanceTypes) == 0 {
+		return nil, errors.Errorf("instance type %q not found", instanceType)
+	}
+
+	return out.InstanceTypes[0], nil
+}
+
+// validateCPUOptions checks that the instance type supports the core count and the threads per core of the
+// CPU options, which EC2 would otherwise only reject when launching the instance.
+func (s *Service) validateCPUOptions(instanceType string, cpuOptions *infrav1.CPUOptions) error {
+	if cpuOptions == nil || instanceType == "" {
+		return nil
+	}
+
+	info, err := s.describeInstanceType(instanceType)
+	if err != nil {
+		return err
+	}
+
+	return checkCPUOptions(info, instanceType, cpuOptions)
+}
+
+// checkCPUOptions checks that the described instance type supports the core count and the threads per core of the
+// CPU options.
+func checkCPUOptions(info *ec2.InstanceTypeInfo, instanceType string, cpuOptions *infrav1.CPUOptions) error {
+	if cpuOptions == nil || info.VCpuInfo == nil {
+		return nil
+	}
+
+	vCPUInfo := info.VCpuInfo
+	if len(vCPUInfo.ValidCores) > 0 && !containsInt64(vCPUInfo.ValidCores, cpuOptions.CoreCount) {
+		return errors.Errorf("instance type %q does not support %d CPU cores, valid core counts are %v",
+			instanceType, cpuOptions.CoreCount, aws.Int64ValueSlice(vCPUInfo.ValidCores))
+	}
+	if len(vCPUInfo.ValidThreadsPerCore) > 0 && !containsInt64(vCPUInfo.ValidThreadsPerCore, cpuOptions.ThreadsPerCore) {
+		return errors.Errorf("instance type %q does not support %d threads per core, valid threads per core are %v",
+			instanceType, cpuOptions.ThreadsPerCore, aws.Int64ValueSlice(vCPUInfo.ValidThreadsPerCore))
+	}
+
+	return nil
+}
+
+func containsInt64(values []*int64, value int64) bool {
+	for _, v := range values {
+		if aws.Int64Value(v) == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(values []*string, value string) bool {
+	for _, v := range values {
+		if aws.StringValue(v) == value {
+			return true
+		}
+	}
+	return false
+}
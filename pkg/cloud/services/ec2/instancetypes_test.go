@@ -0,0 +1,124 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+)
+
+func TestValidateCPUOptions(t *testing.T) {
+	m5Large := &ec2.DescribeInstanceTypesOutput{
+		InstanceTypes: []*ec2.InstanceTypeInfo{
+			{
+				InstanceType: aws.String("m5.large"),
+				VCpuInfo: &ec2.VCpuInfo{
+					ValidCores:          aws.Int64Slice([]int64{1}),
+					ValidThreadsPerCore: aws.Int64Slice([]int64{1, 2}),
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		cpuOptions *infrav1.CPUOptions
+		expect     func(m *mocks.MockEC2APIMockRecorder)
+		wantErr    bool
+	}{
+		{
+			name: "should not describe the instance type without CPU options",
+		},
+		{
+			name:       "should accept CPU options supported by the instance type",
+			cpuOptions: &infrav1.CPUOptions{CoreCount: 1, ThreadsPerCore: 1},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeInstanceTypes(gomock.Eq(&ec2.DescribeInstanceTypesInput{
+					InstanceTypes: aws.StringSlice([]string{"m5.large"}),
+				})).Return(m5Large, nil)
+			},
+		},
+		{
+			name:       "should reject a core count not supported by the instance type",
+			cpuOptions: &infrav1.CPUOptions{CoreCount: 2, ThreadsPerCore: 1},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeInstanceTypes(gomock.Any()).Return(m5Large, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:       "should reject threads per core not supported by the instance type",
+			cpuOptions: &infrav1.CPUOptions{CoreCount: 1, ThreadsPerCore: 2},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeInstanceTypes(gomock.Any()).Return(&ec2.DescribeInstanceTypesOutput{
+					InstanceTypes: []*ec2.InstanceTypeInfo{
+						{
+							InstanceType: aws.String("m5.large"),
+							VCpuInfo: &ec2.VCpuInfo{
+								ValidCores:          aws.Int64Slice([]int64{1}),
+								ValidThreadsPerCore: aws.Int64Slice([]int64{1}),
+							},
+						},
+					},
+				}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:       "should fail when the instance type can't be described",
+			cpuOptions: &infrav1.CPUOptions{CoreCount: 1, ThreadsPerCore: 1},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeInstanceTypes(gomock.Any()).Return(nil, errors.New("throttled"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			if tc.expect != nil {
+				tc.expect(ec2Mock.EXPECT())
+			}
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			cs, err := setupClusterScope(fake.NewClientBuilder().WithScheme(scheme).Build())
+			g.Expect(err).NotTo(HaveOccurred())
+			s := NewService(cs)
+			s.EC2Client = ec2Mock
+
+			err = s.validateCPUOptions("m5.large", tc.cpuOptions)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
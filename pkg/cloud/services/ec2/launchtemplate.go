@@ -494,6 +494,34 @@ func (s *Service) createLaunchTemplateData(scope scope.LaunchTemplateScope, imag
 
 	data.InstanceMarketOptions = getLaunchTemplateInstanceMarketOptionsRequest(scope.GetLaunchTemplate().SpotMarketOptions)
 
+	data.MetadataOptions = getLaunchTemplateInstanceMetadataOptionsRequest(lt.InstanceMetadataOptions)
+
+	data.CapacityReservationSpecification = getLaunchTemplateCapacityReservationSpecificationRequest(lt.CapacityReservationSpecification)
+
+	if lt.CPUOptions != nil {
+		if err := s.validateCPUOptions(lt.InstanceType, lt.CPUOptions); err != nil {
+			return nil, err
+		}
+		data.CpuOptions = &ec2.LaunchTemplateCpuOptionsRequest{
+			CoreCount:      aws.Int64(lt.CPUOptions.CoreCount),
+			ThreadsPerCore: aws.Int64(lt.CPUOptions.ThreadsPerCore),
+		}
+	}
+
+	if lt.CreditSpecification != nil {
+		data.CreditSpecification = &ec2.CreditSpecificationRequest{
+			CpuCredits: aws.String(string(lt.CreditSpecification.CPUCredits)),
+		}
+	}
+
+	if lt.PrivateDNSNameOptions != nil {
+		data.PrivateDnsNameOptions = &ec2.LaunchTemplatePrivateDnsNameOptionsRequest{
+			HostnameType:                    lt.PrivateDNSNameOptions.HostnameType,
+			EnableResourceNameDnsARecord:    lt.PrivateDNSNameOptions.EnableResourceNameDNSARecord,
+			EnableResourceNameDnsAAAARecord: lt.PrivateDNSNameOptions.EnableResourceNameDNSAAAARecord,
+		}
+	}
+
 	// Set up root volume
 	if lt.RootVolume != nil {
 		rootDeviceName, err := s.checkRootVolume(lt.RootVolume, *data.ImageId)
@@ -509,16 +537,33 @@ func (s *Service) createLaunchTemplateData(scope scope.LaunchTemplateScope, imag
 		}
 	}
 
+	// Set up non root volumes, including instance store volumes
+	for i := range lt.NonRootVolumes {
+		data.BlockDeviceMappings = append(data.BlockDeviceMappings, volumeToLaunchTemplateBlockDeviceMappingRequest(&lt.NonRootVolumes[i]))
+	}
+
 	data.TagSpecifications = s.buildLaunchTemplateTagSpecificationRequest(scope)
 
 	return data, nil
 }
 
 func volumeToLaunchTemplateBlockDeviceMappingRequest(v *infrav1.Volume) *ec2.LaunchTemplateBlockDeviceMappingRequest {
+	if v.IsInstanceStore() {
+		return &ec2.LaunchTemplateBlockDeviceMappingRequest{
+			DeviceName:  aws.String(v.DeviceName),
+			VirtualName: aws.String(v.VirtualName),
+		}
+	}
+
 	ltEbsDevice := &ec2.LaunchTemplateEbsBlockDeviceRequest{
 		DeleteOnTermination: aws.Bool(true),
-		VolumeSize:          aws.Int64(v.Size),
 		Encrypted:           v.Encrypted,
+		SnapshotId:          v.SnapshotID,
+	}
+
+	// Volumes created from a snapshot default to the size of the snapshot.
+	if v.Size != 0 {
+		ltEbsDevice.VolumeSize = aws.Int64(v.Size)
 	}
 
 	if v.Throughput != nil {
@@ -544,6 +589,73 @@ func volumeToLaunchTemplateBlockDeviceMappingRequest(v *infrav1.Volume) *ec2.Lau
 	}
 }
 
+func launchTemplateBlockDeviceMappingToVolume(mapping *ec2.LaunchTemplateBlockDeviceMapping) infrav1.Volume {
+	volume := infrav1.Volume{
+		DeviceName:  aws.StringValue(mapping.DeviceName),
+		VirtualName: aws.StringValue(mapping.VirtualName),
+	}
+	if ebs := mapping.Ebs; ebs != nil {
+		volume.Size = aws.Int64Value(ebs.VolumeSize)
+		volume.Type = infrav1.VolumeType(aws.StringValue(ebs.VolumeType))
+		volume.IOPS = aws.Int64Value(ebs.Iops)
+		volume.Throughput = ebs.Throughput
+		volume.Encrypted = ebs.Encrypted
+		volume.EncryptionKey = aws.StringValue(ebs.KmsKeyId)
+		volume.SnapshotID = ebs.SnapshotId
+	}
+
+	return volume
+}
+
+// launchTemplateVolumesEqual reports whether the non-root volumes of the incoming launch template match the block
+// device mappings of the existing one. The existing volumes also contain the root volume, whose device name is only
+// known from the AMI, so a single mapping that matches no incoming volume is expected when a root volume is set.
+func launchTemplateVolumesEqual(incoming, existing *expinfrav1.AWSLaunchTemplate) bool {
+	unmatched := map[string]infrav1.Volume{}
+	for _, v := range existing.NonRootVolumes {
+		unmatched[v.DeviceName] = v
+	}
+
+	for i := range incoming.NonRootVolumes {
+		// Compare what the launch template stores for the volume, rather than the volume spec itself.
+		want := launchTemplateBlockDeviceMappingToVolume(launchTemplateBlockDeviceMappingFromRequest(
+			volumeToLaunchTemplateBlockDeviceMappingRequest(&incoming.NonRootVolumes[i])))
+		got, ok := unmatched[want.DeviceName]
+		if !ok || !cmp.Equal(want, got) {
+			return false
+		}
+		delete(unmatched, want.DeviceName)
+	}
+
+	expectedUnmatched := 0
+	if incoming.RootVolume != nil {
+		expectedUnmatched = 1
+	}
+
+	return len(unmatched) == expectedUnmatched
+}
+
+func launchTemplateBlockDeviceMappingFromRequest(req *ec2.LaunchTemplateBlockDeviceMappingRequest) *ec2.LaunchTemplateBlockDeviceMapping {
+	mapping := &ec2.LaunchTemplateBlockDeviceMapping{
+		DeviceName:  req.DeviceName,
+		VirtualName: req.VirtualName,
+	}
+	if ebs := req.Ebs; ebs != nil {
+		mapping.Ebs = &ec2.LaunchTemplateEbsBlockDevice{
+			DeleteOnTermination: ebs.DeleteOnTermination,
+			Encrypted:           ebs.Encrypted,
+			Iops:                ebs.Iops,
+			KmsKeyId:            ebs.KmsKeyId,
+			SnapshotId:          ebs.SnapshotId,
+			Throughput:          ebs.Throughput,
+			VolumeSize:          ebs.VolumeSize,
+			VolumeType:          ebs.VolumeType,
+		}
+	}
+
+	return mapping
+}
+
 // DeleteLaunchTemplate delete a launch template.
 func (s *Service) DeleteLaunchTemplate(id string) error {
 	s.scope.Debug("Deleting launch template", "id", id)
@@ -614,6 +726,35 @@ func (s *Service) GetLaunchTemplateLatestVersion(id string) (string, error) {
 	return strconv.Itoa(int(*out.LaunchTemplateVersions[0].VersionNumber)), nil
 }
 
+// RollbackLaunchTemplateVersion deletes the latest version of a launch template, so that $Latest
+// refers to the previous version again, and returns the version rolled back to.
+func (s *Service) RollbackLaunchTemplateVersion(id string) (string, error) {
+	input := &ec2.DescribeLaunchTemplateVersionsInput{
+		LaunchTemplateId: aws.String(id),
+		Versions:         aws.StringSlice([]string{expinfrav1.LaunchTemplateLatestVersion}),
+	}
+
+	out, err := s.EC2Client.DescribeLaunchTemplateVersions(input)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get latest launch template version %q", id)
+	}
+
+	if len(out.LaunchTemplateVersions) == 0 {
+		return "", errors.Errorf("failed to get latest launch template version %q", id)
+	}
+
+	latest := out.LaunchTemplateVersions[0]
+	if aws.BoolValue(latest.DefaultVersion) {
+		return "", errors.Errorf("cannot roll back launch template %q, its latest version is the default version", id)
+	}
+
+	if err := s.deleteLaunchTemplateVersion(id, latest.VersionNumber); err != nil {
+		return "", errors.Wrapf(err, "failed to delete launch template %q version %d", id, aws.Int64Value(latest.VersionNumber))
+	}
+
+	return s.GetLaunchTemplateLatestVersion(id)
+}
+
 func (s *Service) deleteLaunchTemplateVersion(id string, version *int64) error {
 	s.scope.Debug("Deleting launch template version", "id", id)
 
@@ -661,6 +802,54 @@ func (s *Service) SDKToLaunchTemplate(d *ec2.LaunchTemplateVersion) (*expinfrav1
 		}
 	}
 
+	if v.MetadataOptions != nil {
+		i.InstanceMetadataOptions = &infrav1.InstanceMetadataOptions{
+			HTTPEndpoint:            infrav1.InstanceMetadataState(aws.StringValue(v.MetadataOptions.HttpEndpoint)),
+			HTTPPutResponseHopLimit: aws.Int64Value(v.MetadataOptions.HttpPutResponseHopLimit),
+			HTTPTokens:              infrav1.HTTPTokensState(aws.StringValue(v.MetadataOptions.HttpTokens)),
+			InstanceMetadataTags:    infrav1.InstanceMetadataState(aws.StringValue(v.MetadataOptions.InstanceMetadataTags)),
+		}
+	}
+
+	if v.CapacityReservationSpecification != nil {
+		i.CapacityReservationSpecification = &infrav1.CapacityReservationSpecification{
+			CapacityReservationPreference: infrav1.CapacityReservationPreference(aws.StringValue(v.CapacityReservationSpecification.CapacityReservationPreference)),
+		}
+		if target := v.CapacityReservationSpecification.CapacityReservationTarget; target != nil {
+			i.CapacityReservationSpecification.CapacityReservationTarget = &infrav1.CapacityReservationTarget{
+				CapacityReservationID:               target.CapacityReservationId,
+				CapacityReservationResourceGroupArn: target.CapacityReservationResourceGroupArn,
+			}
+		}
+	}
+
+	if v.CpuOptions != nil {
+		i.CPUOptions = &infrav1.CPUOptions{
+			CoreCount:      aws.Int64Value(v.CpuOptions.CoreCount),
+			ThreadsPerCore: aws.Int64Value(v.CpuOptions.ThreadsPerCore),
+		}
+	}
+
+	if v.CreditSpecification != nil {
+		i.CreditSpecification = &infrav1.CreditSpecification{
+			CPUCredits: infrav1.CPUCredits(aws.StringValue(v.CreditSpecification.CpuCredits)),
+		}
+	}
+
+	if v.PrivateDnsNameOptions != nil {
+		i.PrivateDNSNameOptions = &infrav1.PrivateDNSNameOptions{
+			HostnameType:                    v.PrivateDnsNameOptions.HostnameType,
+			EnableResourceNameDNSARecord:    v.PrivateDnsNameOptions.EnableResourceNameDnsARecord,
+			EnableResourceNameDNSAAAARecord: v.PrivateDnsNameOptions.EnableResourceNameDnsAAAARecord,
+		}
+	}
+
+	for _, mapping := range v.BlockDeviceMappings {
+		// This includes the root volume as well, since the launch template data doesn't tell it apart from the
+		// other mappings. LaunchTemplateNeedsUpdate accounts for it when comparing with the incoming volumes.
+		i.NonRootVolumes = append(i.NonRootVolumes, launchTemplateBlockDeviceMappingToVolume(mapping))
+	}
+
 	for _, id := range v.SecurityGroupIds {
 		// FIXME(dlipovetsky): This will include the core security groups as well, making the
 		// "Additional" a bit dishonest. However, including the core groups drastically simplifies
@@ -692,6 +881,30 @@ func (s *Service) LaunchTemplateNeedsUpdate(scope scope.LaunchTemplateScope, inc
 		return true, nil
 	}
 
+	if !instanceMetadataOptionsEqual(incoming.InstanceMetadataOptions, existing.InstanceMetadataOptions) {
+		return true, nil
+	}
+
+	if !cmp.Equal(incoming.CapacityReservationSpecification, existing.CapacityReservationSpecification) {
+		return true, nil
+	}
+
+	if !cmp.Equal(incoming.CPUOptions, existing.CPUOptions) {
+		return true, nil
+	}
+
+	if !cmp.Equal(incoming.CreditSpecification, existing.CreditSpecification) {
+		return true, nil
+	}
+
+	if !cmp.Equal(incoming.PrivateDNSNameOptions, existing.PrivateDNSNameOptions) {
+		return true, nil
+	}
+
+	if !launchTemplateVolumesEqual(incoming, existing) {
+		return true, nil
+	}
+
 	incomingIDs, err := s.GetAdditionalSecurityGroupsIDs(incoming.AdditionalSecurityGroups)
 	if err != nil {
 		return false, err
@@ -725,6 +938,38 @@ func (s *Service) DiscoverLaunchTemplateAMI(scope scope.LaunchTemplateScope) (*s
 		return lt.AMI.ID, nil
 	}
 
+	// Keep using the AMI resolved earlier, so that a newly published image doesn't change what new instances boot.
+	kubernetesVersion := scope.GetMachinePool().Spec.Template.Spec.Version
+	if pinned := pinnedAMI(scope.GetResolvedAMIStatus(), scope.GetObjectMeta(), kubernetesVersion); pinned != nil {
+		return aws.String(pinned.ID), nil
+	}
+
+	lookupAMI, err := s.lookupLaunchTemplateAMI(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved, err := s.pinAMI(*lookupAMI, scope.GetObjectMeta(), kubernetesVersion)
+	if err != nil {
+		return nil, err
+	}
+	scope.SetResolvedAMIStatus(resolved)
+
+	return lookupAMI, nil
+}
+
+// lookupLaunchTemplateAMI looks up the AMI for a launch template that doesn't reference one by ID.
+func (s *Service) lookupLaunchTemplateAMI(scope scope.LaunchTemplateScope) (*string, error) {
+	lt := scope.GetLaunchTemplate()
+
+	if lt.AMI.SSMParameter != nil {
+		lookupAMI, err := s.ssmParameterAMILookup(*lt.AMI.SSMParameter, scope.GetMachinePool().Spec.Template.Spec.Version)
+		if err != nil {
+			return nil, err
+		}
+		return aws.String(lookupAMI), nil
+	}
+
 	if scope.GetMachinePool().Spec.Template.Spec.Version == nil {
 		err := errors.New("Either AWSMachinePool's spec.awslaunchtemplate.ami.id or MachinePool's spec.template.spec.version must be defined")
 		s.scope.Error(err, "")
@@ -866,3 +1111,59 @@ func getLaunchTemplateInstanceMarketOptionsRequest(spotMarketOptions *infrav1.Sp
 
 	return launchTemplateInstanceMarketOptionsRequest
 }
+
+func getLaunchTemplateInstanceMetadataOptionsRequest(metadataOptions *infrav1.InstanceMetadataOptions) *ec2.LaunchTemplateInstanceMetadataOptionsRequest {
+	if metadataOptions == nil {
+		return nil
+	}
+
+	request := &ec2.LaunchTemplateInstanceMetadataOptionsRequest{}
+	if metadataOptions.HTTPEndpoint != "" {
+		request.SetHttpEndpoint(string(metadataOptions.HTTPEndpoint))
+	}
+	if metadataOptions.HTTPPutResponseHopLimit != 0 {
+		request.SetHttpPutResponseHopLimit(metadataOptions.HTTPPutResponseHopLimit)
+	}
+	if metadataOptions.HTTPTokens != "" {
+		request.SetHttpTokens(string(metadataOptions.HTTPTokens))
+	}
+	if metadataOptions.InstanceMetadataTags != "" {
+		request.SetInstanceMetadataTags(string(metadataOptions.InstanceMetadataTags))
+	}
+
+	return request
+}
+
+func getLaunchTemplateCapacityReservationSpecificationRequest(spec *infrav1.CapacityReservationSpecification) *ec2.LaunchTemplateCapacityReservationSpecificationRequest {
+	if spec == nil {
+		return nil
+	}
+
+	request := &ec2.LaunchTemplateCapacityReservationSpecificationRequest{}
+	if spec.CapacityReservationPreference != "" {
+		request.SetCapacityReservationPreference(string(spec.CapacityReservationPreference))
+	}
+	if spec.CapacityReservationTarget != nil {
+		request.SetCapacityReservationTarget(&ec2.CapacityReservationTarget{
+			CapacityReservationId:               spec.CapacityReservationTarget.CapacityReservationID,
+			CapacityReservationResourceGroupArn: spec.CapacityReservationTarget.CapacityReservationResourceGroupArn,
+		})
+	}
+
+	return request
+}
+
+// instanceMetadataOptionsEqual compares the incoming and existing metadata options,
+// treating unset fields as their EC2 defaults.
+func instanceMetadataOptionsEqual(incoming, existing *infrav1.InstanceMetadataOptions) bool {
+	if incoming == nil || existing == nil {
+		return incoming == nil && existing == nil
+	}
+
+	in := incoming.DeepCopy()
+	in.SetDefaults()
+	ex := existing.DeepCopy()
+	ex.SetDefaults()
+
+	return cmp.Equal(in, ex)
+}
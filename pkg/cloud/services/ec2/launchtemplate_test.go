@@ -19,6 +19,7 @@ package ec2
 import (
 	"encoding/base64"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
@@ -199,6 +200,9 @@ func TestGetLaunchTemplate(t *testing.T) {
 					SSHKeyName:               aws.String("foo-keyname"),
 					VersionNumber:            aws.Int64(1),
 					AdditionalSecurityGroups: []infrav1.AWSResourceReference{{ID: aws.String("sg-id")}},
+					NonRootVolumes: []infrav1.Volume{
+						{DeviceName: "foo-device", Size: 16, Type: "cool", Encrypted: aws.Bool(true)},
+					},
 				}
 
 				g.Expect(err).NotTo(HaveOccurred())
@@ -257,6 +261,9 @@ func TestGetLaunchTemplate(t *testing.T) {
 					SSHKeyName:               aws.String("foo-keyname"),
 					VersionNumber:            aws.Int64(1),
 					AdditionalSecurityGroups: []infrav1.AWSResourceReference{{ID: aws.String("sg-id")}},
+					NonRootVolumes: []infrav1.Volume{
+						{DeviceName: "foo-device", Size: 16, Type: "cool", Encrypted: aws.Bool(true)},
+					},
 				}
 
 				g.Expect(err).NotTo(HaveOccurred())
@@ -325,6 +332,22 @@ func TestServiceSDKToLaunchTemplate(t *testing.T) {
 							Groups:      []*string{aws.String("foo-group")},
 						},
 					},
+					MetadataOptions: &ec2.LaunchTemplateInstanceMetadataOptions{
+						HttpEndpoint:            aws.String(ec2.LaunchTemplateInstanceMetadataEndpointStateEnabled),
+						HttpPutResponseHopLimit: aws.Int64(2),
+						HttpTokens:              aws.String(ec2.LaunchTemplateHttpTokensStateRequired),
+						InstanceMetadataTags:    aws.String(ec2.LaunchTemplateInstanceMetadataTagsStateEnabled),
+					},
+					CapacityReservationSpecification: &ec2.LaunchTemplateCapacityReservationSpecificationResponse{
+						CapacityReservationPreference: aws.String(ec2.CapacityReservationPreferenceOpen),
+					},
+					CpuOptions: &ec2.LaunchTemplateCpuOptions{
+						CoreCount:      aws.Int64(2),
+						ThreadsPerCore: aws.Int64(1),
+					},
+					CreditSpecification: &ec2.CreditSpecification{
+						CpuCredits: aws.String("unlimited"),
+					},
 					UserData: aws.String(base64.StdEncoding.EncodeToString([]byte(testUserData))),
 				},
 				VersionNumber: aws.Int64(1),
@@ -337,6 +360,30 @@ func TestServiceSDKToLaunchTemplate(t *testing.T) {
 				IamInstanceProfile: "foo-profile",
 				SSHKeyName:         aws.String("foo-keyname"),
 				VersionNumber:      aws.Int64(1),
+				InstanceMetadataOptions: &infrav1.InstanceMetadataOptions{
+					HTTPEndpoint:            infrav1.InstanceMetadataEndpointStateEnabled,
+					HTTPPutResponseHopLimit: 2,
+					HTTPTokens:              infrav1.HTTPTokensStateRequired,
+					InstanceMetadataTags:    infrav1.InstanceMetadataEndpointStateEnabled,
+				},
+				CapacityReservationSpecification: &infrav1.CapacityReservationSpecification{
+					CapacityReservationPreference: infrav1.CapacityReservationPreferenceOpen,
+				},
+				CPUOptions: &infrav1.CPUOptions{
+					CoreCount:      2,
+					ThreadsPerCore: 1,
+				},
+				CreditSpecification: &infrav1.CreditSpecification{
+					CPUCredits: infrav1.CPUCreditsUnlimited,
+				},
+				NonRootVolumes: []infrav1.Volume{
+					{
+						DeviceName: "foo-device",
+						Encrypted:  aws.Bool(true),
+						Size:       16,
+						Type:       "cool",
+					},
+				},
 			},
 			wantHash: testUserDataHash,
 		},
@@ -421,6 +468,21 @@ func TestServiceLaunchTemplateNeedsUpdate(t *testing.T) {
 			want:    true,
 			wantErr: false,
 		},
+		{
+			name: "Should return true if incoming PrivateDNSNameOptions is not same as existing PrivateDNSNameOptions",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				PrivateDNSNameOptions: &infrav1.PrivateDNSNameOptions{
+					HostnameType:                 aws.String(infrav1.HostnameTypeResourceName),
+					EnableResourceNameDNSARecord: aws.Bool(true),
+				},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				PrivateDNSNameOptions: &infrav1.PrivateDNSNameOptions{
+					HostnameType: aws.String(infrav1.HostnameTypeIPName),
+				},
+			},
+			want: true,
+		},
 		{
 			name: "Should return true if incoming IamInstanceProfile is not same as existing IamInstanceProfile",
 			incoming: &expinfrav1.AWSLaunchTemplate{
@@ -441,6 +503,165 @@ func TestServiceLaunchTemplateNeedsUpdate(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "Should return true if incoming InstanceMetadataOptions are not same as existing InstanceMetadataOptions",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				InstanceMetadataOptions: &infrav1.InstanceMetadataOptions{
+					HTTPTokens:              infrav1.HTTPTokensStateRequired,
+					HTTPPutResponseHopLimit: 2,
+				},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				InstanceMetadataOptions: &infrav1.InstanceMetadataOptions{
+					HTTPEndpoint:            infrav1.InstanceMetadataEndpointStateEnabled,
+					HTTPTokens:              infrav1.HTTPTokensStateOptional,
+					HTTPPutResponseHopLimit: 1,
+					InstanceMetadataTags:    infrav1.InstanceMetadataEndpointStateDisabled,
+				},
+			},
+			want: true,
+		},
+		{
+			name: "Should return false if incoming InstanceMetadataOptions only differ from existing by defaults",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				InstanceMetadataOptions: &infrav1.InstanceMetadataOptions{
+					HTTPTokens: infrav1.HTTPTokensStateRequired,
+				},
+				AdditionalSecurityGroups: []infrav1.AWSResourceReference{
+					{ID: aws.String("sg-999")},
+				},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				InstanceMetadataOptions: &infrav1.InstanceMetadataOptions{
+					HTTPEndpoint:            infrav1.InstanceMetadataEndpointStateEnabled,
+					HTTPTokens:              infrav1.HTTPTokensStateRequired,
+					HTTPPutResponseHopLimit: 1,
+					InstanceMetadataTags:    infrav1.InstanceMetadataEndpointStateDisabled,
+				},
+				AdditionalSecurityGroups: []infrav1.AWSResourceReference{
+					{ID: aws.String("sg-111")},
+					{ID: aws.String("sg-222")},
+					{ID: aws.String("sg-999")},
+				},
+			},
+			want: false,
+		},
+		{
+			name:     "Should return true if incoming InstanceMetadataOptions are removed",
+			incoming: &expinfrav1.AWSLaunchTemplate{},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				InstanceMetadataOptions: &infrav1.InstanceMetadataOptions{
+					HTTPTokens: infrav1.HTTPTokensStateRequired,
+				},
+			},
+			want: true,
+		},
+		{
+			name: "Should return true if incoming CapacityReservationSpecification is not same as existing CapacityReservationSpecification",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				CapacityReservationSpecification: &infrav1.CapacityReservationSpecification{
+					CapacityReservationTarget: &infrav1.CapacityReservationTarget{
+						CapacityReservationID: aws.String("cr-2"),
+					},
+				},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				CapacityReservationSpecification: &infrav1.CapacityReservationSpecification{
+					CapacityReservationTarget: &infrav1.CapacityReservationTarget{
+						CapacityReservationID: aws.String("cr-1"),
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "Should return true if incoming CPUOptions are not same as existing CPUOptions",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				CPUOptions: &infrav1.CPUOptions{CoreCount: 2, ThreadsPerCore: 1},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				CPUOptions: &infrav1.CPUOptions{CoreCount: 2, ThreadsPerCore: 2},
+			},
+			want: true,
+		},
+		{
+			name: "Should return true if incoming CreditSpecification is not same as existing CreditSpecification",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				CreditSpecification: &infrav1.CreditSpecification{CPUCredits: infrav1.CPUCreditsUnlimited},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				CreditSpecification: &infrav1.CreditSpecification{CPUCredits: infrav1.CPUCreditsStandard},
+			},
+			want: true,
+		},
+		{
+			name: "Should return false if incoming NonRootVolumes are the same as the existing block device mappings besides the root volume",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				RootVolume: &infrav1.Volume{Size: 8},
+				NonRootVolumes: []infrav1.Volume{
+					{DeviceName: "/dev/sdb", Size: 16, Type: infrav1.VolumeTypeGP3, EncryptionKey: "key"},
+					{DeviceName: "/dev/sdc", VirtualName: "ephemeral0"},
+				},
+				AdditionalSecurityGroups: []infrav1.AWSResourceReference{
+					{ID: aws.String("sg-999")},
+				},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				NonRootVolumes: []infrav1.Volume{
+					{DeviceName: "/dev/xvda", Size: 8},
+					{DeviceName: "/dev/sdc", VirtualName: "ephemeral0"},
+					{DeviceName: "/dev/sdb", Size: 16, Type: infrav1.VolumeTypeGP3, Encrypted: aws.Bool(true), EncryptionKey: "key"},
+				},
+				AdditionalSecurityGroups: []infrav1.AWSResourceReference{
+					{ID: aws.String("sg-111")},
+					{ID: aws.String("sg-222")},
+					{ID: aws.String("sg-999")},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "Should return true if incoming NonRootVolumes are not same as existing NonRootVolumes",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				NonRootVolumes: []infrav1.Volume{
+					{DeviceName: "/dev/sdb", Size: 32},
+				},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				NonRootVolumes: []infrav1.Volume{
+					{DeviceName: "/dev/sdb", Size: 16},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "Should return true if a NonRootVolume is added",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				NonRootVolumes: []infrav1.Volume{
+					{DeviceName: "/dev/sdb", Size: 16},
+					{DeviceName: "/dev/sdc", VirtualName: "ephemeral0"},
+				},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				NonRootVolumes: []infrav1.Volume{
+					{DeviceName: "/dev/sdb", Size: 16},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "Should return true if a NonRootVolume is removed",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				RootVolume: &infrav1.Volume{Size: 8},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				NonRootVolumes: []infrav1.Volume{
+					{DeviceName: "/dev/xvda", Size: 8},
+					{DeviceName: "/dev/sdb", Size: 16},
+				},
+			},
+			want: true,
+		},
 		{
 			name: "new additional security group with filters",
 			incoming: &expinfrav1.AWSLaunchTemplate{
@@ -717,6 +938,7 @@ func TestCreateLaunchTemplate(t *testing.T) {
 	testCases := []struct {
 		name                 string
 		awsResourceReference []infrav1.AWSResourceReference
+		nonRootVolumes       []infrav1.Volume
 		expect               func(g *WithT, m *mocks.MockEC2APIMockRecorder)
 		check                func(g *WithT, s string, e error)
 	}{
@@ -842,6 +1064,83 @@ func TestCreateLaunchTemplate(t *testing.T) {
 				g.Expect(err).NotTo(HaveOccurred())
 			},
 		},
+		{
+			name:                 "Should create launch template with non root and instance store volumes",
+			awsResourceReference: []infrav1.AWSResourceReference{{ID: aws.String("1")}},
+			nonRootVolumes: []infrav1.Volume{
+				{DeviceName: "/dev/sdb", Size: 16, Type: infrav1.VolumeTypeGP3, Throughput: aws.Int64(250), EncryptionKey: "key"},
+				{DeviceName: "/dev/sdc", VirtualName: "ephemeral0"},
+			},
+			expect: func(g *WithT, m *mocks.MockEC2APIMockRecorder) {
+				var expectedInput = &ec2.CreateLaunchTemplateInput{
+					LaunchTemplateData: &ec2.RequestLaunchTemplateData{
+						InstanceType: aws.String("t3.large"),
+						IamInstanceProfile: &ec2.LaunchTemplateIamInstanceProfileSpecificationRequest{
+							Name: aws.String("instance-profile"),
+						},
+						KeyName:          aws.String("default"),
+						UserData:         pointer.StringPtr(base64.StdEncoding.EncodeToString(userData)),
+						SecurityGroupIds: aws.StringSlice([]string{"nodeSG", "lbSG", "1"}),
+						ImageId:          aws.String("imageID"),
+						InstanceMarketOptions: &ec2.LaunchTemplateInstanceMarketOptionsRequest{
+							MarketType: aws.String("spot"),
+							SpotOptions: &ec2.LaunchTemplateSpotMarketOptionsRequest{
+								MaxPrice: aws.String("0.9"),
+							},
+						},
+						BlockDeviceMappings: []*ec2.LaunchTemplateBlockDeviceMappingRequest{
+							{
+								DeviceName: aws.String("/dev/sdb"),
+								Ebs: &ec2.LaunchTemplateEbsBlockDeviceRequest{
+									DeleteOnTermination: aws.Bool(true),
+									Encrypted:           aws.Bool(true),
+									KmsKeyId:            aws.String("key"),
+									Throughput:          aws.Int64(250),
+									VolumeSize:          aws.Int64(16),
+									VolumeType:          aws.String("gp3"),
+								},
+							},
+							{
+								DeviceName:  aws.String("/dev/sdc"),
+								VirtualName: aws.String("ephemeral0"),
+							},
+						},
+						TagSpecifications: []*ec2.LaunchTemplateTagSpecificationRequest{
+							{
+								ResourceType: aws.String(ec2.ResourceTypeInstance),
+								Tags:         defaultEC2Tags("aws-mp-name", "cluster-name"),
+							},
+							{
+								ResourceType: aws.String(ec2.ResourceTypeVolume),
+								Tags:         defaultEC2Tags("aws-mp-name", "cluster-name"),
+							},
+						},
+					},
+					LaunchTemplateName: aws.String("aws-mp-name"),
+					TagSpecifications: []*ec2.TagSpecification{
+						{
+							ResourceType: aws.String(ec2.ResourceTypeLaunchTemplate),
+							Tags:         defaultEC2Tags("aws-mp-name", "cluster-name"),
+						},
+					},
+				}
+				m.CreateLaunchTemplate(gomock.AssignableToTypeOf(expectedInput)).Return(&ec2.CreateLaunchTemplateOutput{
+					LaunchTemplate: &ec2.LaunchTemplate{
+						LaunchTemplateId: aws.String("launch-template-id"),
+					},
+				}, nil).Do(func(arg *ec2.CreateLaunchTemplateInput) {
+					// formatting added to match arrays during cmp.Equal
+					formatTagsInput(arg)
+					if !cmp.Equal(expectedInput, arg) {
+						t.Fatalf("mismatch in input expected: %+v, got: %+v", expectedInput, arg)
+					}
+				})
+			},
+			check: func(g *WithT, id string, err error) {
+				g.Expect(id).Should(Equal("launch-template-id"))
+				g.Expect(err).NotTo(HaveOccurred())
+			},
+		},
 		{
 			name:                 "Should return with error if failed to create launch template id",
 			awsResourceReference: []infrav1.AWSResourceReference{{ID: aws.String("1")}},
@@ -916,6 +1215,7 @@ func TestCreateLaunchTemplate(t *testing.T) {
 			g.Expect(err).NotTo(HaveOccurred())
 
 			ms.AWSMachinePool.Spec.AWSLaunchTemplate.AdditionalSecurityGroups = tc.awsResourceReference
+			ms.AWSMachinePool.Spec.AWSLaunchTemplate.NonRootVolumes = tc.nonRootVolumes
 
 			s := NewService(cs)
 			s.EC2Client = mockEC2Client
@@ -1195,6 +1495,10 @@ func TestDiscoverLaunchTemplateAMI(t *testing.T) {
 							},
 						},
 					}, nil)
+				m.DescribeImages(gomock.Eq(&ec2.DescribeImagesInput{ImageIds: aws.StringSlice([]string{"latest"})})).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{{ImageId: aws.String("latest"), CreationDate: aws.String("2019-02-08T17:02:31.000Z")}},
+					}, nil)
 			},
 			check: func(g *WithT, res *string, err error) {
 				g.Expect(res).Should(Equal(aws.String("latest")))
@@ -1229,6 +1533,10 @@ func TestDiscoverLaunchTemplateAMI(t *testing.T) {
 							},
 						},
 					}, nil)
+				m.DescribeImages(gomock.Eq(&ec2.DescribeImagesInput{ImageIds: aws.StringSlice([]string{"latest"})})).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{{ImageId: aws.String("latest"), CreationDate: aws.String("2019-02-08T17:02:31.000Z")}},
+					}, nil)
 			},
 			check: func(g *WithT, res *string, err error) {
 				g.Expect(res).Should(Equal(aws.String("latest")))
@@ -1340,6 +1648,9 @@ func TestDiscoverLaunchTemplateAMI_ForEKS(t *testing.T) {
 			g := NewWithT(t)
 
 			ssmMock := mock_ssmiface.NewMockSSMAPI(mockCtrl)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			ec2Mock.EXPECT().DescribeImages(gomock.AssignableToTypeOf(&ec2.DescribeImagesInput{})).
+				Return(&ec2.DescribeImagesOutput{}, nil).AnyTimes()
 
 			scheme, err := setupScheme()
 			g.Expect(err).NotTo(HaveOccurred())
@@ -1357,6 +1668,7 @@ func TestDiscoverLaunchTemplateAMI_ForEKS(t *testing.T) {
 
 			s := NewService(mcps)
 			s.SSMClient = ssmMock
+			s.EC2Client = ec2Mock
 
 			id, err := s.DiscoverLaunchTemplateAMI(ms)
 			tc.check(g, id, err)
@@ -1364,6 +1676,120 @@ func TestDiscoverLaunchTemplateAMI_ForEKS(t *testing.T) {
 	}
 }
 
+func TestDiscoverLaunchTemplateAMI_PinsResolvedAMI(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	pinned := &infrav1.ResolvedAMI{
+		ID:                "pinned",
+		KubernetesVersion: "v1.23.3",
+	}
+
+	testCases := []struct {
+		name        string
+		resolvedAMI *infrav1.ResolvedAMI
+		annotations map[string]string
+		version     string
+		expect      func(m *mocks.MockEC2APIMockRecorder)
+		wantID      string
+		wantPinned  *infrav1.ResolvedAMI
+	}{
+		{
+			name:    "Should pin the looked up AMI with its name and creation date",
+			version: "v1.23.3",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeImages(gomock.Eq(&ec2.DescribeImagesInput{ImageIds: aws.StringSlice([]string{"latest"})})).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{{
+							ImageId:      aws.String("latest"),
+							Name:         aws.String("capa-ami-ubuntu-18.04-v1.23.3-1"),
+							CreationDate: aws.String("2019-02-08T17:02:31.000Z"),
+						}},
+					}, nil)
+			},
+			wantID: "latest",
+			wantPinned: &infrav1.ResolvedAMI{
+				ID:                "latest",
+				Name:              "capa-ami-ubuntu-18.04-v1.23.3-1",
+				CreationDate:      &metav1.Time{Time: time.Date(2019, 2, 8, 17, 2, 31, 0, time.UTC)},
+				KubernetesVersion: "v1.23.3",
+			},
+		},
+		{
+			name:        "Should keep using the pinned AMI",
+			resolvedAMI: pinned,
+			version:     "v1.23.3",
+			wantID:      "pinned",
+			wantPinned:  pinned,
+		},
+		{
+			name:        "Should look up the AMI again when the Kubernetes version changes",
+			resolvedAMI: pinned,
+			version:     "v1.24.0",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeImages(gomock.Eq(&ec2.DescribeImagesInput{ImageIds: aws.StringSlice([]string{"latest"})})).
+					Return(&ec2.DescribeImagesOutput{}, nil)
+			},
+			wantID:     "latest",
+			wantPinned: &infrav1.ResolvedAMI{ID: "latest", KubernetesVersion: "v1.24.0"},
+		},
+		{
+			name:        "Should look up the AMI again when a refresh is requested",
+			resolvedAMI: pinned,
+			annotations: map[string]string{infrav1.RefreshAMIAnnotation: ""},
+			version:     "v1.23.3",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeImages(gomock.Eq(&ec2.DescribeImagesInput{ImageIds: aws.StringSlice([]string{"latest"})})).
+					Return(&ec2.DescribeImagesOutput{}, nil)
+			},
+			wantID:     "latest",
+			wantPinned: &infrav1.ResolvedAMI{ID: "latest", KubernetesVersion: "v1.23.3"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			ssmMock := mock_ssmiface.NewMockSSMAPI(mockCtrl)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+			cs, err := setupClusterScope(client)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			ms, err := setupMachinePoolScope(client, cs)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			ms.AWSMachinePool.Spec.AWSLaunchTemplate = expinfrav1.AWSLaunchTemplate{
+				Name: "aws-launch-tmpl",
+				AMI:  infrav1.AMIReference{SSMParameter: aws.String("/test/ami/{{.K8sVersion}}")},
+			}
+			ms.AWSMachinePool.Annotations = tc.annotations
+			ms.AWSMachinePool.Status.ResolvedAMI = tc.resolvedAMI
+			ms.MachinePool.Spec.Template.Spec.Version = aws.String(tc.version)
+
+			ssmMock.EXPECT().GetParameter(gomock.AssignableToTypeOf(&ssm.GetParameterInput{})).
+				Return(&ssm.GetParameterOutput{Parameter: &ssm.Parameter{Value: aws.String("latest")}}, nil).AnyTimes()
+			if tc.expect != nil {
+				tc.expect(ec2Mock.EXPECT())
+			}
+
+			s := NewService(cs)
+			s.EC2Client = ec2Mock
+			s.SSMClient = ssmMock
+
+			id, err := s.DiscoverLaunchTemplateAMI(ms)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(aws.StringValue(id)).To(Equal(tc.wantID))
+			g.Expect(ms.AWSMachinePool.Status.ResolvedAMI).To(Equal(tc.wantPinned))
+			g.Expect(ms.AWSMachinePool.Annotations).NotTo(HaveKey(infrav1.RefreshAMIAnnotation))
+		})
+	}
+}
+
 func TestDeleteLaunchTemplateVersion(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -1442,3 +1868,94 @@ func TestDeleteLaunchTemplateVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestRollbackLaunchTemplateVersion(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	testCases := []struct {
+		name    string
+		expect  func(m *mocks.MockEC2APIMockRecorder)
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Should delete the latest version and return the previous version",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				gomock.InOrder(
+					m.DescribeLaunchTemplateVersions(gomock.Eq(&ec2.DescribeLaunchTemplateVersionsInput{
+						LaunchTemplateId: aws.String("id"),
+						Versions:         aws.StringSlice([]string{"$Latest"}),
+					})).Return(&ec2.DescribeLaunchTemplateVersionsOutput{
+						LaunchTemplateVersions: []*ec2.LaunchTemplateVersion{
+							{VersionNumber: aws.Int64(5), DefaultVersion: aws.Bool(false)},
+						},
+					}, nil),
+					m.DeleteLaunchTemplateVersions(gomock.Eq(&ec2.DeleteLaunchTemplateVersionsInput{
+						LaunchTemplateId: aws.String("id"),
+						Versions:         aws.StringSlice([]string{"5"}),
+					})).Return(&ec2.DeleteLaunchTemplateVersionsOutput{}, nil),
+					m.DescribeLaunchTemplateVersions(gomock.Eq(&ec2.DescribeLaunchTemplateVersionsInput{
+						LaunchTemplateId: aws.String("id"),
+						Versions:         aws.StringSlice([]string{"$Latest"}),
+					})).Return(&ec2.DescribeLaunchTemplateVersionsOutput{
+						LaunchTemplateVersions: []*ec2.LaunchTemplateVersion{
+							{VersionNumber: aws.Int64(4), DefaultVersion: aws.Bool(false)},
+						},
+					}, nil),
+				)
+			},
+			want: "4",
+		},
+		{
+			name: "Should return error if the latest version is the default version",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeLaunchTemplateVersions(gomock.Any()).Return(&ec2.DescribeLaunchTemplateVersionsOutput{
+					LaunchTemplateVersions: []*ec2.LaunchTemplateVersion{
+						{VersionNumber: aws.Int64(1), DefaultVersion: aws.Bool(true)},
+					},
+				}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should return error if AWS unable to delete launch template version",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeLaunchTemplateVersions(gomock.Any()).Return(&ec2.DescribeLaunchTemplateVersionsOutput{
+					LaunchTemplateVersions: []*ec2.LaunchTemplateVersion{
+						{VersionNumber: aws.Int64(5), DefaultVersion: aws.Bool(false)},
+					},
+				}, nil)
+				m.DeleteLaunchTemplateVersions(gomock.Any()).Return(nil, awserrors.NewFailedDependency("dependency-failure"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+			cs, err := setupClusterScope(client)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			s := NewService(cs)
+			s.EC2Client = ec2Mock
+
+			tc.expect(ec2Mock.EXPECT())
+
+			version, err := s.RollbackLaunchTemplateVersion("id")
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(version).To(Equal(tc.want))
+		})
+	}
+}
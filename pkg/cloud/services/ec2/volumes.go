@@ -0,0 +1,320 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"fmt"
+	"path"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/tags"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+// ReconcileVolumes applies increases of the size, IOPS and throughput, and changes of the type, of the
+// root and non root volumes to the EBS volumes attached to the instance using ModifyVolume.
+// It returns true while a modification of any of the volumes is still in progress.
+func (s *Service) ReconcileVolumes(instance *infrav1.Instance, rootVolume *infrav1.Volume, nonRootVolumes []infrav1.Volume) (bool, error) {
+	if len(instance.VolumeIDs) == 0 {
+		return false, nil
+	}
+
+	volumesByDevice, err := s.getAttachedVolumes(instance)
+	if err != nil {
+		return false, err
+	}
+
+	desired, err := s.getVolumesByDevice(instance.ID, rootVolume, nonRootVolumes)
+	if err != nil {
+		return false, err
+	}
+
+	modifications, err := s.getVolumeModificationStates(instance.VolumeIDs)
+	if err != nil {
+		return false, err
+	}
+
+	inProgress := false
+	for device, volume := range desired {
+		existing, ok := volumesByDevice[device]
+		if !ok {
+			continue
+		}
+		volumeID := aws.StringValue(existing.VolumeId)
+
+		switch modifications[volumeID] {
+		case ec2.VolumeModificationStateModifying, ec2.VolumeModificationStateOptimizing:
+			inProgress = true
+			continue
+		case ec2.VolumeModificationStateFailed:
+			// A failed modification leaves the volume unchanged, so it will simply be retried below.
+			s.scope.Info("Previous volume modification failed, retrying", "volume-id", volumeID)
+		}
+
+		input := getModifyVolumeInput(volume, existing)
+		if input == nil {
+			continue
+		}
+
+		s.scope.Info("Modifying volume", "volume-id", volumeID, "device", device, "instance-id", instance.ID)
+		if _, err := s.EC2Client.ModifyVolume(input); err != nil {
+			return false, errors.Wrapf(err, "failed to modify volume %q", volumeID)
+		}
+		inProgress = true
+	}
+
+	return inProgress, nil
+}
+
+// ApplyVolumeDeletionPolicies prepares the volumes attached to the instance for its termination according
+// to their deletion policy: volumes to retain are detached from the instance lifecycle and volumes to
+// snapshot are snapshotted. The retained volume and snapshot IDs are recorded in the AWSMachine status.
+func (s *Service) ApplyVolumeDeletionPolicies(scope *scope.MachineScope, instance *infrav1.Instance) error {
+	if len(instance.VolumeIDs) == 0 {
+		return nil
+	}
+
+	volumesByDevice, err := s.getAttachedVolumes(instance)
+	if err != nil {
+		return err
+	}
+
+	desired, err := s.getVolumesByDevice(instance.ID, scope.AWSMachine.Spec.RootVolume, scope.AWSMachine.Spec.NonRootVolumes)
+	if err != nil {
+		return err
+	}
+
+	snapshotted, err := s.getSnapshottedVolumeIDs(scope.AWSMachine.Status.VolumeSnapshotIDs)
+	if err != nil {
+		return err
+	}
+
+	var mappings []*ec2.InstanceBlockDeviceMappingSpecification
+	var retainedVolumeIDs []string
+	for device, volume := range desired {
+		existing, ok := volumesByDevice[device]
+		if !ok {
+			continue
+		}
+		volumeID := aws.StringValue(existing.VolumeId)
+
+		retain := volume.VolumeDeletionPolicy == infrav1.VolumeDeletionPolicyRetain
+		if retain {
+			retainedVolumeIDs = append(retainedVolumeIDs, volumeID)
+		}
+		if deleteOnTermination(existing, instance.ID) == retain {
+			mappings = append(mappings, &ec2.InstanceBlockDeviceMappingSpecification{
+				DeviceName: aws.String(device),
+				Ebs: &ec2.EbsInstanceBlockDeviceSpecification{
+					DeleteOnTermination: aws.Bool(!retain),
+				},
+			})
+		}
+
+		if volume.VolumeDeletionPolicy == infrav1.VolumeDeletionPolicySnapshot && !snapshotted[volumeID] {
+			snapshotID, err := s.createVolumeSnapshot(scope, volumeID, device)
+			if err != nil {
+				return err
+			}
+			scope.AWSMachine.Status.VolumeSnapshotIDs = append(scope.AWSMachine.Status.VolumeSnapshotIDs, snapshotID)
+		}
+	}
+
+	if len(mappings) > 0 {
+		if _, err := s.EC2Client.ModifyInstanceAttribute(&ec2.ModifyInstanceAttributeInput{
+			InstanceId:          aws.String(instance.ID),
+			BlockDeviceMappings: mappings,
+		}); err != nil {
+			return errors.Wrapf(err, "failed to update the volume deletion behaviour of instance %q", instance.ID)
+		}
+	}
+
+	sort.Strings(retainedVolumeIDs)
+	scope.AWSMachine.Status.RetainedVolumeIDs = retainedVolumeIDs
+
+	return nil
+}
+
+// getAttachedVolumes returns the volumes attached to the instance, keyed by their device name.
+func (s *Service) getAttachedVolumes(instance *infrav1.Instance) (map[string]*ec2.Volume, error) {
+	out, err := s.EC2Client.DescribeVolumes(&ec2.DescribeVolumesInput{
+		VolumeIds: aws.StringSlice(instance.VolumeIDs),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe volumes of instance %q", instance.ID)
+	}
+
+	volumesByDevice := make(map[string]*ec2.Volume, len(out.Volumes))
+	for _, volume := range out.Volumes {
+		for _, attachment := range volume.Attachments {
+			if aws.StringValue(attachment.InstanceId) == instance.ID {
+				volumesByDevice[aws.StringValue(attachment.Device)] = volume
+			}
+		}
+	}
+
+	return volumesByDevice, nil
+}
+
+// getVolumesByDevice returns the root and non root volumes of the spec keyed by their device name.
+func (s *Service) getVolumesByDevice(instanceID string, rootVolume *infrav1.Volume, nonRootVolumes []infrav1.Volume) (map[string]infrav1.Volume, error) {
+	volumes := make(map[string]infrav1.Volume, len(nonRootVolumes)+1)
+	for _, volume := range nonRootVolumes {
+		volumes[volume.DeviceName] = volume
+	}
+	if rootVolume != nil {
+		rootDeviceName, err := s.getInstanceRootDeviceName(instanceID)
+		if err != nil {
+			return nil, err
+		}
+		volumes[rootDeviceName] = *rootVolume
+	}
+
+	return volumes, nil
+}
+
+func deleteOnTermination(volume *ec2.Volume, instanceID string) bool {
+	for _, attachment := range volume.Attachments {
+		if aws.StringValue(attachment.InstanceId) == instanceID {
+			return aws.BoolValue(attachment.DeleteOnTermination)
+		}
+	}
+	return false
+}
+
+// getSnapshottedVolumeIDs returns the IDs of the volumes the given snapshots were taken of.
+func (s *Service) getSnapshottedVolumeIDs(snapshotIDs []string) (map[string]bool, error) {
+	volumeIDs := map[string]bool{}
+	if len(snapshotIDs) == 0 {
+		return volumeIDs, nil
+	}
+
+	// Filtering instead of passing the snapshot IDs avoids an error for snapshots deleted in the meantime.
+	out, err := s.EC2Client.DescribeSnapshots(&ec2.DescribeSnapshotsInput{
+		Filters: []*ec2.Filter{{Name: aws.String("snapshot-id"), Values: aws.StringSlice(snapshotIDs)}},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to describe volume snapshots")
+	}
+	for _, snapshot := range out.Snapshots {
+		volumeIDs[aws.StringValue(snapshot.VolumeId)] = true
+	}
+
+	return volumeIDs, nil
+}
+
+func (s *Service) createVolumeSnapshot(scope *scope.MachineScope, volumeID, device string) (string, error) {
+	out, err := s.EC2Client.CreateSnapshot(&ec2.CreateSnapshotInput{
+		VolumeId:    aws.String(volumeID),
+		Description: aws.String(fmt.Sprintf("Snapshot of volume %s (%s) of machine %s taken before its deletion", volumeID, device, scope.Name())),
+		TagSpecifications: []*ec2.TagSpecification{
+			tags.BuildParamsToTagSpecification(ec2.ResourceTypeSnapshot, infrav1.BuildParams{
+				ClusterName: s.scope.KubernetesClusterName(),
+				Lifecycle:   infrav1.ResourceLifecycleOwned,
+				Name:        aws.String(fmt.Sprintf("%s-%s", scope.Name(), path.Base(device))),
+				Role:        aws.String(scope.Role()),
+				Additional:  scope.AdditionalTags(),
+			}),
+		},
+	})
+	if err != nil {
+		record.Warnf(scope.AWSMachine, "FailedCreateSnapshot", "Failed to snapshot volume %q: %v", volumeID, err)
+		return "", errors.Wrapf(err, "failed to snapshot volume %q", volumeID)
+	}
+
+	snapshotID := aws.StringValue(out.SnapshotId)
+	s.scope.Info("Created volume snapshot", "volume-id", volumeID, "snapshot-id", snapshotID)
+	return snapshotID, nil
+}
+
+func (s *Service) getInstanceRootDeviceName(instanceID string) (string, error) {
+	out, err := s.EC2Client.DescribeInstanceAttribute(&ec2.DescribeInstanceAttributeInput{
+		Attribute:  aws.String(ec2.InstanceAttributeNameRootDeviceName),
+		InstanceId: aws.String(instanceID),
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get root device name of instance %q", instanceID)
+	}
+	if out.RootDeviceName == nil {
+		return "", errors.Errorf("root device name of instance %q is unknown", instanceID)
+	}
+
+	return aws.StringValue(out.RootDeviceName.Value), nil
+}
+
+// getVolumeModificationStates returns the state of the latest modification of each volume that was modified.
+func (s *Service) getVolumeModificationStates(volumeIDs []string) (map[string]string, error) {
+	states := map[string]string{}
+
+	// Filtering instead of passing the volume IDs avoids an error for volumes that were never modified.
+	input := &ec2.DescribeVolumesModificationsInput{
+		Filters: []*ec2.Filter{{Name: aws.String("volume-id"), Values: aws.StringSlice(volumeIDs)}},
+	}
+	if err := s.EC2Client.DescribeVolumesModificationsPages(input, func(out *ec2.DescribeVolumesModificationsOutput, lastPage bool) bool {
+		for _, modification := range out.VolumesModifications {
+			states[aws.StringValue(modification.VolumeId)] = aws.StringValue(modification.ModificationState)
+		}
+		return true
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to describe volume modifications")
+	}
+
+	return states, nil
+}
+
+// getModifyVolumeInput returns the modification needed to bring the existing volume to the desired one,
+// or nil if there is none. Decreases are ignored as EBS volumes can't be shrunk.
+func getModifyVolumeInput(desired infrav1.Volume, existing *ec2.Volume) *ec2.ModifyVolumeInput {
+	input := &ec2.ModifyVolumeInput{VolumeId: existing.VolumeId}
+	modified := false
+
+	if desired.Size > aws.Int64Value(existing.Size) {
+		input.Size = aws.Int64(desired.Size)
+		modified = true
+	}
+
+	volumeType := infrav1.VolumeType(aws.StringValue(existing.VolumeType))
+	if desired.Type != "" && desired.Type != volumeType {
+		input.VolumeType = aws.String(string(desired.Type))
+		volumeType = desired.Type
+		modified = true
+	}
+
+	switch volumeType {
+	case infrav1.VolumeTypeIO1, infrav1.VolumeTypeIO2, infrav1.VolumeTypeGP3:
+		if desired.IOPS > aws.Int64Value(existing.Iops) {
+			input.Iops = aws.Int64(desired.IOPS)
+			modified = true
+		}
+	}
+
+	if volumeType == infrav1.VolumeTypeGP3 && desired.Throughput != nil && *desired.Throughput > aws.Int64Value(existing.Throughput) {
+		input.Throughput = desired.Throughput
+		modified = true
+	}
+
+	if !modified {
+		return nil
+	}
+	return input
+}
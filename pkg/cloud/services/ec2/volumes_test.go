@@ -0,0 +1,238 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+)
+
+func TestReconcileVolumes(t *testing.T) {
+	instance := &infrav1.Instance{ID: "i-1", VolumeIDs: []string{"vol-root", "vol-data"}}
+	existingVolumes := &ec2.DescribeVolumesOutput{Volumes: []*ec2.Volume{
+		{
+			VolumeId:    aws.String("vol-root"),
+			Size:        aws.Int64(20),
+			VolumeType:  aws.String("gp3"),
+			Iops:        aws.Int64(3000),
+			Throughput:  aws.Int64(125),
+			Attachments: []*ec2.VolumeAttachment{{Device: aws.String("/dev/xvda"), InstanceId: aws.String("i-1")}},
+		},
+		{
+			VolumeId:    aws.String("vol-data"),
+			Size:        aws.Int64(50),
+			VolumeType:  aws.String("gp2"),
+			Iops:        aws.Int64(150),
+			Attachments: []*ec2.VolumeAttachment{{Device: aws.String("/dev/sdb"), InstanceId: aws.String("i-1")}},
+		},
+	}}
+
+	expectDescribe := func(m *mocks.MockEC2APIMockRecorder, states map[string]string) {
+		m.DescribeVolumes(gomock.Eq(&ec2.DescribeVolumesInput{VolumeIds: aws.StringSlice(instance.VolumeIDs)})).
+			Return(existingVolumes, nil)
+		m.DescribeInstanceAttribute(gomock.Eq(&ec2.DescribeInstanceAttributeInput{
+			Attribute:  aws.String(ec2.InstanceAttributeNameRootDeviceName),
+			InstanceId: aws.String("i-1"),
+		})).Return(&ec2.DescribeInstanceAttributeOutput{RootDeviceName: &ec2.AttributeValue{Value: aws.String("/dev/xvda")}}, nil)
+		m.DescribeVolumesModificationsPages(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ *ec2.DescribeVolumesModificationsInput, fn func(*ec2.DescribeVolumesModificationsOutput, bool) bool) error {
+				out := &ec2.DescribeVolumesModificationsOutput{}
+				for id, state := range states {
+					out.VolumesModifications = append(out.VolumesModifications, &ec2.VolumeModification{
+						VolumeId:          aws.String(id),
+						ModificationState: aws.String(state),
+					})
+				}
+				fn(out, true)
+				return nil
+			})
+	}
+
+	tests := []struct {
+		name           string
+		rootVolume     *infrav1.Volume
+		nonRootVolumes []infrav1.Volume
+		expect         func(m *mocks.MockEC2APIMockRecorder)
+		wantInProgress bool
+	}{
+		{
+			name:           "does not modify volumes matching the spec",
+			rootVolume:     &infrav1.Volume{Size: 20, Type: infrav1.VolumeTypeGP3, IOPS: 3000, Throughput: aws.Int64(125)},
+			nonRootVolumes: []infrav1.Volume{{DeviceName: "/dev/sdb", Size: 50, Type: infrav1.VolumeTypeGP2}},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				expectDescribe(m, nil)
+			},
+		},
+		{
+			name:       "increases the size, IOPS and throughput of the root volume",
+			rootVolume: &infrav1.Volume{Size: 40, Type: infrav1.VolumeTypeGP3, IOPS: 4000, Throughput: aws.Int64(250)},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				expectDescribe(m, nil)
+				m.ModifyVolume(gomock.Eq(&ec2.ModifyVolumeInput{
+					VolumeId:   aws.String("vol-root"),
+					Size:       aws.Int64(40),
+					Iops:       aws.Int64(4000),
+					Throughput: aws.Int64(250),
+				})).Return(&ec2.ModifyVolumeOutput{}, nil)
+			},
+			wantInProgress: true,
+		},
+		{
+			name:           "changes the type of a non root volume",
+			nonRootVolumes: []infrav1.Volume{{DeviceName: "/dev/sdb", Size: 50, Type: infrav1.VolumeTypeGP3}},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeVolumes(gomock.Any()).Return(existingVolumes, nil)
+				m.DescribeVolumesModificationsPages(gomock.Any(), gomock.Any()).Return(nil)
+				m.ModifyVolume(gomock.Eq(&ec2.ModifyVolumeInput{
+					VolumeId:   aws.String("vol-data"),
+					VolumeType: aws.String("gp3"),
+				})).Return(&ec2.ModifyVolumeOutput{}, nil)
+			},
+			wantInProgress: true,
+		},
+		{
+			name:       "waits for a modification in progress",
+			rootVolume: &infrav1.Volume{Size: 40, Type: infrav1.VolumeTypeGP3},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				expectDescribe(m, map[string]string{"vol-root": ec2.VolumeModificationStateOptimizing})
+			},
+			wantInProgress: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			clusterScope, err := setupClusterScope(fake.NewClientBuilder().WithScheme(scheme).Build())
+			g.Expect(err).NotTo(HaveOccurred())
+
+			tc.expect(ec2Mock.EXPECT())
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			inProgress, err := s.ReconcileVolumes(instance, tc.rootVolume, tc.nonRootVolumes)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(inProgress).To(Equal(tc.wantInProgress))
+		})
+	}
+}
+
+func TestApplyVolumeDeletionPolicies(t *testing.T) {
+	instance := &infrav1.Instance{ID: "i-1", VolumeIDs: []string{"vol-root", "vol-data"}}
+	attachedVolumes := &ec2.DescribeVolumesOutput{Volumes: []*ec2.Volume{
+		{
+			VolumeId:    aws.String("vol-root"),
+			Attachments: []*ec2.VolumeAttachment{{Device: aws.String("/dev/xvda"), InstanceId: aws.String("i-1"), DeleteOnTermination: aws.Bool(true)}},
+		},
+		{
+			VolumeId:    aws.String("vol-data"),
+			Attachments: []*ec2.VolumeAttachment{{Device: aws.String("/dev/sdb"), InstanceId: aws.String("i-1"), DeleteOnTermination: aws.Bool(true)}},
+		},
+	}}
+
+	tests := []struct {
+		name            string
+		rootVolume      *infrav1.Volume
+		nonRootVolumes  []infrav1.Volume
+		snapshotIDs     []string
+		expect          func(m *mocks.MockEC2APIMockRecorder)
+		wantRetainedIDs []string
+		wantSnapshotIDs []string
+	}{
+		{
+			name:           "retains a non root volume",
+			nonRootVolumes: []infrav1.Volume{{DeviceName: "/dev/sdb", Size: 50, VolumeDeletionPolicy: infrav1.VolumeDeletionPolicyRetain}},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeVolumes(gomock.Any()).Return(attachedVolumes, nil)
+				m.ModifyInstanceAttribute(gomock.Eq(&ec2.ModifyInstanceAttributeInput{
+					InstanceId: aws.String("i-1"),
+					BlockDeviceMappings: []*ec2.InstanceBlockDeviceMappingSpecification{{
+						DeviceName: aws.String("/dev/sdb"),
+						Ebs:        &ec2.EbsInstanceBlockDeviceSpecification{DeleteOnTermination: aws.Bool(false)},
+					}},
+				})).Return(&ec2.ModifyInstanceAttributeOutput{}, nil)
+			},
+			wantRetainedIDs: []string{"vol-data"},
+		},
+		{
+			name:       "snapshots the root volume",
+			rootVolume: &infrav1.Volume{Size: 20, VolumeDeletionPolicy: infrav1.VolumeDeletionPolicySnapshot},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeVolumes(gomock.Any()).Return(attachedVolumes, nil)
+				m.DescribeInstanceAttribute(gomock.Any()).
+					Return(&ec2.DescribeInstanceAttributeOutput{RootDeviceName: &ec2.AttributeValue{Value: aws.String("/dev/xvda")}}, nil)
+				m.CreateSnapshot(gomock.AssignableToTypeOf(&ec2.CreateSnapshotInput{})).
+					Return(&ec2.Snapshot{SnapshotId: aws.String("snap-1")}, nil)
+			},
+			wantSnapshotIDs: []string{"snap-1"},
+		},
+		{
+			name:        "does not snapshot a volume twice",
+			rootVolume:  &infrav1.Volume{Size: 20, VolumeDeletionPolicy: infrav1.VolumeDeletionPolicySnapshot},
+			snapshotIDs: []string{"snap-1"},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeVolumes(gomock.Any()).Return(attachedVolumes, nil)
+				m.DescribeInstanceAttribute(gomock.Any()).
+					Return(&ec2.DescribeInstanceAttributeOutput{RootDeviceName: &ec2.AttributeValue{Value: aws.String("/dev/xvda")}}, nil)
+				m.DescribeSnapshots(gomock.Eq(&ec2.DescribeSnapshotsInput{
+					Filters: []*ec2.Filter{{Name: aws.String("snapshot-id"), Values: aws.StringSlice([]string{"snap-1"})}},
+				})).Return(&ec2.DescribeSnapshotsOutput{Snapshots: []*ec2.Snapshot{{SnapshotId: aws.String("snap-1"), VolumeId: aws.String("vol-root")}}}, nil)
+			},
+			wantSnapshotIDs: []string{"snap-1"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			cs, err := setupClusterScope(client)
+			g.Expect(err).NotTo(HaveOccurred())
+			machineScope, err := setupMachineScope(client, cs)
+			g.Expect(err).NotTo(HaveOccurred())
+			machineScope.AWSMachine.Spec.RootVolume = tc.rootVolume
+			machineScope.AWSMachine.Spec.NonRootVolumes = tc.nonRootVolumes
+			machineScope.AWSMachine.Status.VolumeSnapshotIDs = tc.snapshotIDs
+
+			tc.expect(ec2Mock.EXPECT())
+			s := NewService(machineScope.InfraCluster)
+			s.EC2Client = ec2Mock
+
+			g.Expect(s.ApplyVolumeDeletionPolicies(machineScope, instance)).To(Succeed())
+			g.Expect(machineScope.AWSMachine.Status.RetainedVolumeIDs).To(Equal(tc.wantRetainedIDs))
+			g.Expect(machineScope.AWSMachine.Status.VolumeSnapshotIDs).To(Equal(tc.wantSnapshotIDs))
+		})
+	}
+}
@@ -29,8 +29,21 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 )
 
-// Ec2StateChangeNotification defines the EC2 instance's state change notification.
-const Ec2StateChangeNotification = "EC2 Instance State-change Notification"
+const (
+	// Ec2StateChangeNotification defines the EC2 instance's state change notification.
+	Ec2StateChangeNotification = "EC2 Instance State-change Notification"
+	// Ec2SpotInstanceInterruptionWarning defines the notification sent two minutes before a spot instance is interrupted.
+	Ec2SpotInstanceInterruptionWarning = "EC2 Spot Instance Interruption Warning"
+	// Ec2InstanceRebalanceRecommendation defines the notification sent when a spot instance is at an elevated risk of interruption.
+	Ec2InstanceRebalanceRecommendation = "EC2 Instance Rebalance Recommendation"
+)
+
+// ec2EventDetailTypes are the types of the EC2 events the rule forwards to the queue.
+var ec2EventDetailTypes = []string{Ec2StateChangeNotification, Ec2SpotInstanceInterruptionWarning, Ec2InstanceRebalanceRecommendation}
+
+// ec2EventStates are the instance states the rule forwards state changes for. The spot events don't have
+// a state, so they are matched by its absence.
+var ec2EventStates = []interface{}{infrav1.InstanceStateShuttingDown, infrav1.InstanceStateTerminated, existsFilter{Exists: false}}
 
 // reconcileRules creates rules and attaches the queue as a target.
 func (s Service) reconcileRules() error {
@@ -124,9 +137,9 @@ func (s Service) reconcileRules() error {
 func (s Service) createRule() error {
 	eventPattern := eventPattern{
 		Source:     []string{"aws.ec2"},
-		DetailType: []string{Ec2StateChangeNotification},
+		DetailType: ec2EventDetailTypes,
 		EventDetail: &eventDetail{
-			States: []infrav1.InstanceState{infrav1.InstanceStateShuttingDown, infrav1.InstanceStateTerminated},
+			States: ec2EventStates,
 		},
 	}
 	data, err := json.Marshal(eventPattern)
@@ -176,7 +189,7 @@ func (s Service) AddInstanceToEventPattern(instanceID string) error {
 	if err != nil {
 		return err
 	}
-	e.DetailType = []string{Ec2StateChangeNotification}
+	setEventPatternDetailTypes(&e)
 
 	for _, r := range e.EventDetail.InstanceIDs {
 		if r == instanceID {
@@ -212,7 +225,7 @@ func (s Service) RemoveInstanceFromEventPattern(instanceID string) {
 	if err != nil {
 		return
 	}
-	e.DetailType = []string{Ec2StateChangeNotification}
+	setEventPatternDetailTypes(&e)
 
 	found := false
 	for i, r := range e.EventDetail.InstanceIDs {
@@ -241,6 +254,16 @@ func (s Service) RemoveInstanceFromEventPattern(instanceID string) {
 	}
 }
 
+// setEventPatternDetailTypes makes the event pattern match all the EC2 events of the tracked instances,
+// including the spot events of rules created before they were tracked.
+func setEventPatternDetailTypes(e *eventPattern) {
+	e.DetailType = ec2EventDetailTypes
+	if e.EventDetail == nil {
+		e.EventDetail = &eventDetail{}
+	}
+	e.EventDetail.States = ec2EventStates
+}
+
 func (s Service) getEC2RuleName() string {
 	return fmt.Sprintf("%s-ec2-rule", s.scope.Name())
 }
@@ -259,6 +282,10 @@ type eventPattern struct {
 }
 
 type eventDetail struct {
-	InstanceIDs []string                `json:"instance-id,omitempty"`
-	States      []infrav1.InstanceState `json:"state,omitempty"`
+	InstanceIDs []string      `json:"instance-id,omitempty"`
+	States      []interface{} `json:"state,omitempty"`
+}
+
+type existsFilter struct {
+	Exists bool `json:"exists"`
 }
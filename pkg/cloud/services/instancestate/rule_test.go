@@ -51,21 +51,13 @@ func TestReconcileRules(t *testing.T) {
 				m.DescribeRule(gomock.Eq(&eventbridge.DescribeRuleInput{
 					Name: aws.String(ruleName),
 				})).Return(nil, awserr.New(eventbridge.ErrCodeResourceNotFoundException, "", nil))
-				e := &eventPattern{
-					Source:     []string{"aws.ec2"},
-					DetailType: []string{Ec2StateChangeNotification},
-					EventDetail: &eventDetail{
-						States: []infrav1.InstanceState{infrav1.InstanceStateShuttingDown, infrav1.InstanceStateTerminated},
-					},
-				}
-				data, err := json.Marshal(e)
-				if err != nil {
-					t.Fatalf("got an unexpected error: %v", err)
-				}
+				data := `{"source":["aws.ec2"],` +
+					`"detail-type":["EC2 Instance State-change Notification","EC2 Spot Instance Interruption Warning","EC2 Instance Rebalance Recommendation"],` +
+					`"detail":{"state":["shutting-down","terminated",{"exists":false}]}}`
 				m.PutRule(gomock.Eq(&eventbridge.PutRuleInput{
 					Name:         aws.String(ruleName),
 					State:        aws.String(eventbridge.RuleStateDisabled),
-					EventPattern: aws.String(string(data)),
+					EventPattern: aws.String(data),
 				}))
 			},
 			postCreateEventBridgeExpect: func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder) {
@@ -237,10 +229,11 @@ func TestAddInstanceToRule(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 	pattern := eventPattern{
-		DetailType: []string{Ec2StateChangeNotification},
+		DetailType: ec2EventDetailTypes,
 		Source:     []string{"aws.ec2"},
 		EventDetail: &eventDetail{
 			InstanceIDs: []string{"instance-a"},
+			States:      ec2EventStates,
 		},
 	}
 	patternData, err := json.Marshal(pattern)
@@ -289,6 +282,46 @@ func TestAddInstanceToRule(t *testing.T) {
 			newInstanceID: "instance-a",
 			expectErr:     false,
 		},
+		{
+			name: "tracks spot events of instances in rules only tracking state changes",
+			eventBridgeExpect: func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder) {
+				stateChangePattern := eventPattern{
+					DetailType: []string{Ec2StateChangeNotification},
+					Source:     []string{"aws.ec2"},
+					EventDetail: &eventDetail{
+						InstanceIDs: []string{"instance-a"},
+						States:      []interface{}{infrav1.InstanceStateShuttingDown, infrav1.InstanceStateTerminated},
+					},
+				}
+				stateChangePatternData, err := json.Marshal(stateChangePattern)
+				if err != nil {
+					t.Fatalf("got an unexpected error: %v", err)
+				}
+				m.DescribeRule(&eventbridge.DescribeRuleInput{
+					Name: aws.String("test-cluster-ec2-rule"),
+				}).Return(&eventbridge.DescribeRuleOutput{
+					EventPattern: aws.String(string(stateChangePatternData)),
+				}, nil)
+				expectedData, err := json.Marshal(eventPattern{
+					DetailType: ec2EventDetailTypes,
+					Source:     []string{"aws.ec2"},
+					EventDetail: &eventDetail{
+						InstanceIDs: []string{"instance-a", "instance-c"},
+						States:      ec2EventStates,
+					},
+				})
+				if err != nil {
+					t.Fatalf("got an unexpected error: %v", err)
+				}
+				m.PutRule(&eventbridge.PutRuleInput{
+					Name:         aws.String("test-cluster-ec2-rule"),
+					EventPattern: aws.String(string(expectedData)),
+					State:        aws.String(eventbridge.RuleStateEnabled),
+				}).Return(nil, nil)
+			},
+			newInstanceID: "instance-c",
+			expectErr:     false,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -316,10 +349,11 @@ func TestRemoveInstanceStateFromEventPattern(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 	pattern := eventPattern{
-		DetailType: []string{Ec2StateChangeNotification},
+		DetailType: ec2EventDetailTypes,
 		Source:     []string{"aws.ec2"},
 		EventDetail: &eventDetail{
 			InstanceIDs: []string{"instance-a", "instance-b", "instance-c"},
+			States:      ec2EventStates,
 		},
 	}
 	patternData, err := json.Marshal(pattern)
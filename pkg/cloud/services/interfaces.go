@@ -17,9 +17,12 @@ limitations under the License.
 package services
 
 import (
+	"time"
+
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
 const (
@@ -40,10 +43,21 @@ type ASGInterface interface {
 	UpdateASG(scope *scope.MachinePoolScope) error
 	StartASGInstanceRefresh(scope *scope.MachinePoolScope) error
 	CanStartASGInstanceRefresh(scope *scope.MachinePoolScope) (bool, error)
+	GetLatestASGInstanceRefresh(name string) (*expinfrav1.AWSMachinePoolInstanceRefreshStatus, error)
+	CancelASGInstanceRefresh(name string) error
 	UpdateResourceTags(resourceID *string, create, remove map[string]string) error
 	DeleteASGAndWait(id string) error
 	SuspendProcesses(name string, processes []string) error
 	ResumeProcesses(name string, processes []string) error
+	PutWarmPool(name string, warmPool *expinfrav1.WarmPool) error
+	DeleteWarmPool(name string) error
+	DescribeLifecycleHooks(asgName string) ([]*expinfrav1.AWSLifecycleHook, error)
+	PutLifecycleHook(asgName string, hook *expinfrav1.AWSLifecycleHook) error
+	DeleteLifecycleHook(asgName, hookName string) error
+	CompleteLifecycleAction(asgName, hookName, instanceID string) error
+	DescribeScheduledActions(asgName string) ([]*expinfrav1.AWSScheduledAction, error)
+	PutScheduledAction(asgName string, action *expinfrav1.AWSScheduledAction) error
+	DeleteScheduledAction(asgName, actionName string) error
 }
 
 // EC2Interface encapsulates the methods exposed to the machine
@@ -63,6 +77,13 @@ type EC2Interface interface {
 	TerminateInstanceAndWait(instanceID string) error
 	DetachSecurityGroupsFromNetworkInterface(groups []string, interfaceID string) error
 
+	ReconcileElasticIP(scope *scope.MachineScope, instance *infrav1.Instance) error
+	ReleaseElasticIP(scope *scope.MachineScope) error
+	ReconcileVolumes(instance *infrav1.Instance, rootVolume *infrav1.Volume, nonRootVolumes []infrav1.Volume) (bool, error)
+	ApplyVolumeDeletionPolicies(scope *scope.MachineScope, instance *infrav1.Instance) error
+	ReconcileInstanceStatus(scope *scope.MachineScope, instanceID string) error
+	ReconcileAMIDeprecation(obj conditions.Setter, imageID string) error
+
 	ReconcileLaunchTemplate(scope scope.LaunchTemplateScope, canUpdateLaunchTemplate func() (bool, error), runPostLaunchTemplateUpdateOperation func() error) error
 	ReconcileTags(scope scope.LaunchTemplateScope, resourceServicesToUpdate []scope.ResourceServiceToUpdate) error
 
@@ -73,6 +94,7 @@ type EC2Interface interface {
 	CreateLaunchTemplate(scope scope.LaunchTemplateScope, imageID *string, userData []byte) (string, error)
 	CreateLaunchTemplateVersion(id string, scope scope.LaunchTemplateScope, imageID *string, userData []byte) error
 	PruneLaunchTemplateVersions(id string) error
+	RollbackLaunchTemplateVersion(id string) (string, error)
 	DeleteLaunchTemplate(id string) error
 	LaunchTemplateNeedsUpdate(scope scope.LaunchTemplateScope, incoming *expinfrav1.AWSLaunchTemplate, existing *expinfrav1.AWSLaunchTemplate) (bool, error)
 	DeleteBastion() error
@@ -84,7 +106,8 @@ type EC2Interface interface {
 type SecretInterface interface {
 	Delete(m *scope.MachineScope) error
 	Create(m *scope.MachineScope, data []byte) (string, int32, error)
-	UserData(secretPrefix string, chunks int32, region string, endpoints []scope.ServiceEndpoint) ([]byte, error)
+	UserData(secretPrefix string, chunks int32, region string, kmsKeyID string, endpoints []scope.ServiceEndpoint) ([]byte, error)
+	DeleteOrphanedSecrets(inUsePrefixes []string, createdBefore time.Time) ([]string, error)
 }
 
 // ELBInterface encapsulates the methods exposed to the cluster and machine
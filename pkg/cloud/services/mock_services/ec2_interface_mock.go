@@ -27,6 +27,7 @@ import (
 	v1beta2 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	v1beta20 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
 	scope "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	conditions "sigs.k8s.io/cluster-api/util/conditions"
 )
 
 // MockEC2Interface is a mock of EC2Interface interface.
@@ -52,6 +53,20 @@ func (m *MockEC2Interface) EXPECT() *MockEC2InterfaceMockRecorder {
 	return m.recorder
 }
 
+// ApplyVolumeDeletionPolicies mocks base method.
+func (m *MockEC2Interface) ApplyVolumeDeletionPolicies(arg0 *scope.MachineScope, arg1 *v1beta2.Instance) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyVolumeDeletionPolicies", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyVolumeDeletionPolicies indicates an expected call of ApplyVolumeDeletionPolicies.
+func (mr *MockEC2InterfaceMockRecorder) ApplyVolumeDeletionPolicies(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyVolumeDeletionPolicies", reflect.TypeOf((*MockEC2Interface)(nil).ApplyVolumeDeletionPolicies), arg0, arg1)
+}
+
 // CreateInstance mocks base method.
 func (m *MockEC2Interface) CreateInstance(arg0 *scope.MachineScope, arg1 []byte, arg2 string) (*v1beta2.Instance, error) {
 	m.ctrl.T.Helper()
@@ -303,6 +318,20 @@ func (mr *MockEC2InterfaceMockRecorder) PruneLaunchTemplateVersions(arg0 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneLaunchTemplateVersions", reflect.TypeOf((*MockEC2Interface)(nil).PruneLaunchTemplateVersions), arg0)
 }
 
+// ReconcileAMIDeprecation mocks base method.
+func (m *MockEC2Interface) ReconcileAMIDeprecation(arg0 conditions.Setter, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileAMIDeprecation", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReconcileAMIDeprecation indicates an expected call of ReconcileAMIDeprecation.
+func (mr *MockEC2InterfaceMockRecorder) ReconcileAMIDeprecation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileAMIDeprecation", reflect.TypeOf((*MockEC2Interface)(nil).ReconcileAMIDeprecation), arg0, arg1)
+}
+
 // ReconcileBastion mocks base method.
 func (m *MockEC2Interface) ReconcileBastion() error {
 	m.ctrl.T.Helper()
@@ -317,6 +346,34 @@ func (mr *MockEC2InterfaceMockRecorder) ReconcileBastion() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileBastion", reflect.TypeOf((*MockEC2Interface)(nil).ReconcileBastion))
 }
 
+// ReconcileElasticIP mocks base method.
+func (m *MockEC2Interface) ReconcileElasticIP(arg0 *scope.MachineScope, arg1 *v1beta2.Instance) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileElasticIP", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReconcileElasticIP indicates an expected call of ReconcileElasticIP.
+func (mr *MockEC2InterfaceMockRecorder) ReconcileElasticIP(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileElasticIP", reflect.TypeOf((*MockEC2Interface)(nil).ReconcileElasticIP), arg0, arg1)
+}
+
+// ReconcileInstanceStatus mocks base method.
+func (m *MockEC2Interface) ReconcileInstanceStatus(arg0 *scope.MachineScope, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileInstanceStatus", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReconcileInstanceStatus indicates an expected call of ReconcileInstanceStatus.
+func (mr *MockEC2InterfaceMockRecorder) ReconcileInstanceStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileInstanceStatus", reflect.TypeOf((*MockEC2Interface)(nil).ReconcileInstanceStatus), arg0, arg1)
+}
+
 // ReconcileLaunchTemplate mocks base method.
 func (m *MockEC2Interface) ReconcileLaunchTemplate(arg0 scope.LaunchTemplateScope, arg1 func() (bool, error), arg2 func() error) error {
 	m.ctrl.T.Helper()
@@ -345,6 +402,50 @@ func (mr *MockEC2InterfaceMockRecorder) ReconcileTags(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileTags", reflect.TypeOf((*MockEC2Interface)(nil).ReconcileTags), arg0, arg1)
 }
 
+// ReconcileVolumes mocks base method.
+func (m *MockEC2Interface) ReconcileVolumes(arg0 *v1beta2.Instance, arg1 *v1beta2.Volume, arg2 []v1beta2.Volume) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileVolumes", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReconcileVolumes indicates an expected call of ReconcileVolumes.
+func (mr *MockEC2InterfaceMockRecorder) ReconcileVolumes(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileVolumes", reflect.TypeOf((*MockEC2Interface)(nil).ReconcileVolumes), arg0, arg1, arg2)
+}
+
+// ReleaseElasticIP mocks base method.
+func (m *MockEC2Interface) ReleaseElasticIP(arg0 *scope.MachineScope) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseElasticIP", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseElasticIP indicates an expected call of ReleaseElasticIP.
+func (mr *MockEC2InterfaceMockRecorder) ReleaseElasticIP(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseElasticIP", reflect.TypeOf((*MockEC2Interface)(nil).ReleaseElasticIP), arg0)
+}
+
+// RollbackLaunchTemplateVersion mocks base method.
+func (m *MockEC2Interface) RollbackLaunchTemplateVersion(arg0 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RollbackLaunchTemplateVersion", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RollbackLaunchTemplateVersion indicates an expected call of RollbackLaunchTemplateVersion.
+func (mr *MockEC2InterfaceMockRecorder) RollbackLaunchTemplateVersion(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackLaunchTemplateVersion", reflect.TypeOf((*MockEC2Interface)(nil).RollbackLaunchTemplateVersion), arg0)
+}
+
 // TerminateInstance mocks base method.
 func (m *MockEC2Interface) TerminateInstance(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -135,9 +135,22 @@ func (s *Service) reconcileSubnets() error {
 				}
 			}
 
+			// Make sure the private DNS name options of managed subnets are up-to-date.
+			if !unmanagedVPC {
+				if options := privateDNSNameOptionsToUpdate(sub.PrivateDNSNameOptions, existingSubnet.PrivateDNSNameOptions); options != nil {
+					if err := s.modifySubnetPrivateDNSNameOptions(existingSubnet.ID, options); err != nil {
+						record.Warnf(s.scope.InfraCluster(), "FailedModifySubnetAttributes", "Failed modifying managed Subnet %q attributes: %v", existingSubnet.ID, err)
+						return errors.Wrapf(err, "failed to set subnet %q private DNS name options", existingSubnet.ID)
+					}
+					record.Eventf(s.scope.InfraCluster(), "SuccessfulModifySubnetAttributes", "Modified managed Subnet %q attributes", existingSubnet.ID)
+				}
+			}
+
 			// Update subnet spec with the existing subnet details
 			// TODO(vincepri): check if subnet needs to be updated.
+			privateDNSNameOptions := sub.PrivateDNSNameOptions
 			existingSubnet.DeepCopyInto(sub)
+			sub.PrivateDNSNameOptions = privateDNSNameOptions
 		} else if unmanagedVPC {
 			// If there is no existing subnet and we have an umanaged vpc report an error
 			record.Warnf(s.scope.InfraCluster(), "FailedMatchSubnet", "Using unmanaged VPC and failed to find existing subnet for specified subnet id %d, cidr %q", sub.ID, sub.CidrBlock)
@@ -320,6 +333,13 @@ func (s *Service) describeVpcSubnets() (infrav1.Subnets, error) {
 			AvailabilityZone: *ec2sn.AvailabilityZone,
 			Tags:             converters.TagsToMap(ec2sn.Tags),
 		}
+		if options := ec2sn.PrivateDnsNameOptionsOnLaunch; options != nil {
+			spec.PrivateDNSNameOptions = &infrav1.PrivateDNSNameOptions{
+				HostnameType:                    options.HostnameType,
+				EnableResourceNameDNSARecord:    options.EnableResourceNameDnsARecord,
+				EnableResourceNameDNSAAAARecord: options.EnableResourceNameDnsAAAARecord,
+			}
+		}
 		// For IPv6 subnets, both, ipv4 and 6 have to be defined so pods can have ipv6 cidr ranges.
 		spec.CidrBlock = aws.StringValue(ec2sn.CidrBlock)
 		for _, set := range ec2sn.Ipv6CidrBlockAssociationSet {
@@ -449,11 +469,20 @@ func (s *Service) createSubnet(sn *infrav1.SubnetSpec) (*infrav1.SubnetSpec, err
 		record.Eventf(s.scope.InfraCluster(), "SuccessfulModifySubnetAttributes", "Modified managed Subnet %q attributes", *out.Subnet.SubnetId)
 	}
 
+	if sn.PrivateDNSNameOptions != nil {
+		if err := s.modifySubnetPrivateDNSNameOptions(*out.Subnet.SubnetId, sn.PrivateDNSNameOptions); err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedModifySubnetAttributes", "Failed modifying managed Subnet %q attributes: %v", *out.Subnet.SubnetId, err)
+			return nil, errors.Wrapf(err, "failed to set subnet %q private DNS name options", *out.Subnet.SubnetId)
+		}
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulModifySubnetAttributes", "Modified managed Subnet %q attributes", *out.Subnet.SubnetId)
+	}
+
 	subnet := &infrav1.SubnetSpec{
-		ID:               *out.Subnet.SubnetId,
-		AvailabilityZone: *out.Subnet.AvailabilityZone,
-		CidrBlock:        *out.Subnet.CidrBlock, // TODO: this will panic in case of IPv6 only subnets...
-		IsPublic:         sn.IsPublic,
+		ID:                    *out.Subnet.SubnetId,
+		AvailabilityZone:      *out.Subnet.AvailabilityZone,
+		CidrBlock:             *out.Subnet.CidrBlock, // TODO: this will panic in case of IPv6 only subnets...
+		IsPublic:              sn.IsPublic,
+		PrivateDNSNameOptions: sn.PrivateDNSNameOptions,
 	}
 	for _, set := range out.Subnet.Ipv6CidrBlockAssociationSet {
 		if *set.Ipv6CidrBlockState.State == ec2.SubnetCidrBlockStateCodeAssociated {
@@ -472,6 +501,74 @@ func (s *Service) createSubnet(sn *infrav1.SubnetSpec) (*infrav1.SubnetSpec, err
 	return subnet, nil
 }
 
+// modifySubnetPrivateDNSNameOptions sets the hostname type and the DNS records of the instances launched in the subnet.
+func (s *Service) modifySubnetPrivateDNSNameOptions(subnetID string, options *infrav1.PrivateDNSNameOptions) error {
+	// Only one subnet attribute can be modified at a time.
+	inputs := []*ec2.ModifySubnetAttributeInput{}
+	if options.HostnameType != nil {
+		inputs = append(inputs, &ec2.ModifySubnetAttributeInput{
+			SubnetId:                       aws.String(subnetID),
+			PrivateDnsHostnameTypeOnLaunch: options.HostnameType,
+		})
+	}
+	if options.EnableResourceNameDNSARecord != nil {
+		inputs = append(inputs, &ec2.ModifySubnetAttributeInput{
+			SubnetId:                             aws.String(subnetID),
+			EnableResourceNameDnsARecordOnLaunch: &ec2.AttributeBooleanValue{Value: options.EnableResourceNameDNSARecord},
+		})
+	}
+	if options.EnableResourceNameDNSAAAARecord != nil {
+		inputs = append(inputs, &ec2.ModifySubnetAttributeInput{
+			SubnetId:                                aws.String(subnetID),
+			EnableResourceNameDnsAAAARecordOnLaunch: &ec2.AttributeBooleanValue{Value: options.EnableResourceNameDNSAAAARecord},
+		})
+	}
+
+	for _, input := range inputs {
+		input := input
+		if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
+			if _, err := s.EC2Client.ModifySubnetAttribute(input); err != nil {
+				return false, err
+			}
+			return true, nil
+		}, awserrors.SubnetNotFound); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// privateDNSNameOptionsToUpdate returns the desired private DNS name options that differ from the existing ones of a
+// subnet, or nil if they are all up-to-date.
+func privateDNSNameOptionsToUpdate(desired, existing *infrav1.PrivateDNSNameOptions) *infrav1.PrivateDNSNameOptions {
+	if desired == nil {
+		return nil
+	}
+	if existing == nil {
+		existing = &infrav1.PrivateDNSNameOptions{}
+	}
+
+	options := &infrav1.PrivateDNSNameOptions{}
+	updated := false
+	if desired.HostnameType != nil && aws.StringValue(desired.HostnameType) != aws.StringValue(existing.HostnameType) {
+		options.HostnameType = desired.HostnameType
+		updated = true
+	}
+	if desired.EnableResourceNameDNSARecord != nil && aws.BoolValue(desired.EnableResourceNameDNSARecord) != aws.BoolValue(existing.EnableResourceNameDNSARecord) {
+		options.EnableResourceNameDNSARecord = desired.EnableResourceNameDNSARecord
+		updated = true
+	}
+	if desired.EnableResourceNameDNSAAAARecord != nil && aws.BoolValue(desired.EnableResourceNameDNSAAAARecord) != aws.BoolValue(existing.EnableResourceNameDNSAAAARecord) {
+		options.EnableResourceNameDNSAAAARecord = desired.EnableResourceNameDNSAAAARecord
+		updated = true
+	}
+	if !updated {
+		return nil
+	}
+	return options
+}
+
 func (s *Service) deleteSubnet(id string) error {
 	_, err := s.EC2Client.DeleteSubnet(&ec2.DeleteSubnetInput{
 		SubnetId: aws.String(id),
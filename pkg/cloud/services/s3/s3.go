@@ -67,6 +67,11 @@ func (s *Service) ReconcileBucket() error {
 		return errors.Wrap(err, "ensuring bucket exists")
 	}
 
+	// Instances fetch their bootstrap data through pre-signed URLs instead of being granted read access to the bucket.
+	if s.presignedURLsEnabled() {
+		return nil
+	}
+
 	if err := s.ensureBucketPolicy(bucketName); err != nil {
 		return errors.Wrap(err, "ensuring bucket policy")
 	}
@@ -127,15 +132,24 @@ func (s *Service) Create(m *scope.MachineScope, data []byte) (string, error) {
 
 	s.scope.Info("Creating object", "bucket_name", bucket, "key", key)
 
-	if _, err := s.S3Client.PutObject(&s3.PutObjectInput{
+	input := &s3.PutObjectInput{
 		Body:                 aws.ReadSeekCloser(bytes.NewReader(data)),
 		Bucket:               aws.String(bucket),
 		Key:                  aws.String(key),
 		ServerSideEncryption: aws.String("aws:kms"),
-	}); err != nil {
+	}
+	if kmsKeyID := s.scope.Bucket().KMSKeyID; kmsKeyID != "" {
+		input.SSEKMSKeyId = aws.String(kmsKeyID)
+	}
+
+	if _, err := s.S3Client.PutObject(input); err != nil {
 		return "", errors.Wrap(err, "putting object")
 	}
 
+	if s.presignedURLsEnabled() {
+		return s.presignedURL(bucket, key)
+	}
+
 	objectURL := &url.URL{
 		Scheme: "s3",
 		Host:   bucket,
@@ -278,6 +292,25 @@ func (s *Service) bucketManagementEnabled() bool {
 	return s.scope.Bucket() != nil
 }
 
+func (s *Service) presignedURLsEnabled() bool {
+	return s.scope.Bucket().PresignedURLDuration != nil
+}
+
+// presignedURL returns a pre-signed URL to get the object, which is valid for the configured duration.
+func (s *Service) presignedURL(bucket, key string) (string, error) {
+	req, _ := s.S3Client.GetObjectRequest(&s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+
+	presignedURL, err := req.Presign(s.scope.Bucket().PresignedURLDuration.Duration)
+	if err != nil {
+		return "", errors.Wrap(err, "pre-signing object URL")
+	}
+
+	return presignedURL, nil
+}
+
 func (s *Service) bucketName() string {
 	return s.scope.Bucket().Name
 }
@@ -24,9 +24,12 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
 	s3svc "github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/golang/mock/gomock"
@@ -196,6 +199,22 @@ func TestReconcile_bucket(t *testing.T) {
 		}
 	})
 
+	t.Run("skips_bucket_policy_when_bootstrap_data_is_delivered_through_presigned_urls", func(t *testing.T) {
+		t.Parallel()
+
+		svc, s3Mock := testService(t, &infrav1.S3Bucket{
+			Name:                 "foo",
+			PresignedURLDuration: &metav1.Duration{Duration: time.Hour},
+		})
+
+		s3Mock.EXPECT().CreateBucket(gomock.Any()).Return(nil, nil).Times(1)
+		s3Mock.EXPECT().PutBucketPolicy(gomock.Any()).Times(0)
+
+		if err := svc.ReconcileBucket(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+
 	t.Run("ignores_when_bucket_already_exists_but_its_owned_by_the_same_account", func(t *testing.T) {
 		t.Parallel()
 
@@ -444,6 +463,60 @@ func TestCreate_object(t *testing.T) {
 		})
 	})
 
+	t.Run("for_machine_with_kms_key_and_presigned_urls", func(t *testing.T) {
+		t.Parallel()
+
+		svc, s3Mock := testService(t, &infrav1.S3Bucket{
+			Name:                 bucketName,
+			KMSKeyID:             "alias/bootstrap",
+			PresignedURLDuration: &metav1.Duration{Duration: 10 * time.Minute},
+		})
+
+		machineScope := &scope.MachineScope{
+			Machine: &clusterv1.Machine{},
+			AWSMachine: &infrav1.AWSMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: nodeName,
+				},
+			},
+		}
+
+		s3Mock.EXPECT().PutObject(gomock.Any()).Do(func(putObjectInput *s3svc.PutObjectInput) {
+			if aws.StringValue(putObjectInput.SSEKMSKeyId) != "alias/bootstrap" {
+				t.Errorf("Expected object to be encrypted with KMS key %q, got %q", "alias/bootstrap", aws.StringValue(putObjectInput.SSEKMSKeyId))
+			}
+		}).Return(nil, nil).Times(1)
+
+		// Pre-signing happens locally, so a real client can build the request.
+		s3Client := s3svc.New(session.Must(session.NewSession(&aws.Config{
+			Region:      aws.String("us-east-1"),
+			Credentials: credentials.NewStaticCredentials("AKID", "SECRET", ""),
+		})))
+		s3Mock.EXPECT().GetObjectRequest(gomock.Any()).DoAndReturn(s3Client.GetObjectRequest).Times(1)
+
+		bootstrapDataURL, err := svc.Create(machineScope, []byte("foobar"))
+		if err != nil {
+			t.Fatalf("Unexpected error, got: %v", err)
+		}
+
+		parsedURL, err := url.Parse(bootstrapDataURL)
+		if err != nil {
+			t.Fatalf("Parsing URL %q: %v", bootstrapDataURL, err)
+		}
+
+		if parsedURL.Scheme != "https" {
+			t.Errorf("Unexpected URL scheme, expected %q, got %q", "https", parsedURL.Scheme)
+		}
+
+		if !strings.HasSuffix(parsedURL.Path, nodeName) {
+			t.Errorf("URL Path should end with node name %q, got: %q", nodeName, parsedURL.Path)
+		}
+
+		if expires := parsedURL.Query().Get("X-Amz-Expires"); expires != "600" {
+			t.Errorf("Expected URL to expire in 600 seconds, got %q", expires)
+		}
+	})
+
 	t.Run("is_idempotent", func(t *testing.T) {
 		t.Parallel()
 
@@ -19,18 +19,24 @@ package secretsmanager
 import (
 	"fmt"
 	"path"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/pkg/errors"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/uuid"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/metrics"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/wait"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/internal/bytes"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
 )
 
 const (
@@ -54,6 +60,7 @@ func (s *Service) Create(m *scope.MachineScope, data []byte) (string, int32, err
 	// Build the tags to apply to the secret.
 	additionalTags := m.AdditionalTags()
 	additionalTags[infrav1.ClusterAWSCloudProviderTagKey(s.scope.Name())] = string(infrav1.ResourceLifecycleOwned)
+	additionalTags[infrav1.NameAWSClusterNamespace] = s.scope.Namespace()
 	tags := infrav1.Build(infrav1.BuildParams{
 		ClusterName: s.scope.Name(),
 		Lifecycle:   infrav1.ResourceLifecycleOwned,
@@ -72,7 +79,7 @@ func (s *Service) Create(m *scope.MachineScope, data []byte) (string, int32, err
 	var err error
 	bytes.Split(data, false, maxSecretSizeBytes, func(chunk []byte) {
 		name := fmt.Sprintf("%s-%d", prefix, chunks)
-		retryFunc := func() (bool, error) { return s.retryableCreateSecret(name, chunk, tags) }
+		retryFunc := func() (bool, error) { return s.retryableCreateSecret(name, chunk, m.SecureSecretsKMSKeyID(), tags) }
 		// Default timeout is 5 mins, but if Secrets Manager has got to the state where the timeout is reached,
 		// makes sense to slow down machine creation until AWS weather improves.
 		if err = wait.WaitForWithRetryable(wait.NewBackoff(), retryFunc, retryableErrors...); err != nil {
@@ -85,12 +92,16 @@ func (s *Service) Create(m *scope.MachineScope, data []byte) (string, int32, err
 }
 
 // retryableCreateSecret is a function to be passed into a waiter. In a separate function for ease of reading.
-func (s *Service) retryableCreateSecret(name string, chunk []byte, tags infrav1.Tags) (bool, error) {
-	_, err := s.SecretsManagerClient.CreateSecret(&secretsmanager.CreateSecretInput{
+func (s *Service) retryableCreateSecret(name string, chunk []byte, kmsKeyID string, tags infrav1.Tags) (bool, error) {
+	input := &secretsmanager.CreateSecretInput{
 		Name:         aws.String(name),
 		SecretBinary: chunk,
 		Tags:         converters.MapToSecretsManagerTags(tags),
-	})
+	}
+	if kmsKeyID != "" {
+		input.KmsKeyId = aws.String(kmsKeyID)
+	}
+	_, err := s.SecretsManagerClient.CreateSecret(input)
 	// If the secret already exists, delete it, return request to retry, as deletes are eventually consistent
 	if awserrors.IsResourceExists(err) {
 		return false, s.forceDeleteSecretEntry(name)
@@ -124,3 +135,64 @@ func (s *Service) Delete(m *scope.MachineScope) error {
 
 	return kerrors.NewAggregate(errs)
 }
+
+// DeleteOrphanedSecrets deletes the bootstrap secrets owned by the cluster that were created before the given time and
+// whose prefix isn't one of the given prefixes still in use by machines. Only the secrets tagged with the namespace of
+// the cluster are considered, as the prefixes in use are those of the machines in that namespace. The names of the
+// deleted secrets are returned.
+func (s *Service) DeleteOrphanedSecrets(inUsePrefixes []string, createdBefore time.Time) ([]string, error) {
+	clusterTagKey := infrav1.ClusterTagKey(s.scope.Name())
+	inUse := sets.NewString(inUsePrefixes...)
+
+	var orphaned []string
+	input := &secretsmanager.ListSecretsInput{
+		Filters: []*secretsmanager.Filter{
+			{
+				Key:    aws.String(secretsmanager.FilterNameStringTypeName),
+				Values: aws.StringSlice([]string{entryPrefix + "/"}),
+			},
+			{
+				Key:    aws.String(secretsmanager.FilterNameStringTypeTagKey),
+				Values: aws.StringSlice([]string{clusterTagKey}),
+			},
+		},
+	}
+	err := s.SecretsManagerClient.ListSecretsPages(input, func(out *secretsmanager.ListSecretsOutput, _ bool) bool {
+		for _, secret := range out.SecretList {
+			// Filters match on prefixes, so the cluster tag has to be checked again to skip the secrets of clusters
+			// whose name starts with the name of this cluster.
+			tags := converters.SecretsManagerTagsToMap(secret.Tags)
+			if tags[clusterTagKey] != string(infrav1.ResourceLifecycleOwned) || tags[infrav1.NameAWSClusterNamespace] != s.scope.Namespace() {
+				continue
+			}
+			if !aws.TimeValue(secret.CreatedDate).Before(createdBefore) {
+				continue
+			}
+			name := aws.StringValue(secret.Name)
+			i := strings.LastIndex(name, "-")
+			if i < 0 || inUse.Has(name[:i]) {
+				continue
+			}
+			orphaned = append(orphaned, name)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list bootstrap secrets")
+	}
+
+	var deleted []string
+	var errs []error
+	for _, name := range orphaned {
+		if err := s.forceDeleteSecretEntry(name); err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedDeleteOrphanedSecret", "Failed to delete orphaned bootstrap secret %q: %v", name, err)
+			errs = append(errs, err)
+			continue
+		}
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulDeleteOrphanedSecret", "Deleted orphaned bootstrap secret %q", name)
+		deleted = append(deleted, name)
+	}
+	metrics.RecordOrphanedSecretsDeleted(string(infrav1.SecretBackendSecretsManager), len(deleted))
+
+	return deleted, kerrors.NewAggregate(errs)
+}
@@ -20,6 +20,7 @@ import (
 	"math/rand"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/secretsmanager"
@@ -63,6 +64,10 @@ func TestServiceCreate(t *testing.T) {
 			Key:   aws.String("kubernetes.io/cluster/test"),
 			Value: aws.String("owned"),
 		},
+		{
+			Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/v2/cluster-namespace"),
+			Value: aws.String("default"),
+		},
 		{
 			Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/v2/cluster/test"),
 			Value: aws.String("owned"),
@@ -86,6 +91,7 @@ func TestServiceCreate(t *testing.T) {
 		name           string
 		bytesCount     int64
 		secretPrefix   string
+		kmsKeyID       string
 		expectedPrefix string
 		wantErr        bool
 		expect         func(g *WithT, m *mock_secretsmanageriface.MockSecretsManagerAPIMockRecorder)
@@ -116,6 +122,21 @@ func TestServiceCreate(t *testing.T) {
 				)
 			},
 		},
+		{
+			name:           "Should encrypt data with the customer managed KMS key if one is set",
+			bytesCount:     10,
+			secretPrefix:   "prefix",
+			kmsKeyID:       "alias/userdata",
+			expectedPrefix: "prefix",
+			wantErr:        false,
+			expect: func(g *WithT, m *mock_secretsmanageriface.MockSecretsManagerAPIMockRecorder) {
+				m.CreateSecret(gomock.AssignableToTypeOf(&secretsmanager.CreateSecretInput{})).Return(&secretsmanager.CreateSecretOutput{}, nil).Do(
+					func(createSecretInput *secretsmanager.CreateSecretInput) {
+						g.Expect(createSecretInput.KmsKeyId).To(Equal(aws.String("alias/userdata")))
+					},
+				)
+			},
+		},
 		{
 			name:           "Should not retry if non-retryable error occurred while storing data in secret manager",
 			bytesCount:     10,
@@ -173,6 +194,7 @@ func TestServiceCreate(t *testing.T) {
 			ms, err := getMachineScope(client, clusterScope)
 			g.Expect(err).NotTo(HaveOccurred())
 			ms.SetSecretPrefix(tt.secretPrefix)
+			ms.AWSMachine.Spec.CloudInit.KMSKeyID = tt.kmsKeyID
 			data := generateBytes(g, tt.bytesCount)
 
 			prefix, _, err := s.Create(ms, data)
@@ -258,6 +280,126 @@ func TestServiceDelete(t *testing.T) {
 	}
 }
 
+func TestServiceDeleteOrphanedSecrets(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	now := time.Now()
+	createdBefore := now.Add(-time.Hour)
+	clusterTags := []*secretsmanager.Tag{
+		{Key: aws.String(infrav1.ClusterTagKey("test")), Value: aws.String("owned")},
+		{Key: aws.String(infrav1.NameAWSClusterNamespace), Value: aws.String("default")},
+	}
+	listInput := &secretsmanager.ListSecretsInput{
+		Filters: []*secretsmanager.Filter{
+			{
+				Key:    aws.String("name"),
+				Values: aws.StringSlice([]string{"aws.cluster.x-k8s.io/"}),
+			},
+			{
+				Key:    aws.String("tag-key"),
+				Values: aws.StringSlice([]string{infrav1.ClusterTagKey("test")}),
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		secrets []*secretsmanager.SecretListEntry
+		expect  func(m *mock_secretsmanageriface.MockSecretsManagerAPIMockRecorder)
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "Should only delete old secrets whose prefix isn't in use",
+			secrets: []*secretsmanager.SecretListEntry{
+				{Name: aws.String("aws.cluster.x-k8s.io/in-use-0"), CreatedDate: aws.Time(now.Add(-2 * time.Hour)), Tags: clusterTags},
+				{Name: aws.String("aws.cluster.x-k8s.io/orphaned-0"), CreatedDate: aws.Time(now.Add(-2 * time.Hour)), Tags: clusterTags},
+				{Name: aws.String("aws.cluster.x-k8s.io/orphaned-1"), CreatedDate: aws.Time(now.Add(-2 * time.Hour)), Tags: clusterTags},
+				{Name: aws.String("aws.cluster.x-k8s.io/recent-0"), CreatedDate: aws.Time(now), Tags: clusterTags},
+				{
+					Name:        aws.String("aws.cluster.x-k8s.io/other-cluster-0"),
+					CreatedDate: aws.Time(now.Add(-2 * time.Hour)),
+					Tags: []*secretsmanager.Tag{
+						{Key: aws.String(infrav1.ClusterTagKey("test-other")), Value: aws.String("owned")},
+					},
+				},
+				{
+					Name:        aws.String("aws.cluster.x-k8s.io/other-namespace-0"),
+					CreatedDate: aws.Time(now.Add(-2 * time.Hour)),
+					Tags: []*secretsmanager.Tag{
+						{Key: aws.String(infrav1.ClusterTagKey("test")), Value: aws.String("owned")},
+						{Key: aws.String(infrav1.NameAWSClusterNamespace), Value: aws.String("other")},
+					},
+				},
+				{
+					Name:        aws.String("aws.cluster.x-k8s.io/untagged-namespace-0"),
+					CreatedDate: aws.Time(now.Add(-2 * time.Hour)),
+					Tags: []*secretsmanager.Tag{
+						{Key: aws.String(infrav1.ClusterTagKey("test")), Value: aws.String("owned")},
+					},
+				},
+			},
+			expect: func(m *mock_secretsmanageriface.MockSecretsManagerAPIMockRecorder) {
+				m.DeleteSecret(gomock.Eq(&secretsmanager.DeleteSecretInput{
+					SecretId:                   aws.String("aws.cluster.x-k8s.io/orphaned-0"),
+					ForceDeleteWithoutRecovery: aws.Bool(true),
+				})).Return(&secretsmanager.DeleteSecretOutput{}, nil)
+				m.DeleteSecret(gomock.Eq(&secretsmanager.DeleteSecretInput{
+					SecretId:                   aws.String("aws.cluster.x-k8s.io/orphaned-1"),
+					ForceDeleteWithoutRecovery: aws.Bool(true),
+				})).Return(&secretsmanager.DeleteSecretOutput{}, nil)
+			},
+			want: []string{"aws.cluster.x-k8s.io/orphaned-0", "aws.cluster.x-k8s.io/orphaned-1"},
+		},
+		{
+			name: "Should return the secrets deleted along with the errors",
+			secrets: []*secretsmanager.SecretListEntry{
+				{Name: aws.String("aws.cluster.x-k8s.io/orphaned-0"), CreatedDate: aws.Time(now.Add(-2 * time.Hour)), Tags: clusterTags},
+				{Name: aws.String("aws.cluster.x-k8s.io/orphaned-1"), CreatedDate: aws.Time(now.Add(-2 * time.Hour)), Tags: clusterTags},
+			},
+			expect: func(m *mock_secretsmanageriface.MockSecretsManagerAPIMockRecorder) {
+				m.DeleteSecret(gomock.Eq(&secretsmanager.DeleteSecretInput{
+					SecretId:                   aws.String("aws.cluster.x-k8s.io/orphaned-0"),
+					ForceDeleteWithoutRecovery: aws.Bool(true),
+				})).Return(nil, awserrors.NewConflict("new conflict"))
+				m.DeleteSecret(gomock.Eq(&secretsmanager.DeleteSecretInput{
+					SecretId:                   aws.String("aws.cluster.x-k8s.io/orphaned-1"),
+					ForceDeleteWithoutRecovery: aws.Bool(true),
+				})).Return(&secretsmanager.DeleteSecretOutput{}, nil)
+			},
+			want:    []string{"aws.cluster.x-k8s.io/orphaned-1"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			client := setupClient()
+			clusterScope, err := getClusterScope(client)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			secretManagerClientMock := mock_secretsmanageriface.NewMockSecretsManagerAPI(mockCtrl)
+			secretManagerClientMock.EXPECT().ListSecretsPages(gomock.Eq(listInput), gomock.Any()).
+				DoAndReturn(func(_ *secretsmanager.ListSecretsInput, fn func(*secretsmanager.ListSecretsOutput, bool) bool) error {
+					fn(&secretsmanager.ListSecretsOutput{SecretList: tt.secrets}, true)
+					return nil
+				})
+			tt.expect(secretManagerClientMock.EXPECT())
+			s := NewService(clusterScope)
+			s.SecretsManagerClient = secretManagerClientMock
+
+			deleted, err := s.DeleteOrphanedSecrets([]string{"aws.cluster.x-k8s.io/in-use"}, createdBefore)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(deleted).To(Equal(tt.want))
+		})
+	}
+}
+
 func setupClient() client.Client {
 	scheme := runtime.NewScheme()
 	_ = infrav1.AddToScheme(scheme)
@@ -267,7 +409,8 @@ func setupClient() client.Client {
 func getClusterScope(client client.Client) (*scope.ClusterScope, error) {
 	cluster := &clusterv1.Cluster{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: "test",
+			Name:      "test",
+			Namespace: "default",
 		},
 	}
 	return scope.NewClusterScope(scope.ClusterScopeParams{
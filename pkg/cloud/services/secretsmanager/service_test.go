@@ -27,7 +27,7 @@ import (
 func TestUserData(t *testing.T) {
 	service := Service{}
 	endpoints := []scope.ServiceEndpoint{}
-	doc, _ := service.UserData("secretARN", 1, "eu-west-1", endpoints)
+	doc, _ := service.UserData("secretARN", 1, "eu-west-1", "", endpoints)
 
 	if _, err := mail.ReadMessage(bytes.NewBuffer(doc)); err != nil {
 		t.Fatalf("Cannot parse MIME doc: %+v\n%s", err, string(doc))
@@ -43,7 +43,7 @@ func TestUserDataEndpoints(t *testing.T) {
 			ServiceID:     "secretsmanager",
 		},
 	}
-	doc, _ := service.UserData("secretARN", 1, "eu-west-1", endpoints)
+	doc, _ := service.UserData("secretARN", 1, "eu-west-1", "", endpoints)
 
 	if _, err := mail.ReadMessage(bytes.NewBuffer(doc)); err != nil {
 		t.Fatalf("Cannot parse MIME doc: %+v\n%s", err, string(doc))
@@ -20,18 +20,23 @@ import (
 	"fmt"
 	"path"
 	"regexp"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/pkg/errors"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/uuid"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/metrics"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/wait"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/internal/bytes"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
 )
 
 const (
@@ -39,6 +44,9 @@ const (
 
 	// max byte size for ssm is 4KB else we cross into the advanced-parameter tier.
 	maxSecretSizeBytes = 4000
+
+	// max byte size for advanced parameters is 8KB.
+	maxAdvancedSecretSizeBytes = 8000
 )
 
 var (
@@ -48,12 +56,13 @@ var (
 	}
 )
 
-// Create stores data in AWS SSM for a given machine, chunking at 4kb per secret. The prefix of the secret
-// ARN and the number of chunks are returned.
+// Create stores data in AWS SSM for a given machine, chunking at 4kb per secret, or 8kb per advanced secret.
+// The prefix of the secret ARN and the number of chunks are returned.
 func (s *Service) Create(m *scope.MachineScope, data []byte) (string, int32, error) {
 	// Build the tags to apply to the secret.
 	additionalTags := m.AdditionalTags()
 	additionalTags[infrav1.ClusterAWSCloudProviderTagKey(s.scope.Name())] = string(infrav1.ResourceLifecycleOwned)
+	additionalTags[infrav1.NameAWSClusterNamespace] = s.scope.Namespace()
 	tags := infrav1.Build(infrav1.BuildParams{
 		ClusterName: s.scope.Name(),
 		Lifecycle:   infrav1.ResourceLifecycleOwned,
@@ -74,12 +83,19 @@ func (s *Service) Create(m *scope.MachineScope, data []byte) (string, int32, err
 		prefix = "/" + prefix
 	}
 
+	maxSize := maxSecretSizeBytes
+	if m.SSMParameterTier() == infrav1.SSMParameterTierAdvanced {
+		maxSize = maxAdvancedSecretSizeBytes
+	}
+
 	// Split the data into chunks and create the secrets on demand.
 	chunks := int32(0)
 	var err error
-	bytes.Split(data, true, maxSecretSizeBytes, func(chunk []byte) {
+	bytes.Split(data, true, maxSize, func(chunk []byte) {
 		name := fmt.Sprintf("%s/%d", prefix, chunks)
-		retryFunc := func() (bool, error) { return s.retryableCreateSecret(name, chunk, tags) }
+		retryFunc := func() (bool, error) {
+			return s.retryableCreateSecret(name, chunk, m.SecureSecretsKMSKeyID(), m.SSMParameterTier(), tags)
+		}
 		// Default timeout is 5 mins, but if SSM has got to the state where the timeout is reached,
 		// makes sense to slow down machine creation until AWS weather improves.
 		if err = wait.WaitForWithRetryable(wait.NewBackoff(), retryFunc, retryableErrors...); err != nil {
@@ -92,13 +108,20 @@ func (s *Service) Create(m *scope.MachineScope, data []byte) (string, int32, err
 }
 
 // retryableCreateSecret is a function to be passed into a waiter. In a separate function for ease of reading.
-func (s *Service) retryableCreateSecret(name string, chunk []byte, tags infrav1.Tags) (bool, error) {
-	_, err := s.SSMClient.PutParameter(&ssm.PutParameterInput{
+func (s *Service) retryableCreateSecret(name string, chunk []byte, kmsKeyID string, tier infrav1.SSMParameterTier, tags infrav1.Tags) (bool, error) {
+	input := &ssm.PutParameterInput{
 		Name:  aws.String(name),
 		Value: aws.String(string(chunk)),
 		Tags:  converters.MapToSSMTags(tags),
 		Type:  aws.String("SecureString"),
-	})
+	}
+	if kmsKeyID != "" {
+		input.KeyId = aws.String(kmsKeyID)
+	}
+	if tier != "" {
+		input.Tier = aws.String(string(tier))
+	}
+	_, err := s.SSMClient.PutParameter(input)
 	if err != nil {
 		return false, err
 	}
@@ -127,3 +150,61 @@ func (s *Service) Delete(m *scope.MachineScope) error {
 
 	return kerrors.NewAggregate(errs)
 }
+
+// DeleteOrphanedSecrets deletes the bootstrap secrets owned by the cluster that were last modified before the given
+// time and whose prefix isn't one of the given prefixes still in use by machines. Only the secrets tagged with the
+// namespace of the cluster are considered, as the prefixes in use are those of the machines in that namespace. The
+// names of the deleted secrets are returned.
+func (s *Service) DeleteOrphanedSecrets(inUsePrefixes []string, createdBefore time.Time) ([]string, error) {
+	inUse := sets.NewString(inUsePrefixes...)
+
+	var orphaned []string
+	input := &ssm.DescribeParametersInput{
+		ParameterFilters: []*ssm.ParameterStringFilter{
+			{
+				Key:    aws.String("Path"),
+				Option: aws.String("Recursive"),
+				Values: aws.StringSlice([]string{"/" + prefixRe.ReplaceAllString(entryPrefix, "")}),
+			},
+			{
+				Key:    aws.String("tag:" + infrav1.ClusterTagKey(s.scope.Name())),
+				Values: aws.StringSlice([]string{string(infrav1.ResourceLifecycleOwned)}),
+			},
+			{
+				Key:    aws.String("tag:" + infrav1.NameAWSClusterNamespace),
+				Values: aws.StringSlice([]string{s.scope.Namespace()}),
+			},
+		},
+	}
+	err := s.SSMClient.DescribeParametersPages(input, func(out *ssm.DescribeParametersOutput, _ bool) bool {
+		for _, parameter := range out.Parameters {
+			if !aws.TimeValue(parameter.LastModifiedDate).Before(createdBefore) {
+				continue
+			}
+			name := aws.StringValue(parameter.Name)
+			if inUse.Has(path.Dir(name)) {
+				continue
+			}
+			orphaned = append(orphaned, name)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to describe bootstrap secrets")
+	}
+
+	var deleted []string
+	var errs []error
+	for _, name := range orphaned {
+		if err := s.forceDeleteSecretEntry(name); err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedDeleteOrphanedSecret", "Failed to delete orphaned bootstrap secret %q: %v", name, err)
+			errs = append(errs, err)
+			continue
+		}
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulDeleteOrphanedSecret", "Deleted orphaned bootstrap secret %q", name)
+		deleted = append(deleted, name)
+	}
+	metrics.RecordOrphanedSecretsDeleted(string(infrav1.SecretBackendSSMParameterStore), len(deleted))
+
+	return deleted, kerrors.NewAggregate(errs)
+}
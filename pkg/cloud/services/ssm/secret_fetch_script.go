@@ -46,6 +46,7 @@ if [ "{{.Endpoint}}" != "" ]; then
 fi
 SECRET_PREFIX="{{.SecretPrefix}}"
 CHUNKS="{{.Chunks}}"
+KMS_KEY_ID="{{.KMSKeyID}}"
 FILE="/etc/secret-userdata.txt"
 FINAL_INDEX=$((CHUNKS - 1))
 
@@ -163,6 +164,9 @@ get_secret_value() {
   set -o nounset
   set -o pipefail
   if [ ${get_return} -ne 0 ]; then
+    if [ "${KMS_KEY_ID}" != "" ] && [[ "${data}" == *KMS* ]]; then
+      log::error "the instance profile may not be allowed to decrypt with KMS key ${KMS_KEY_ID}"
+    fi
     log::error "could not get secret value, deleting secret"
     delete_secrets
     log::error_exit "could not get secret value, but secret was deleted" 1
@@ -174,6 +178,9 @@ get_secret_value() {
 log::info "aws.cluster.x-k8s.io encrypted cloud-init script $0 started"
 log::info "secret prefix: ${SECRET_PREFIX}"
 log::info "secret count: ${CHUNKS}"
+if [ "${KMS_KEY_ID}" != "" ]; then
+  log::info "secret KMS key: ${KMS_KEY_ID}"
+fi
 
 if test -f "${FILE}"; then
   log::info "encrypted userdata already written to disk"
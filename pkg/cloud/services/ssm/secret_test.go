@@ -21,6 +21,7 @@ import (
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ssm"
@@ -76,6 +77,10 @@ func TestServiceCreate(t *testing.T) {
 			Key:   aws.String("kubernetes.io/cluster/test"),
 			Value: aws.String("owned"),
 		},
+		{
+			Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/v2/cluster-namespace"),
+			Value: aws.String("default"),
+		},
 		{
 			Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/v2/cluster/test"),
 			Value: aws.String("owned"),
@@ -90,7 +95,10 @@ func TestServiceCreate(t *testing.T) {
 		name           string
 		bytesCount     int64
 		secretPrefix   string
+		kmsKeyID       string
+		parameterTier  infrav1.SSMParameterTier
 		expectedPrefix string
+		expectedChunks int32
 		wantErr        bool
 		expect         func(m *mock_ssmiface.MockSSMAPIMockRecorder)
 	}{
@@ -119,6 +127,27 @@ func TestServiceCreate(t *testing.T) {
 				)
 			},
 		},
+		{
+			name:           "Should store data in fewer advanced parameters encrypted with the customer managed KMS key",
+			bytesCount:     10000,
+			secretPrefix:   "prefix",
+			kmsKeyID:       "alias/userdata",
+			parameterTier:  infrav1.SSMParameterTierAdvanced,
+			expectedPrefix: "/prefix",
+			expectedChunks: 2,
+			expect: func(m *mock_ssmiface.MockSSMAPIMockRecorder) {
+				m.PutParameter(gomock.AssignableToTypeOf(&ssm.PutParameterInput{})).Times(2).Return(&ssm.PutParameterOutput{}, nil).Do(
+					func(putParameterInput *ssm.PutParameterInput) {
+						if aws.StringValue(putParameterInput.KeyId) != "alias/userdata" {
+							t.Fatalf("KMS key is not as expected: %v", putParameterInput.KeyId)
+						}
+						if aws.StringValue(putParameterInput.Tier) != "Advanced" {
+							t.Fatalf("Tier is not as expected: %v", putParameterInput.Tier)
+						}
+					},
+				)
+			},
+		},
 		{
 			name:           "Should not retry if non-retryable error occurred while storing data in SSM",
 			bytesCount:     10,
@@ -169,10 +198,15 @@ func TestServiceCreate(t *testing.T) {
 			ms, err := getMachineScope(client, clusterScope)
 			g.Expect(err).NotTo(HaveOccurred())
 			ms.SetSecretPrefix(tt.secretPrefix)
+			ms.AWSMachine.Spec.CloudInit.KMSKeyID = tt.kmsKeyID
+			ms.AWSMachine.Spec.CloudInit.SSMParameterTier = tt.parameterTier
 			data := generateBytes(tt.bytesCount)
 
-			prefix, _, err := s.Create(ms, data)
+			prefix, chunks, err := s.Create(ms, data)
 			check(prefix, tt.expectedPrefix, err, tt.wantErr)
+			if tt.expectedChunks != 0 && chunks != tt.expectedChunks {
+				t.Fatalf("Chunks are not as expected, actual: %d, expected: %d", chunks, tt.expectedChunks)
+			}
 		})
 	}
 }
@@ -257,10 +291,109 @@ func TestServiceDelete(t *testing.T) {
 	}
 }
 
+func TestServiceDeleteOrphanedSecrets(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	now := time.Now()
+	createdBefore := now.Add(-time.Hour)
+	describeInput := &ssm.DescribeParametersInput{
+		ParameterFilters: []*ssm.ParameterStringFilter{
+			{
+				Key:    aws.String("Path"),
+				Option: aws.String("Recursive"),
+				Values: aws.StringSlice([]string{"/cluster.x-k8s.io"}),
+			},
+			{
+				Key:    aws.String("tag:" + infrav1.ClusterTagKey("test")),
+				Values: aws.StringSlice([]string{"owned"}),
+			},
+			{
+				Key:    aws.String("tag:" + infrav1.NameAWSClusterNamespace),
+				Values: aws.StringSlice([]string{"default"}),
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		parameters []*ssm.ParameterMetadata
+		expect     func(m *mock_ssmiface.MockSSMAPIMockRecorder)
+		want       []string
+		wantErr    bool
+	}{
+		{
+			name: "Should only delete old secrets whose prefix isn't in use",
+			parameters: []*ssm.ParameterMetadata{
+				{Name: aws.String("/cluster.x-k8s.io/in-use/0"), LastModifiedDate: aws.Time(now.Add(-2 * time.Hour))},
+				{Name: aws.String("/cluster.x-k8s.io/orphaned/0"), LastModifiedDate: aws.Time(now.Add(-2 * time.Hour))},
+				{Name: aws.String("/cluster.x-k8s.io/orphaned/1"), LastModifiedDate: aws.Time(now.Add(-2 * time.Hour))},
+				{Name: aws.String("/cluster.x-k8s.io/recent/0"), LastModifiedDate: aws.Time(now)},
+			},
+			expect: func(m *mock_ssmiface.MockSSMAPIMockRecorder) {
+				m.DeleteParameter(gomock.Eq(&ssm.DeleteParameterInput{
+					Name: aws.String("/cluster.x-k8s.io/orphaned/0"),
+				})).Return(&ssm.DeleteParameterOutput{}, nil)
+				m.DeleteParameter(gomock.Eq(&ssm.DeleteParameterInput{
+					Name: aws.String("/cluster.x-k8s.io/orphaned/1"),
+				})).Return(&ssm.DeleteParameterOutput{}, nil)
+			},
+			want: []string{"/cluster.x-k8s.io/orphaned/0", "/cluster.x-k8s.io/orphaned/1"},
+		},
+		{
+			name: "Should return the secrets deleted along with the errors",
+			parameters: []*ssm.ParameterMetadata{
+				{Name: aws.String("/cluster.x-k8s.io/orphaned/0"), LastModifiedDate: aws.Time(now.Add(-2 * time.Hour))},
+				{Name: aws.String("/cluster.x-k8s.io/orphaned/1"), LastModifiedDate: aws.Time(now.Add(-2 * time.Hour))},
+			},
+			expect: func(m *mock_ssmiface.MockSSMAPIMockRecorder) {
+				m.DeleteParameter(gomock.Eq(&ssm.DeleteParameterInput{
+					Name: aws.String("/cluster.x-k8s.io/orphaned/0"),
+				})).Return(nil, awserrors.NewConflict("new conflict"))
+				m.DeleteParameter(gomock.Eq(&ssm.DeleteParameterInput{
+					Name: aws.String("/cluster.x-k8s.io/orphaned/1"),
+				})).Return(&ssm.DeleteParameterOutput{}, nil)
+			},
+			want:    []string{"/cluster.x-k8s.io/orphaned/1"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+			clusterScope, err := getClusterScope(client)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			ssmClientMock := mock_ssmiface.NewMockSSMAPI(mockCtrl)
+			ssmClientMock.EXPECT().DescribeParametersPages(gomock.Eq(describeInput), gomock.Any()).
+				DoAndReturn(func(_ *ssm.DescribeParametersInput, fn func(*ssm.DescribeParametersOutput, bool) bool) error {
+					fn(&ssm.DescribeParametersOutput{Parameters: tt.parameters}, true)
+					return nil
+				})
+			tt.expect(ssmClientMock.EXPECT())
+			s := NewService(clusterScope)
+			s.SSMClient = ssmClientMock
+
+			deleted, err := s.DeleteOrphanedSecrets([]string{"/cluster.x-k8s.io/in-use"}, createdBefore)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(deleted).To(Equal(tt.want))
+		})
+	}
+}
+
 func getClusterScope(client client.Client) (*scope.ClusterScope, error) {
 	cluster := &clusterv1.Cluster{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: "test",
+			Name:      "test",
+			Namespace: "default",
 		},
 	}
 	return scope.NewClusterScope(scope.ClusterScopeParams{
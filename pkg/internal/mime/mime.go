@@ -49,13 +49,14 @@ type scriptVariables struct {
 	SecretPrefix string
 	Chunks       int32
 	Region       string
+	KMSKeyID     string
 	Endpoint     string
 }
 
 // GenerateInitDocument renders a given template, applies MIME properties
 // and returns a series of byte chunks which put together represent a UserData
 // script.
-func GenerateInitDocument(secretPrefix string, chunks int32, region string, endpoint string, secretFetchScript string) ([]byte, error) {
+func GenerateInitDocument(secretPrefix string, chunks int32, region string, kmsKeyID string, endpoint string, secretFetchScript string) ([]byte, error) {
 	var secretFetchTemplate = template.Must(template.New("secret-fetch-script").Parse(secretFetchScript))
 
 	var buf bytes.Buffer
@@ -70,6 +71,7 @@ func GenerateInitDocument(secretPrefix string, chunks int32, region string, endp
 		SecretPrefix: secretPrefix,
 		Chunks:       chunks,
 		Region:       region,
+		KMSKeyID:     kmsKeyID,
 		Endpoint:     endpoint,
 	}
 